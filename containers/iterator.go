@@ -37,7 +37,7 @@ type IteratorWithIndex interface {
 }
 
 // IteratorWithKey is a stateful iterator for ordered containers whose elements are key value pairs.
-type IteratorWithKey[K, V comparable] interface {
+type IteratorWithKey[K, V any] interface {
 	// Next moves the iterator to the next element and returns true if there was a next element in the container.
 	// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
 	// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
@@ -108,7 +108,7 @@ type ReverseIteratorWithIndex interface {
 // Prev() function to enable traversal in reverse
 //
 // Last() function to move the iterator to the last element.
-type ReverseIteratorWithKey[K, V comparable] interface {
+type ReverseIteratorWithKey[K, V any] interface {
 	// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
 	// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
 	// Modifies the state of the iterator.
@@ -131,3 +131,19 @@ type ReverseIteratorWithKey[K, V comparable] interface {
 
 	IteratorWithKey[K, V]
 }
+
+// ForwardIteratorWithKey is the minimal forward-only iterator over key value pairs.
+// It is satisfied by the key iterators of all ordered maps and trees and is used to feed bulk loaders.
+type ForwardIteratorWithKey[K, V any] interface {
+	// Next moves the iterator to the next element and returns true if there was a next element in the container.
+	// Modifies the state of the iterator.
+	Next() bool
+
+	// Value returns the current element's value.
+	// Does not modify the state of the iterator.
+	Value() V
+
+	// Key returns the current element's key.
+	// Does not modify the state of the iterator.
+	Key() K
+}
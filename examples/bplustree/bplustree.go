@@ -0,0 +1,43 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/trees/bplustree"
+)
+
+// BPlusTreeExample to demonstrate basic usage of BPlusTree
+func main() {
+	tree := bplustree.NewWithIntComparator[string](4) // empty (keys are of type int)
+
+	tree.Put(1, "a") // 1->a
+	tree.Put(2, "b") // 1->a, 2->b (in order)
+	tree.Put(3, "c") // 1->a, 2->b, 3->c (in order)
+	tree.Put(4, "d") // 1->a, 2->b, 3->c, 4->d (in order)
+	tree.Put(5, "e") // 1->a, 2->b, 3->c, 4->d, 5->e (in order)
+
+	fmt.Println(tree)
+	// BPlusTree
+	//     1
+	//     2
+	// [3]
+	//     3
+	//     4
+	//     5
+
+	_, _ = tree.Get(3) // c, true
+	tree.Remove(1)     // 2->b, 3->c, 4->d, 5->e (in order)
+
+	// Range scan over [2, 4)
+	for it := tree.Range(2, 4); it.Next(); {
+		fmt.Println(it.Key(), it.Value()) // 2 b, 3 c
+	}
+
+	// Cursor positioned before the first key >= 4
+	it := tree.Seek(4)
+	it.Next() // 4->d
+	it.Prev() // 3->c
+}
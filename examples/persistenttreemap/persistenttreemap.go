@@ -0,0 +1,30 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/maps/persistenttreemap"
+)
+
+// PersistentTreeMapExample to demonstrate basic usage of PersistentTreeMap
+func main() {
+	v0 := persistenttreemap.NewWithIntComparator[string]() // empty
+	v1 := v0.Put(1, "x")                                   // 1->x
+	v2 := v1.Put(2, "b").Put(1, "a")                       // 1->a, 2->b (in order)
+	v3 := v2.Remove(1)                                     // 2->b
+
+	fmt.Println(v1) // map[1:x] (older versions are unchanged)
+	fmt.Println(v2) // map[1:a 2:b]
+	fmt.Println(v3) // map[2:b]
+
+	// Every version can be shared with other goroutines without locking
+	done := make(chan bool)
+	go func(snapshot *persistenttreemap.Map[int, string]) {
+		_, _ = snapshot.Get(2) // b, true
+		done <- true
+	}(v2)
+	<-done
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/trees/radixtree"
+)
+
+// RadixTreeExample to demonstrate basic usage of RadixTree
+func main() {
+	tree := radixtree.New[string]() // empty
+	tree.Put("/", "index")          // /->index
+	tree.Put("/api", "api")         // /->index, /api->api (in order)
+	tree.Put("/api/users", "users") // /->index, /api->api, /api/users->users (in order)
+	tree.Put("/about", "about")     // /->index, /about->about, /api->api, /api/users->users (in order)
+
+	fmt.Println(tree.Get("/api"))                    // api true
+	fmt.Println(tree.LongestPrefix("/api/users/42")) // /api/users users true
+	fmt.Println(tree.LongestPrefix("/contact"))      // / index true
+
+	tree.WalkPrefix("/a", func(key string, value string) bool {
+		fmt.Println(key, value) // /about about, /api api, /api/users users
+		return true
+	})
+
+	fmt.Println(tree.DeletePrefix("/api")) // 2
+	fmt.Println(tree.Keys())               // [/ /about]
+	fmt.Println(tree)
+	// RadixTree
+	// /: index
+	//     about: about
+}
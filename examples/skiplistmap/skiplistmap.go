@@ -0,0 +1,33 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/maps/skiplistmap"
+	"github.com/kcswag/kcgods/utils"
+)
+
+// SkipListMapExample to demonstrate basic usage of SkipListMap
+func main() {
+	m := skiplistmap.NewWithLevels[int, string](utils.IntComparator, 0.5, 16) // empty
+	m.Seed(1)                                                                 // deterministic levels
+	m.Put(3, "c")                                                             // 3->c
+	m.Put(1, "x")                                                             // 1->x, 3->c (in order)
+	m.Put(2, "b")                                                             // 1->x, 2->b, 3->c (in order)
+	m.Put(1, "a")                                                             // 1->a, 2->b, 3->c (in order)
+
+	fmt.Println(m.Get(2))     // b true
+	fmt.Println(m.GetAt(0))   // 1 a true
+	fmt.Println(m.IndexOf(3)) // 2
+	fmt.Println(m.Ceiling(2)) // 2 b
+	for it := m.Range(2, 4); it.Next(); {
+		fmt.Println(it.Key(), it.Value()) // 2 b, 3 c
+	}
+
+	m.Remove(1)    // 2->b, 3->c
+	fmt.Println(m) // map[2:b 3:c]
+	m.Clear()      // empty
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/sets/skiplistset"
+)
+
+// SkipListSetExample to demonstrate basic usage of SkipListSet
+func main() {
+	set := skiplistset.NewWithIntComparator() // empty
+	set.Seed(1)                               // deterministic levels
+	set.Add(1)                                // 1
+	set.Add(2, 2, 3, 4, 5)                    // 1, 2, 3, 4, 5 (in order, duplicates ignored)
+	set.Remove(4)                             // 1, 2, 3, 5 (in order)
+
+	fmt.Println(set.Contains(1, 5)) // true
+	fmt.Println(set.Get(1))         // 2 true
+	fmt.Println(set.IndexOf(5))     // 3
+	fmt.Println(set.Floor(4))       // 3 true
+	for it := set.Range(2, 5); it.Next(); {
+		fmt.Println(it.Value()) // 2, 3
+	}
+
+	set.Clear() // empty
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dot escapes strings for the labels of graphs written in the Graphviz DOT language by the ToDOT methods
+// of the trees.
+//
+// Reference: https://graphviz.org/doc/info/lang.html
+package dot
+
+import "strings"
+
+var (
+	labelReplacer  = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	recordReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "|", `\|`, "{", `\{`, "}", `\}`, "<", `\<`, ">", `\>`)
+)
+
+// Escape escapes the string to be used within a quoted label.
+func Escape(s string) string {
+	return labelReplacer.Replace(s)
+}
+
+// EscapeRecord escapes the string to be used as a field within a quoted record label, i.e. it also escapes the
+// characters that delimit the fields and ports of a record.
+func EscapeRecord(s string) string {
+	return recordReplacer.Replace(s)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dot
+
+import (
+	"testing"
+)
+
+func TestEscape(t *testing.T) {
+	tests := [][]string{
+		{"plain", "plain"},
+		{`a"b`, `a\"b`},
+		{`a\b`, `a\\b`},
+		{"a\nb", `a\nb`},
+		{"a|{b}<c>", "a|{b}<c>"},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := Escape(test[0]), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestEscapeRecord(t *testing.T) {
+	tests := [][]string{
+		{"plain", "plain"},
+		{`a"b\c`, `a\"b\\c`},
+		{"a\nb", `a\nb`},
+		{"a|{b}<c>", `a\|\{b\}\<c\>`},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := EscapeRecord(test[0]), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package owner tells whether handles, e.g. the nodes returned by the Insert methods of heaps, still belong to
+// the container that is given them back.
+//
+// A container hands out its current owner with every handle and replaces the owner when all handles become stale,
+// e.g. on Clear. Moving all elements of a container into another one in place, e.g. on Meld, forwards the owner of
+// the emptied container so that the handles stay valid without being visited.
+//
+// Structure is not thread safe.
+package owner
+
+// Owner identifies the container that handles belong to.
+type Owner struct {
+	next *Owner // owner this one has been forwarded to, if any
+}
+
+// Forward makes the owner resolve to the target owner, which must not resolve to this owner.
+func (owner *Owner) Forward(target *Owner) {
+	owner.next = target
+}
+
+// Resolve returns the owner this owner has been forwarded to, possibly over several hops, or the owner itself.
+// Resolving a nil owner returns nil.
+func (owner *Owner) Resolve() *Owner {
+	if owner == nil {
+		return nil
+	}
+	root := owner
+	for root.next != nil {
+		root = root.next
+	}
+	// Shorten the path for later calls
+	for owner != root {
+		owner.next, owner = root, owner.next
+	}
+	return root
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package owner
+
+import "testing"
+
+func TestOwnerResolve(t *testing.T) {
+	a, b, c := &Owner{}, &Owner{}, &Owner{}
+	if actualValue := a.Resolve(); actualValue != a {
+		t.Errorf("Got %p expected %p", actualValue, a)
+	}
+	a.Forward(b)
+	b.Forward(c)
+	if actualValue := a.Resolve(); actualValue != c {
+		t.Errorf("Got %p expected %p", actualValue, c)
+	}
+	if actualValue := a.next; actualValue != c {
+		t.Errorf("Got %p expected %p", actualValue, c)
+	}
+	if actualValue := (*Owner)(nil).Resolve(); actualValue != nil {
+		t.Errorf("Got %p expected %v", actualValue, nil)
+	}
+}
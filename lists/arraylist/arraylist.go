@@ -11,8 +11,9 @@ package arraylist
 
 import (
 	"fmt"
+	"github.com/kcswag/kcgods/containers"
 	"github.com/kcswag/kcgods/utils"
-	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -20,6 +21,8 @@ import (
 type List[T any] struct {
 	elements []T
 	size     int
+	modCount int             // number of changes to the size or the backing array, which invalidate the views of the list
+	equals   utils.Equals[T] // utils.DefaultEquals unless given, nil only for the zero value
 }
 
 const (
@@ -29,7 +32,27 @@ const (
 
 // New instantiates a new list and adds the passed values, if any, to the list
 func New[T any](values ...T) *List[T] {
-	list := &List[T]{}
+	list := &List[T]{equals: utils.DefaultEquals[T]()}
+	if len(values) > 0 {
+		list.Add(values...)
+	}
+	return list
+}
+
+// NewFromSlice instantiates a new list that holds the values of the slice in O(1), using the slice (including its
+// spare capacity) as its backing array instead of copying it.
+// The list takes ownership of the slice, i.e. the slice must not be used afterwards.
+func NewFromSlice[T any](values []T) *List[T] {
+	return &List[T]{elements: values[:cap(values)], size: len(values), equals: utils.DefaultEquals[T]()}
+}
+
+// NewWithEquals instantiates a new list that compares elements with the equality function, e.g. in Contains and
+// IndexOf, and adds the passed values, if any, to the list.
+func NewWithEquals[T any](equals utils.Equals[T], values ...T) *List[T] {
+	if equals == nil {
+		equals = utils.DefaultEquals[T]()
+	}
+	list := &List[T]{equals: equals}
 	if len(values) > 0 {
 		list.Add(values...)
 	}
@@ -43,6 +66,12 @@ func (list *List[T]) Add(values ...T) {
 		list.elements[list.size] = value
 		list.size++
 	}
+	list.modCount++
+}
+
+// AddAll appends all elements of the container in the order of its Values.
+func (list *List[T]) AddAll(container containers.Container[T]) {
+	list.Add(container.Values()...)
 }
 
 // Get returns the element at index.
@@ -65,31 +94,91 @@ func (list *List[T]) Remove(index int) {
 	list.elements[index] = *new(T)                                // cleanup reference
 	copy(list.elements[index:], list.elements[index+1:list.size]) // shift to the left by one (slow operation, need ways to optimize this)
 	list.size--
+	list.modCount++
 
 	list.shrink()
 }
 
+// RemoveRange removes the elements from index from (inclusive) to index to (exclusive) in a single pass.
+// Does not do anything if the range is not within bounds of the list.
+func (list *List[T]) RemoveRange(from, to int) {
+	if from < 0 || to > list.size || from >= to {
+		return
+	}
+	copy(list.elements[from:], list.elements[to:list.size])
+	list.clearFrom(list.size - (to - from))
+	list.modCount++
+	list.shrink()
+}
+
+// RemoveIf removes all elements for which the given function returns a true value in a single pass,
+// keeping the order of the remaining elements, and returns the number of removed elements.
+// The function is passed the index the element had before any elements were removed.
+func (list *List[T]) RemoveIf(f func(index int, value T) bool) int {
+	size := 0
+	for index, value := range list.elements[:list.size] {
+		if !f(index, value) {
+			list.elements[size] = value
+			size++
+		}
+	}
+	removed := list.size - size
+	list.clearFrom(size)
+	list.modCount++
+	list.shrink()
+	return removed
+}
+
+// RetainAll removes all elements that are not contained in the container and returns the number of removed elements.
+// Containers with a Contains method (e.g. lists and sets) are asked for every element, otherwise the elements
+// are compared with the values of the container by the equality of the list.
+func (list *List[T]) RetainAll(container containers.Container[T]) int {
+	var contains func(value T) bool
+	if c, ok := container.(interface{ Contains(values ...T) bool }); ok {
+		contains = func(value T) bool {
+			return c.Contains(value)
+		}
+	} else {
+		values, equals := container.Values(), list.equalsFunc()
+		contains = func(value T) bool {
+			for _, element := range values {
+				if equals(element, value) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return list.RemoveIf(func(index int, value T) bool {
+		return !contains(value)
+	})
+}
+
 // Contains checks if elements (one or more) are present in the set.
 // All elements have to be present in the set for the method to return true.
 // Performance time complexity of n^2.
 // Returns true if no arguments are passed at all, i.e. set is always super-set of empty set.
 func (list *List[T]) Contains(values ...T) bool {
-
+	equals := list.equalsFunc()
 	for _, searchValue := range values {
-		found := false
-		for index := 0; index < list.size; index++ {
-			if reflect.DeepEqual(list.elements[index], searchValue) {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if list.IndexOfWith(searchValue, equals) < 0 {
 			return false
 		}
 	}
 	return true
 }
 
+// RemoveValue removes the first occurrence of the value from the list.
+// Returns true if the value was found and removed.
+func (list *List[T]) RemoveValue(value T) bool {
+	index := list.IndexOf(value)
+	if index < 0 {
+		return false
+	}
+	list.Remove(index)
+	return true
+}
+
 // Values returns all elements in the list.
 func (list *List[T]) Values() []T {
 	newElements := make([]T, list.size, list.size)
@@ -97,19 +186,93 @@ func (list *List[T]) Values() []T {
 	return newElements
 }
 
-//IndexOf returns index of provided element
+// IndexOf returns index of provided element
 func (list *List[T]) IndexOf(value T) int {
-	if list.size == 0 {
-		return -1
+	return list.IndexOfWith(value, list.equalsFunc())
+}
+
+// IndexOfWith returns the index of the first element that is equal to the value under the equality function,
+// or -1 if there is none.
+func (list *List[T]) IndexOfWith(value T, equals utils.Equals[T]) int {
+	for index, element := range list.elements[:list.size] {
+		if equals(element, value) {
+			return index
+		}
 	}
-	for index, element := range list.elements {
-		if reflect.DeepEqual(element, value) {
+	return -1
+}
+
+// LastIndexOf returns the index of the last occurrence of the value, or -1 if there is none.
+func (list *List[T]) LastIndexOf(value T) int {
+	return list.LastIndexOfWith(value, list.equalsFunc())
+}
+
+// LastIndexOfWith returns the index of the last element that is equal to the value under the equality function,
+// or -1 if there is none.
+func (list *List[T]) LastIndexOfWith(value T, equals utils.Equals[T]) int {
+	for index := list.size - 1; index >= 0; index-- {
+		if equals(list.elements[index], value) {
 			return index
 		}
 	}
 	return -1
 }
 
+// BinarySearch searches the value in the list sorted by the comparator in O(log n).
+// Returns the index of the first element equal to the value and true, or the index at which the value would have
+// to be inserted to keep the list sorted and false.
+func (list *List[T]) BinarySearch(value T, comparator utils.Comparator) (index int, found bool) {
+	index = sort.Search(list.size, func(i int) bool {
+		return comparator(list.elements[i], value) >= 0
+	})
+	return index, index < list.size && comparator(list.elements[index], value) == 0
+}
+
+// InsertSorted inserts the value into the list sorted by the comparator after all elements equal to it,
+// and returns its index.
+func (list *List[T]) InsertSorted(value T, comparator utils.Comparator) int {
+	index := sort.Search(list.size, func(i int) bool {
+		return comparator(list.elements[i], value) > 0
+	})
+	list.Insert(index, value)
+	return index
+}
+
+// Reverse reverses the order of the elements in-place.
+func (list *List[T]) Reverse() {
+	for i, j := 0, list.size-1; i < j; i, j = i+1, j-1 {
+		list.elements[i], list.elements[j] = list.elements[j], list.elements[i]
+	}
+}
+
+// Fill sets all elements to the value.
+func (list *List[T]) Fill(value T) {
+	for index := range list.elements[:list.size] {
+		list.elements[index] = value
+	}
+}
+
+// EnsureCapacity grows the backing array to hold at least capacity elements without further allocations.
+// Note that removing elements may shrink it again.
+func (list *List[T]) EnsureCapacity(capacity int) {
+	if capacity > cap(list.elements) {
+		list.resize(capacity)
+	}
+}
+
+// TrimToSize shrinks the backing array to the number of elements.
+func (list *List[T]) TrimToSize() {
+	if cap(list.elements) > list.size {
+		list.resize(list.size)
+		list.modCount++
+	}
+}
+
+// Capacity returns the number of elements the backing array can hold.
+func (list *List[T]) Capacity() int {
+	return cap(list.elements)
+}
+
 // Empty returns true if list does not contain any elements.
 func (list *List[T]) Empty() bool {
 	return list.size == 0
@@ -124,6 +287,7 @@ func (list *List[T]) Size() int {
 func (list *List[T]) Clear() {
 	list.size = 0
 	list.elements = []T{}
+	list.modCount++
 }
 
 // Sort sorts values (in-place) using.
@@ -157,6 +321,7 @@ func (list *List[T]) Insert(index int, values ...T) {
 	l := len(values)
 	list.growBy(l)
 	list.size += l
+	list.modCount++
 	copy(list.elements[index+l:], list.elements[index:list.size-l])
 	copy(list.elements[index:], values)
 }
@@ -193,6 +358,24 @@ func (list *List[T]) withinRange(index int) bool {
 	return index >= 0 && index < list.size
 }
 
+// clearFrom removes the elements from the index on, cleaning up their references.
+func (list *List[T]) clearFrom(index int) {
+	var zero T
+	for i := index; i < list.size; i++ {
+		list.elements[i] = zero
+	}
+	list.size = index
+}
+
+// equalsFunc returns the equality of the list, which is utils.DefaultEquals unless the list was given one.
+// Does not store the default in a zero value list, so that reading the list does not write to it.
+func (list *List[T]) equalsFunc() utils.Equals[T] {
+	if list.equals == nil {
+		return utils.DefaultEquals[T]()
+	}
+	return list.equals
+}
+
 func (list *List[T]) resize(cap int) {
 	newElements := make([]T, cap, cap)
 	copy(newElements, list.elements)
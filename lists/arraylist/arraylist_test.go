@@ -39,6 +39,27 @@ func TestListNew(t *testing.T) {
 	}
 }
 
+func TestListNewFromSlice(t *testing.T) {
+	values := make([]string, 2, 4)
+	values[0], values[1] = "a", "b"
+	list := NewFromSlice(values)
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// The slice is the backing array of the list
+	list.Set(0, "x")
+	if actualValue, expectedValue := values[0], "x"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	list.Add("c")
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[x b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := NewFromSlice[int](nil).Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestListAdd(t *testing.T) {
 	list := New[string]()
 	list.Add("a")
@@ -81,6 +102,42 @@ func TestListIndexOf(t *testing.T) {
 	}
 }
 
+func TestListIndexOfZeroValue(t *testing.T) {
+	list := New[int](1, 2, 3)
+	if index := list.IndexOf(0); index != -1 {
+		t.Errorf("Got %v expected %v", index, -1)
+	}
+	if index := list.LastIndexOf(0); index != -1 {
+		t.Errorf("Got %v expected %v", index, -1)
+	}
+}
+
+func TestListLastIndexOf(t *testing.T) {
+	list := New[string]("a", "b", "a", "c")
+	if index := list.LastIndexOf("a"); index != 2 {
+		t.Errorf("Got %v expected %v", index, 2)
+	}
+	if index := list.LastIndexOf("d"); index != -1 {
+		t.Errorf("Got %v expected %v", index, -1)
+	}
+
+	equalFold := func(a, b string) bool {
+		return strings.EqualFold(a, b)
+	}
+	if index := list.IndexOfWith("C", equalFold); index != 3 {
+		t.Errorf("Got %v expected %v", index, 3)
+	}
+	if index := list.IndexOfWith("A", equalFold); index != 0 {
+		t.Errorf("Got %v expected %v", index, 0)
+	}
+	if index := list.LastIndexOfWith("A", equalFold); index != 2 {
+		t.Errorf("Got %v expected %v", index, 2)
+	}
+	if index := list.LastIndexOfWith("D", equalFold); index != -1 {
+		t.Errorf("Got %v expected %v", index, -1)
+	}
+}
+
 func TestListRemove(t *testing.T) {
 	list := New[string]()
 	list.Add("a")
@@ -183,6 +240,86 @@ func TestListContains(t *testing.T) {
 	}
 }
 
+type user struct {
+	id   int
+	name string
+}
+
+func TestListEquals(t *testing.T) {
+	sameID := func(a, b user) bool { return a.id == b.id }
+	list := NewWithEquals[user](sameID, user{1, "a"}, user{2, "b"}, user{3, "c"})
+	if actualValue := list.Contains(user{2, "renamed"}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.Contains(user{4, "a"}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := list.IndexOf(user{id: 3}); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := list.RemoveValue(user{id: 1}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(user{id: 1}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[{2 b} {3 c}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// Derived lists compare the same way
+	selected := list.Select(func(index int, value user) bool { return true })
+	if actualValue := selected.Contains(user{id: 3}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	list.Add(user{4, "d"})
+	if removed := list.RetainAll(container[user]{{id: 2}, {id: 4}}); removed != 1 {
+		t.Errorf("Got %v expected %v", removed, 1)
+	}
+	if actualValue := list.SubList(0, 2).IndexOf(user{id: 4}); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestListZeroValueEquals(t *testing.T) {
+	var list List[int]
+	list.Add(1, 2)
+	if actualValue := list.Contains(2); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.IndexOf(3); actualValue != -1 {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+	// Reading the list must not write to it, e.g. by storing the default equality
+	if list.equals != nil {
+		t.Errorf("Got %v expected %v", "stored equality", nil)
+	}
+}
+
+func TestListRemoveValue(t *testing.T) {
+	list := New[int](1, 2, 1, 0)
+	if actualValue := list.RemoveValue(1); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(0); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(3); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// Pointers are compared by the values they refer to unless the list is given an equality
+	pointers := New[*user](&user{1, "a"})
+	if actualValue := pointers.Contains(&user{1, "a"}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	pointers = NewWithEquals[*user](utils.ComparableEquals[*user], &user{1, "a"})
+	if actualValue := pointers.Contains(&user{1, "a"}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
 func TestListValues(t *testing.T) {
 	list := New[any]()
 	list.Add("a")
@@ -230,6 +367,324 @@ func TestListSet(t *testing.T) {
 	}
 }
 
+func TestListAddAll(t *testing.T) {
+	list := New[string]("a")
+	list.AddAll(New[string]("b", "c"))
+	list.AddAll(list)
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[a b c a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestListRemoveRange(t *testing.T) {
+	list := New[int](0, 1, 2, 3, 4, 5, 6, 7)
+	tests := [][]interface{}{
+		{2, 5, "[0 1 5 6 7]"},
+		{-1, 2, "[0 1 5 6 7]"},
+		{3, 6, "[0 1 5 6 7]"},
+		{3, 2, "[0 1 5 6 7]"},
+		{2, 2, "[0 1 5 6 7]"},
+		{3, 5, "[0 1 5]"},
+		{0, 3, "[]"},
+	}
+	for _, test := range tests {
+		list.RemoveRange(test[0].(int), test[1].(int))
+		if actualValue := fmt.Sprint(list.Values()); actualValue != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[2])
+		}
+	}
+	if actualValue := list.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestListRemoveIf(t *testing.T) {
+	list := New[int](0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	var indices []int
+	removed := list.RemoveIf(func(index int, value int) bool {
+		indices = append(indices, index)
+		return value%3 == 0
+	})
+	if removed != 4 {
+		t.Errorf("Got %v expected %v", removed, 4)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[1 2 4 5 7 8]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(indices), "[0 1 2 3 4 5 6 7 8 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if removed := list.RemoveIf(func(index int, value int) bool { return false }); removed != 0 {
+		t.Errorf("Got %v expected %v", removed, 0)
+	}
+	if removed := list.RemoveIf(func(index int, value int) bool { return true }); removed != 6 {
+		t.Errorf("Got %v expected %v", removed, 6)
+	}
+	if actualValue := list.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestListRemoveIfCleansUp(t *testing.T) {
+	a, b := new(int), new(int)
+	list := New[*int](a, b, a, b)
+	list.EnsureCapacity(4)
+	list.RemoveIf(func(index int, value *int) bool { return value == b })
+	for _, element := range list.elements[list.size:] {
+		if element != nil {
+			t.Errorf("Got %v expected %v", element, nil)
+		}
+	}
+}
+
+// container only implements containers.Container, i.e. has no Contains method.
+type container[T any] []T
+
+func (c container[T]) Empty() bool    { return len(c) == 0 }
+func (c container[T]) Size() int      { return len(c) }
+func (c container[T]) Clear()         {}
+func (c container[T]) Values() []T    { return c }
+func (c container[T]) String() string { return fmt.Sprint([]T(c)) }
+
+func TestListRetainAll(t *testing.T) {
+	list := New[string]("a", "b", "c", "b", "d")
+	if removed := list.RetainAll(New[string]("b", "d", "e")); removed != 2 {
+		t.Errorf("Got %v expected %v", removed, 2)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[b b d]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if removed := list.RetainAll(container[string]{"d"}); removed != 2 {
+		t.Errorf("Got %v expected %v", removed, 2)
+	}
+	if removed := list.RetainAll(list); removed != 0 {
+		t.Errorf("Got %v expected %v", removed, 0)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[d]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if removed := list.RetainAll(New[string]()); removed != 1 {
+		t.Errorf("Got %v expected %v", removed, 1)
+	}
+}
+
+func TestListBinarySearch(t *testing.T) {
+	list := New[int](1, 3, 3, 3, 5, 7)
+	tests := [][]interface{}{
+		{0, 0, false},
+		{1, 0, true},
+		{2, 1, false},
+		{3, 1, true},
+		{5, 4, true},
+		{6, 5, false},
+		{7, 5, true},
+		{8, 6, false},
+	}
+	for _, test := range tests {
+		if index, found := list.BinarySearch(test[0].(int), utils.IntComparator); index != test[1] || found != test[2] {
+			t.Errorf("Got %v, %v expected %v, %v for %v", index, found, test[1], test[2], test[0])
+		}
+	}
+	if index, found := New[int]().BinarySearch(1, utils.IntComparator); index != 0 || found {
+		t.Errorf("Got %v, %v expected %v, %v", index, found, 0, false)
+	}
+}
+
+func TestListInsertSorted(t *testing.T) {
+	type item struct {
+		key  int
+		name string
+	}
+	byKey := func(a, b interface{}) int {
+		return utils.IntComparator(a.(item).key, b.(item).key)
+	}
+	list := New[item]()
+	tests := [][]interface{}{
+		{item{2, "a"}, 0},
+		{item{1, "b"}, 0},
+		{item{3, "c"}, 2},
+		{item{2, "d"}, 2},
+		{item{0, "e"}, 0},
+	}
+	for _, test := range tests {
+		if index := list.InsertSorted(test[0].(item), byKey); index != test[1] {
+			t.Errorf("Got %v expected %v for %v", index, test[1], test[0])
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[{0 e} {1 b} {2 a} {2 d} {3 c}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestListReverse(t *testing.T) {
+	list := New[int]()
+	list.Reverse()
+	for _, values := range [][]int{{1}, {1, 2}, {1, 2, 3}} {
+		list := New[int](values...)
+		list.Reverse()
+		for index, value := range list.Values() {
+			if expectedValue := values[len(values)-1-index]; value != expectedValue {
+				t.Errorf("Got %v expected %v", value, expectedValue)
+			}
+		}
+	}
+}
+
+func TestListFill(t *testing.T) {
+	list := New[string]("a", "b", "c")
+	list.Fill("x")
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[x x x]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := list.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func TestListCapacity(t *testing.T) {
+	list := New[int]()
+	list.EnsureCapacity(100)
+	if actualValue := list.Capacity(); actualValue != 100 {
+		t.Errorf("Got %v expected %v", actualValue, 100)
+	}
+	for n := 0; n < 99; n++ {
+		list.Add(n)
+	}
+	if actualValue := list.Capacity(); actualValue != 100 {
+		t.Errorf("Got %v expected %v", actualValue, 100)
+	}
+	list.EnsureCapacity(10)
+	if actualValue := list.Capacity(); actualValue != 100 {
+		t.Errorf("Got %v expected %v", actualValue, 100)
+	}
+	list.TrimToSize()
+	if actualValue := list.Capacity(); actualValue != 99 {
+		t.Errorf("Got %v expected %v", actualValue, 99)
+	}
+	if actualValue, ok := list.Get(98); actualValue != 98 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 98)
+	}
+}
+
+func TestSubList(t *testing.T) {
+	list := New[int](0, 1, 2, 3, 4, 5)
+	subList := list.SubList(1, 4)
+	if actualValue := subList.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := subList.Get(0); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if _, ok := subList.Get(3); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if actualValue := subList.Contains(1, 3); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := subList.Contains(4); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	// Changes of the list are visible in the view and the other way around
+	list.Set(2, 20)
+	subList.Set(2, 30)
+	if actualValue, expectedValue := fmt.Sprint(subList.Values()), "[1 20 30]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[0 1 20 30 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := []struct {
+		change       func()
+		expectedView string
+		expectedList string
+	}{
+		{func() { subList.Add(7) }, "[1 20 30 7]", "[0 1 20 30 7 4 5]"},
+		{func() { subList.Set(4, 8) }, "[1 20 30 7 8]", "[0 1 20 30 7 8 4 5]"},
+		{func() { subList.Insert(0, 9) }, "[9 1 20 30 7 8]", "[0 9 1 20 30 7 8 4 5]"},
+		{func() { subList.Insert(7, 9) }, "[9 1 20 30 7 8]", "[0 9 1 20 30 7 8 4 5]"},
+		{func() { subList.Remove(1) }, "[9 20 30 7 8]", "[0 9 20 30 7 8 4 5]"},
+		{func() { subList.Remove(5) }, "[9 20 30 7 8]", "[0 9 20 30 7 8 4 5]"},
+		{func() { subList.Swap(0, 4) }, "[8 20 30 7 9]", "[0 8 20 30 7 9 4 5]"},
+		{func() { subList.Sort(utils.IntComparator) }, "[7 8 9 20 30]", "[0 7 8 9 20 30 4 5]"},
+		{func() { subList.Clear() }, "[]", "[0 4 5]"},
+		{func() { subList.Add(1, 2) }, "[1 2]", "[0 1 2 4 5]"},
+	}
+	for _, test := range tests {
+		test.change()
+		if actualValue := fmt.Sprint(subList.Values()); actualValue != test.expectedView {
+			t.Errorf("Got %v expected %v", actualValue, test.expectedView)
+		}
+		if actualValue := fmt.Sprint(list.Values()); actualValue != test.expectedList {
+			t.Errorf("Got %v expected %v", actualValue, test.expectedList)
+		}
+	}
+	if actualValue := subList.IndexOf(2); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, expectedValue := subList.String(), "SubList\n1, 2"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue := list.SubList(5, 5).Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	for _, bounds := range [][]int{{-1, 2}, {2, 6}, {3, 2}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Got no panic for range %v", bounds)
+				}
+			}()
+			list.SubList(bounds[0], bounds[1])
+		}()
+	}
+}
+
+func TestSubListListModified(t *testing.T) {
+	list := New[string]()
+	list.Add("a", "b", "c", "d")
+	subList := list.SubList(1, 3)
+	subList.Add("x")
+	subList.Remove(0)
+	list.Set(0, "z")
+	list.Swap(2, 3)
+	if actualValue, expectedValue := subList.String(), "SubList\nc, d"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	modifications := map[string]func(list *List[string]){
+		"Clear":       func(list *List[string]) { list.Clear() },
+		"Remove, Add": func(list *List[string]) { list.Remove(0); list.Add("y") },
+		"Insert":      func(list *List[string]) { list.Insert(0, "y") },
+		"RemoveIf":    func(list *List[string]) { list.RemoveIf(func(index int, value string) bool { return false }) },
+		"TrimToSize":  func(list *List[string]) { list.Add("y"); list.RemoveRange(4, 5); list.TrimToSize() },
+	}
+	for name, modify := range modifications {
+		list := New[string]("a", "b", "c", "d")
+		subList := list.SubList(1, 3)
+		modify(list)
+		for _, call := range []func(){
+			func() { subList.Values() },
+			func() { subList.Contains("c") },
+			func() { subList.Get(0) },
+			func() { subList.Size() },
+			func() { _ = subList.String() },
+		} {
+			func() {
+				defer func() {
+					r := recover()
+					if message, ok := r.(string); !ok || !strings.HasPrefix(message, "Invalid sublist") {
+						t.Errorf("Got %v expected a panic of the view after %v", r, name)
+					}
+				}()
+				call()
+			}()
+		}
+	}
+}
+
 func TestListEach(t *testing.T) {
 	list := New[string]()
 	list.Add("a", "b", "c")
@@ -358,7 +813,7 @@ func TestListChaining(t *testing.T) {
 }
 
 func TestListIteratorNextOnEmpty(t *testing.T) {
-	list := New()
+	list := New[string]()
 	it := list.Iterator()
 	for it.Next() {
 		t.Errorf("Shouldn't iterate on empty list")
@@ -397,7 +852,7 @@ func TestListIteratorNext(t *testing.T) {
 }
 
 func TestListIteratorPrevOnEmpty(t *testing.T) {
-	list := New()
+	list := New[string]()
 	it := list.Iterator()
 	for it.Prev() {
 		t.Errorf("Shouldn't iterate on empty list")
@@ -514,7 +969,7 @@ func TestListIteratorNextTo(t *testing.T) {
 
 	// NextTo (empty)
 	{
-		list := New()
+		list := New[string]()
 		it := list.Iterator()
 		for it.NextTo(seek) {
 			t.Errorf("Shouldn't iterate on empty list")
@@ -563,7 +1018,7 @@ func TestListIteratorPrevTo(t *testing.T) {
 
 	// PrevTo (empty)
 	{
-		list := New()
+		list := New[string]()
 		it := list.Iterator()
 		it.End()
 		for it.PrevTo(seek) {
@@ -650,6 +1105,14 @@ func TestListString(t *testing.T) {
 	}
 }
 
+func benchmarkIndexOf(b *testing.B, list *List[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n += size / 10 {
+			list.IndexOf(n)
+		}
+	}
+}
+
 func benchmarkGet(b *testing.B, list *List[int], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -802,3 +1265,39 @@ func BenchmarkArrayListRemove100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkRemove(b, list, size)
 }
+
+func BenchmarkArrayListRemoveIf100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	for i := 0; i < b.N; i++ {
+		list := New[int]()
+		for n := 0; n < size; n++ {
+			list.Add(n)
+		}
+		b.StartTimer()
+		list.RemoveIf(func(index int, value int) bool { return value%2 == 0 })
+		b.StopTimer()
+	}
+}
+
+func BenchmarkArrayListIndexOf1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := New[int]()
+	for n := 0; n < size; n++ {
+		list.Add(n)
+	}
+	b.StartTimer()
+	benchmarkIndexOf(b, list, size)
+}
+
+func BenchmarkArrayListIndexOfDeepEquals1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := NewWithEquals[int](utils.DeepEquals[int])
+	for n := 0; n < size; n++ {
+		list.Add(n)
+	}
+	b.StartTimer()
+	benchmarkIndexOf(b, list, size)
+}
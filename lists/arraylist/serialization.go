@@ -22,6 +22,7 @@ func (list *List[T]) FromJSON(data []byte) error {
 	err := json.Unmarshal(data, &list.elements)
 	if err == nil {
 		list.size = len(list.elements)
+		list.modCount++
 	}
 	return err
 }
@@ -0,0 +1,191 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package arraylist
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+)
+
+// Assert List implementation
+//var _ lists.List[int] = (*SubList[int])(nil)
+
+// SubList is a live view of a range of a list. Reading the view reads the list and changing the view,
+// e.g. adding or removing elements, changes the list.
+// Once elements have been added to or removed from the list other than through the view, every method of the view
+// panics, even if the size of the list is the same again.
+type SubList[T any] struct {
+	list     *List[T]
+	offset   int
+	size     int
+	modCount int // modification count of the list as of the last change through the view
+}
+
+// SubList returns a view of the elements from index from (inclusive) to index to (exclusive).
+// Panics if the range is not within bounds of the list.
+func (list *List[T]) SubList(from, to int) *SubList[T] {
+	if from < 0 || to > list.size || from > to {
+		panic("Invalid range, should be within bounds of the list")
+	}
+	return &SubList[T]{list: list, offset: from, size: to - from, modCount: list.modCount}
+}
+
+// Add appends the values at the end of the view, i.e. inserts them into the list after the view.
+func (subList *SubList[T]) Add(values ...T) {
+	subList.checkModCount()
+	subList.list.Insert(subList.offset+subList.size, values...)
+	subList.size += len(values)
+	subList.modCount = subList.list.modCount
+}
+
+// Get returns the element at index of the view.
+// Second return parameter is true if index is within bounds of the view, otherwise false.
+func (subList *SubList[T]) Get(index int) (T, bool) {
+	subList.checkModCount()
+	if !subList.withinRange(index) {
+		return *new(T), false
+	}
+	return subList.list.Get(subList.offset + index)
+}
+
+// Remove removes the element at the given index of the view from the list.
+func (subList *SubList[T]) Remove(index int) {
+	subList.checkModCount()
+	if !subList.withinRange(index) {
+		return
+	}
+	subList.list.Remove(subList.offset + index)
+	subList.size--
+	subList.modCount = subList.list.modCount
+}
+
+// Contains checks if elements (one or more) are present in the view.
+// All elements have to be present in the view for the method to return true.
+// Returns true if no arguments are passed at all, i.e. set is always super-set of empty set.
+func (subList *SubList[T]) Contains(values ...T) bool {
+	subList.checkModCount()
+	for _, value := range values {
+		if subList.IndexOf(value) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexOf returns the index within the view of the first occurrence of the value, or -1 if there is none.
+func (subList *SubList[T]) IndexOf(value T) int {
+	subList.checkModCount()
+	equals := subList.list.equalsFunc()
+	for index, element := range subList.elements() {
+		if equals(element, value) {
+			return index
+		}
+	}
+	return -1
+}
+
+// Values returns all elements in the view.
+func (subList *SubList[T]) Values() []T {
+	subList.checkModCount()
+	values := make([]T, subList.size, subList.size)
+	copy(values, subList.elements())
+	return values
+}
+
+// Empty returns true if the view does not contain any elements.
+func (subList *SubList[T]) Empty() bool {
+	subList.checkModCount()
+	return subList.size == 0
+}
+
+// Size returns number of elements within the view.
+func (subList *SubList[T]) Size() int {
+	subList.checkModCount()
+	return subList.size
+}
+
+// Clear removes all elements of the view from the list.
+func (subList *SubList[T]) Clear() {
+	subList.checkModCount()
+	subList.list.RemoveRange(subList.offset, subList.offset+subList.size)
+	subList.modCount = subList.list.modCount
+	subList.size = 0
+}
+
+// Sort sorts the elements of the view (in-place) within the list.
+func (subList *SubList[T]) Sort(comparator utils.Comparator) {
+	subList.checkModCount()
+	if subList.size < 2 {
+		return
+	}
+	utils.Sort(subList.elements(), comparator)
+}
+
+// Swap swaps the two values at the specified positions of the view.
+func (subList *SubList[T]) Swap(i, j int) {
+	subList.checkModCount()
+	if subList.withinRange(i) && subList.withinRange(j) {
+		subList.list.Swap(subList.offset+i, subList.offset+j)
+	}
+}
+
+// Insert inserts values at specified index position of the view shifting the value at that position (if any)
+// and any subsequent elements to the right.
+// Does not do anything if position is negative or bigger than view's size
+// Note: position equal to view's size is valid, i.e. append.
+func (subList *SubList[T]) Insert(index int, values ...T) {
+	subList.checkModCount()
+	if index < 0 || index > subList.size {
+		return
+	}
+	subList.list.Insert(subList.offset+index, values...)
+	subList.size += len(values)
+	subList.modCount = subList.list.modCount
+}
+
+// Set the value at specified index of the view
+// Does not do anything if position is negative or bigger than view's size
+// Note: position equal to view's size is valid, i.e. append.
+func (subList *SubList[T]) Set(index int, value T) {
+	subList.checkModCount()
+	if !subList.withinRange(index) {
+		// Append
+		if index == subList.size {
+			subList.Add(value)
+		}
+		return
+	}
+	subList.list.Set(subList.offset+index, value)
+}
+
+// String returns a string representation of container
+func (subList *SubList[T]) String() string {
+	subList.checkModCount()
+	str := "SubList\n"
+	values := []string{}
+	for _, value := range subList.elements() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// elements returns the slice of the backing array of the list that holds the elements of the view.
+func (subList *SubList[T]) elements() []T {
+	return subList.list.elements[subList.offset : subList.offset+subList.size]
+}
+
+// Check that the index is within bounds of the view
+func (subList *SubList[T]) withinRange(index int) bool {
+	return index >= 0 && index < subList.size
+}
+
+// Check that no elements have been added to or removed from the list other than through the view
+func (subList *SubList[T]) checkModCount() {
+	if subList.list.modCount != subList.modCount {
+		panic("Invalid sublist, the list has been modified other than through the view")
+	}
+}
@@ -14,7 +14,7 @@ import (
 )
 
 func TestListNew(t *testing.T) {
-	list1 := New()
+	list1 := New[any]()
 
 	if actualValue := list1.Empty(); actualValue != true {
 		t.Errorf("Got %v expected %v", actualValue, true)
@@ -175,6 +175,79 @@ func TestListContains(t *testing.T) {
 	}
 }
 
+type user struct {
+	id   int
+	name string
+}
+
+func TestListEquals(t *testing.T) {
+	sameID := func(a, b user) bool { return a.id == b.id }
+	list := NewWithEquals[user](sameID, user{1, "a"}, user{2, "b"}, user{3, "c"})
+	if actualValue := list.Contains(user{2, "renamed"}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.Contains(user{4, "a"}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := list.IndexOf(user{id: 3}); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := list.RemoveValue(user{id: 1}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(user{id: 1}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[{2 b} {3 c}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// Derived lists compare the same way
+	selected := list.Select(func(index int, value user) bool { return true })
+	if actualValue := selected.Contains(user{id: 3}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestListZeroValueEquals(t *testing.T) {
+	var list List[int]
+	list.Add(1, 2)
+	if actualValue := list.Contains(2); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.IndexOf(3); actualValue != -1 {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+	// Reading the list must not write to it, e.g. by storing the default equality
+	if list.equals != nil {
+		t.Errorf("Got %v expected %v", "stored equality", nil)
+	}
+}
+
+func TestListRemoveValue(t *testing.T) {
+	list := New[int](1, 2, 1, 0)
+	if actualValue := list.RemoveValue(1); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(0); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(3); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// Pointers are compared by the values they refer to unless the list is given an equality
+	pointers := New[*user](&user{1, "a"})
+	if actualValue := pointers.Contains(&user{1, "a"}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	pointers = NewWithEquals[*user](utils.ComparableEquals[*user], &user{1, "a"})
+	if actualValue := pointers.Contains(&user{1, "a"}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
 func TestListValues(t *testing.T) {
 	list := New[any]()
 	list.Add("a")
@@ -382,7 +455,7 @@ func TestListChaining(t *testing.T) {
 }
 
 func TestListIteratorNextOnEmpty(t *testing.T) {
-	list := New()
+	list := New[string]()
 	it := list.Iterator()
 	for it.Next() {
 		t.Errorf("Shouldn't iterate on empty list")
@@ -421,7 +494,7 @@ func TestListIteratorNext(t *testing.T) {
 }
 
 func TestListIteratorPrevOnEmpty(t *testing.T) {
-	list := New()
+	list := New[string]()
 	it := list.Iterator()
 	for it.Prev() {
 		t.Errorf("Shouldn't iterate on empty list")
@@ -538,7 +611,7 @@ func TestListIteratorNextTo(t *testing.T) {
 
 	// NextTo (empty)
 	{
-		list := New()
+		list := New[string]()
 		it := list.Iterator()
 		for it.NextTo(seek) {
 			t.Errorf("Shouldn't iterate on empty list")
@@ -674,6 +747,14 @@ func TestListString(t *testing.T) {
 	}
 }
 
+func benchmarkIndexOf(b *testing.B, list *List[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n += size / 10 {
+			list.IndexOf(n)
+		}
+	}
+}
+
 func benchmarkGet(b *testing.B, list *List[int], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -826,3 +907,25 @@ func BenchmarkDoublyLinkedListRemove100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkRemove(b, list, size)
 }
+
+func BenchmarkDoublyLinkedListIndexOf1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := New[int]()
+	for n := 0; n < size; n++ {
+		list.Add(n)
+	}
+	b.StartTimer()
+	benchmarkIndexOf(b, list, size)
+}
+
+func BenchmarkDoublyLinkedListIndexOfDeepEquals1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := NewWithEquals[int](utils.DeepEquals[int])
+	for n := 0; n < size; n++ {
+		list.Add(n)
+	}
+	b.StartTimer()
+	benchmarkIndexOf(b, list, size)
+}
@@ -11,7 +11,6 @@ package singlylinkedlist
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 
 	"github.com/kcswag/kcgods/utils"
@@ -19,9 +18,10 @@ import (
 
 // List holds the elements, where each element points to the next element
 type List[T any] struct {
-	first *element[T]
-	last  *element[T]
-	size  int
+	first  *element[T]
+	last   *element[T]
+	size   int
+	equals utils.Equals[T] // utils.DefaultEquals unless given, nil only for the zero value
 }
 
 type element[T any] struct {
@@ -31,7 +31,20 @@ type element[T any] struct {
 
 // New instantiates a new list and adds the passed values, if any, to the list
 func New[T any](values ...T) *List[T] {
-	list := &List[T]{}
+	list := &List[T]{equals: utils.DefaultEquals[T]()}
+	if len(values) > 0 {
+		list.Add(values...)
+	}
+	return list
+}
+
+// NewWithEquals instantiates a new list that compares elements with the equality function, e.g. in Contains and
+// IndexOf, and adds the passed values, if any, to the list
+func NewWithEquals[T any](equals utils.Equals[T], values ...T) *List[T] {
+	if equals == nil {
+		equals = utils.DefaultEquals[T]()
+	}
+	list := &List[T]{equals: equals}
 	if len(values) > 0 {
 		list.Add(values...)
 	}
@@ -131,10 +144,11 @@ func (list *List[T]) Contains(values ...T) bool {
 	if list.size == 0 {
 		return false
 	}
+	equals := list.equalsFunc()
 	for _, value := range values {
 		found := false
 		for element := list.first; element != nil; element = element.next {
-			if reflect.DeepEqual(element.value, value) {
+			if equals(element.value, value) {
 				found = true
 				break
 			}
@@ -146,6 +160,17 @@ func (list *List[T]) Contains(values ...T) bool {
 	return true
 }
 
+// RemoveValue removes the first occurrence of the value from the list.
+// Returns true if the value was found and removed.
+func (list *List[T]) RemoveValue(value T) bool {
+	index := list.IndexOf(value)
+	if index < 0 {
+		return false
+	}
+	list.Remove(index)
+	return true
+}
+
 // Values returns all elements in the list.
 func (list *List[T]) Values() []T {
 	values := make([]T, list.size, list.size)
@@ -155,13 +180,11 @@ func (list *List[T]) Values() []T {
 	return values
 }
 
-//IndexOf returns index of provided element
+// IndexOf returns index of provided element
 func (list *List[T]) IndexOf(value T) int {
-	if list.size == 0 {
-		return -1
-	}
-	for index, element := range list.Values() {
-		if reflect.DeepEqual(element, value) {
+	equals := list.equalsFunc()
+	for index, element := 0, list.first; element != nil; index, element = index+1, element.next {
+		if equals(element.value, value) {
 			return index
 		}
 	}
@@ -292,6 +315,15 @@ func (list *List[T]) String() string {
 	return str
 }
 
+// equalsFunc returns the equality of the list, which is utils.DefaultEquals unless the list was given one.
+// Does not store the default in a zero value list, so that reading the list does not write to it.
+func (list *List[T]) equalsFunc() utils.Equals[T] {
+	if list.equals == nil {
+		return utils.DefaultEquals[T]()
+	}
+	return list.equals
+}
+
 // Check that the index is within bounds of the list
 func (list *List[T]) withinRange(index int) bool {
 	return index >= 0 && index < list.size
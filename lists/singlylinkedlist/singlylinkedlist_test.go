@@ -14,7 +14,7 @@ import (
 )
 
 func TestListNew(t *testing.T) {
-	list1 := New()
+	list1 := New[any]()
 
 	if actualValue := list1.Empty(); actualValue != true {
 		t.Errorf("Got %v expected %v", actualValue, true)
@@ -175,6 +175,79 @@ func TestListContains(t *testing.T) {
 	}
 }
 
+type user struct {
+	id   int
+	name string
+}
+
+func TestListEquals(t *testing.T) {
+	sameID := func(a, b user) bool { return a.id == b.id }
+	list := NewWithEquals[user](sameID, user{1, "a"}, user{2, "b"}, user{3, "c"})
+	if actualValue := list.Contains(user{2, "renamed"}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.Contains(user{4, "a"}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := list.IndexOf(user{id: 3}); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := list.RemoveValue(user{id: 1}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(user{id: 1}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[{2 b} {3 c}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// Derived lists compare the same way
+	selected := list.Select(func(index int, value user) bool { return true })
+	if actualValue := selected.Contains(user{id: 3}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestListZeroValueEquals(t *testing.T) {
+	var list List[int]
+	list.Add(1, 2)
+	if actualValue := list.Contains(2); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.IndexOf(3); actualValue != -1 {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+	// Reading the list must not write to it, e.g. by storing the default equality
+	if list.equals != nil {
+		t.Errorf("Got %v expected %v", "stored equality", nil)
+	}
+}
+
+func TestListRemoveValue(t *testing.T) {
+	list := New[int](1, 2, 1, 0)
+	if actualValue := list.RemoveValue(1); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(0); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := list.RemoveValue(3); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// Pointers are compared by the values they refer to unless the list is given an equality
+	pointers := New[*user](&user{1, "a"})
+	if actualValue := pointers.Contains(&user{1, "a"}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	pointers = NewWithEquals[*user](utils.ComparableEquals[*user], &user{1, "a"})
+	if actualValue := pointers.Contains(&user{1, "a"}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
 func TestListValues(t *testing.T) {
 	list := New[any]()
 	list.Add("a")
@@ -275,7 +348,7 @@ func TestListEach(t *testing.T) {
 func TestListMap(t *testing.T) {
 	list := New[string]()
 	list.Add("a", "b", "c")
-	mappedList := list.Map(func(index int, value string) any {
+	mappedList := list.Map(func(index int, value string) string {
 		return "mapped: " + value
 	})
 	if actualValue, _ := mappedList.Get(0); actualValue != "mapped: a" {
@@ -362,7 +435,7 @@ func TestListChaining(t *testing.T) {
 	list.Add("a", "b", "c")
 	chainedList := list.Select(func(index int, value string) bool {
 		return value > "a"
-	}).Map(func(index int, value string) any {
+	}).Map(func(index int, value string) string {
 		return value + value
 	})
 	if chainedList.Size() != 2 {
@@ -377,7 +450,7 @@ func TestListChaining(t *testing.T) {
 }
 
 func TestListIteratorNextOnEmpty(t *testing.T) {
-	list := New()
+	list := New[string]()
 	it := list.Iterator()
 	for it.Next() {
 		t.Errorf("Shouldn't iterate on empty list")
@@ -452,7 +525,7 @@ func TestListIteratorNextTo(t *testing.T) {
 
 	// NextTo (empty)
 	{
-		list := New()
+		list := New[string]()
 		it := list.Iterator()
 		for it.NextTo(seek) {
 			t.Errorf("Shouldn't iterate on empty list")
@@ -537,6 +610,14 @@ func TestListString(t *testing.T) {
 	}
 }
 
+func benchmarkIndexOf(b *testing.B, list *List[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n += size / 10 {
+			list.IndexOf(n)
+		}
+	}
+}
+
 func benchmarkGet(b *testing.B, list *List[int], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -689,3 +770,25 @@ func BenchmarkSinglyLinkedListRemove100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkRemove(b, list, size)
 }
+
+func BenchmarkSinglyLinkedListIndexOf1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := New[int]()
+	for n := 0; n < size; n++ {
+		list.Add(n)
+	}
+	b.StartTimer()
+	benchmarkIndexOf(b, list, size)
+}
+
+func BenchmarkSinglyLinkedListIndexOfDeepEquals1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := NewWithEquals[int](utils.DeepEquals[int])
+	for n := 0; n < size; n++ {
+		list.Add(n)
+	}
+	b.StartTimer()
+	benchmarkIndexOf(b, list, size)
+}
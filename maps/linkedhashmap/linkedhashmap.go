@@ -14,6 +14,7 @@ package linkedhashmap
 import (
 	"fmt"
 	"github.com/kcswag/kcgods/lists/doublylinkedlist"
+	"github.com/kcswag/kcgods/utils"
 	"reflect"
 	"strings"
 )
@@ -28,7 +29,7 @@ type Map[K comparable, V any] struct {
 func New[K comparable, V any]() *Map[K, V] {
 	return &Map[K, V]{
 		table:    make(map[K]V),
-		ordering: doublylinkedlist.New[K](),
+		ordering: doublylinkedlist.NewWithEquals[K](utils.ComparableEquals[K]),
 	}
 }
 
@@ -56,8 +57,7 @@ func (m *Map[K, V]) Get(key K) (value V, found bool) {
 func (m *Map[K, V]) Remove(key K) {
 	if _, contains := m.table[key]; contains {
 		delete(m.table, key)
-		index := m.ordering.IndexOf(key)
-		m.ordering.Remove(index)
+		m.ordering.RemoveValue(key)
 	}
 }
 
@@ -0,0 +1,180 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package persistenttreemap
+
+// Assert Iterator implementation
+//var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
+
+// Iterator holding the iterator's state.
+// Nodes are shared between versions and have no parent pointers, so the iterator keeps the path from the root
+// to the current node instead.
+type Iterator[K, V any] struct {
+	m        *Map[K, V]
+	path     []*node[K, V]
+	position position
+}
+
+type position byte
+
+const (
+	begin, between, end position = 0, 1, 2
+)
+
+// Iterator returns a stateful iterator whose elements are key/value pairs.
+// The iterator walks the version of the map it was created from, later versions do not affect it.
+func (m *Map[K, V]) Iterator() Iterator[K, V] {
+	return Iterator[K, V]{m: m, position: begin}
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Next() bool {
+	switch iterator.position {
+	case end:
+		return false
+	case begin:
+		iterator.path = iterator.path[:0]
+		iterator.descend(iterator.m.root, true)
+	case between:
+		if current := iterator.current(); current.right != nil {
+			iterator.descend(current.right, true)
+		} else {
+			iterator.ascend(false)
+		}
+	}
+	if len(iterator.path) == 0 {
+		iterator.position = end
+		return false
+	}
+	iterator.position = between
+	return true
+}
+
+// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
+// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Prev() bool {
+	switch iterator.position {
+	case begin:
+		return false
+	case end:
+		iterator.path = iterator.path[:0]
+		iterator.descend(iterator.m.root, false)
+	case between:
+		if current := iterator.current(); current.left != nil {
+			iterator.descend(current.left, false)
+		} else {
+			iterator.ascend(true)
+		}
+	}
+	if len(iterator.path) == 0 {
+		iterator.position = begin
+		return false
+	}
+	iterator.position = between
+	return true
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[K, V]) Value() V {
+	return iterator.current().value
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[K, V]) Key() K {
+	return iterator.current().key
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator[K, V]) Begin() {
+	iterator.path = iterator.path[:0]
+	iterator.position = begin
+}
+
+// End moves the iterator past the last element (one-past-the-end).
+// Call Prev() to fetch the last element if any.
+func (iterator *Iterator[K, V]) End() {
+	iterator.path = iterator.path[:0]
+	iterator.position = end
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator
+func (iterator *Iterator[K, V]) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// Last moves the iterator to the last element and returns true if there was a last element in the container.
+// If Last() returns true, then last element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Last() bool {
+	iterator.End()
+	return iterator.Prev()
+}
+
+// NextTo moves the iterator to the next element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If NextTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) NextTo(f func(key K, value V) bool) bool {
+	for iterator.Next() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrevTo moves the iterator to the previous element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If PrevTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) PrevTo(f func(key K, value V) bool) bool {
+	for iterator.Prev() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (iterator *Iterator[K, V]) current() *node[K, V] {
+	return iterator.path[len(iterator.path)-1]
+}
+
+// descend pushes the path from n down to the left-most (or right-most) node of its subtree.
+func (iterator *Iterator[K, V]) descend(n *node[K, V], left bool) {
+	for n != nil {
+		iterator.path = append(iterator.path, n)
+		if left {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+}
+
+// ascend pops the path until it reaches the first ancestor whose left (or right) subtree holds the current node.
+func (iterator *Iterator[K, V]) ascend(fromRight bool) {
+	child := iterator.current()
+	iterator.path = iterator.path[:len(iterator.path)-1]
+	for len(iterator.path) > 0 {
+		parent := iterator.current()
+		if fromRight && parent.right == child || !fromRight && parent.left == child {
+			return
+		}
+		child = parent
+		iterator.path = iterator.path[:len(iterator.path)-1]
+	}
+}
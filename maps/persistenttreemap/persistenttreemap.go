@@ -0,0 +1,327 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package persistenttreemap implements an immutable sorted map backed by a persistent AVL tree.
+//
+// Elements are ordered by key in the map.
+//
+// A map is never modified after it has been created. Put and Remove return a new version of the map
+// that shares all unchanged nodes with the previous version (path copying), so each update allocates
+// O(log n) nodes and every version stays valid and unchanged.
+//
+// Structure is safe for concurrent readers without any locking, since nodes are never mutated once published.
+//
+// References: https://en.wikipedia.org/wiki/Persistent_data_structure
+package persistenttreemap
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+)
+
+// Map holds the elements in an immutable AVL tree
+type Map[K, V any] struct {
+	root       *node[K, V]
+	size       int
+	comparator utils.Comparator
+}
+
+// node is an immutable tree node, shared between all versions that contain it
+type node[K, V any] struct {
+	key    K
+	value  V
+	left   *node[K, V]
+	right  *node[K, V]
+	height int
+}
+
+// NewWith instantiates an empty persistent tree map with the custom comparator.
+func NewWith[K, V any](comparator utils.Comparator) *Map[K, V] {
+	return &Map[K, V]{comparator: comparator}
+}
+
+// NewWithIntComparator instantiates an empty persistent tree map with the IntComparator, i.e. keys are of type int.
+func NewWithIntComparator[V any]() *Map[int, V] {
+	return NewWith[int, V](utils.IntComparator)
+}
+
+// NewWithStringComparator instantiates an empty persistent tree map with the StringComparator, i.e. keys are of type string.
+func NewWithStringComparator[V any]() *Map[string, V] {
+	return NewWith[string, V](utils.StringComparator)
+}
+
+// Put returns a new version of the map with the key-value pair inserted, the receiver is left unchanged.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Put(key K, value V) *Map[K, V] {
+	root, added := m.put(m.root, key, value)
+	size := m.size
+	if added {
+		size++
+	}
+	return &Map[K, V]{root: root, size: size, comparator: m.comparator}
+}
+
+// Get searches the element in the map by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Get(key K) (value V, found bool) {
+	n := m.root
+	for n != nil {
+		compare := m.comparator(key, n.key)
+		switch {
+		case compare == 0:
+			return n.value, true
+		case compare < 0:
+			n = n.left
+		case compare > 0:
+			n = n.right
+		}
+	}
+	return *new(V), false
+}
+
+// Remove returns a new version of the map without the element of the given key, the receiver is left unchanged.
+// If the key is not found, the receiver itself is returned.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Remove(key K) *Map[K, V] {
+	root, removed := m.remove(m.root, key)
+	if !removed {
+		return m
+	}
+	return &Map[K, V]{root: root, size: m.size - 1, comparator: m.comparator}
+}
+
+// Empty returns true if map does not contain any elements
+func (m *Map[K, V]) Empty() bool {
+	return m.size == 0
+}
+
+// Size returns number of elements in the map.
+func (m *Map[K, V]) Size() int {
+	return m.size
+}
+
+// Keys returns all keys in-order
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	for it := m.Iterator(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// Values returns all values in-order based on the key.
+func (m *Map[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	for it := m.Iterator(); it.Next(); {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+// Clear returns an empty map with the same comparator, the receiver is left unchanged.
+func (m *Map[K, V]) Clear() *Map[K, V] {
+	return NewWith[K, V](m.comparator)
+}
+
+// Min returns the minimum key and its value from the map.
+// Returns nil, nil if map is empty.
+func (m *Map[K, V]) Min() (key K, value V) {
+	if n := m.root.bottom(true); n != nil {
+		return n.key, n.value
+	}
+	return *new(K), *new(V)
+}
+
+// Max returns the maximum key and its value from the map.
+// Returns nil, nil if map is empty.
+func (m *Map[K, V]) Max() (key K, value V) {
+	if n := m.root.bottom(false); n != nil {
+		return n.key, n.value
+	}
+	return *new(K), *new(V)
+}
+
+// Floor finds the floor key-value pair for the input key.
+// In case that no floor is found, then both returned values will be nil.
+// It's generally enough to check the first value (key) for nil, which determines if floor was found.
+//
+// Floor key is defined as the largest key that is smaller than or equal to the given key.
+// A floor key may not be found, either because the map is empty, or because
+// all keys in the map are larger than the given key.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Floor(key K) (foundKey K, foundValue V) {
+	var floor *node[K, V]
+	n := m.root
+	for n != nil {
+		compare := m.comparator(key, n.key)
+		switch {
+		case compare == 0:
+			return n.key, n.value
+		case compare < 0:
+			n = n.left
+		case compare > 0:
+			floor = n
+			n = n.right
+		}
+	}
+	if floor != nil {
+		return floor.key, floor.value
+	}
+	return *new(K), *new(V)
+}
+
+// Ceiling finds the ceiling key-value pair for the input key.
+// In case that no ceiling is found, then both returned values will be nil.
+// It's generally enough to check the first value (key) for nil, which determines if ceiling was found.
+//
+// Ceiling key is defined as the smallest key that is larger than or equal to the given key.
+// A ceiling key may not be found, either because the map is empty, or because
+// all keys in the map are smaller than the given key.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Ceiling(key K) (foundKey K, foundValue V) {
+	var ceiling *node[K, V]
+	n := m.root
+	for n != nil {
+		compare := m.comparator(key, n.key)
+		switch {
+		case compare == 0:
+			return n.key, n.value
+		case compare < 0:
+			ceiling = n
+			n = n.left
+		case compare > 0:
+			n = n.right
+		}
+	}
+	if ceiling != nil {
+		return ceiling.key, ceiling.value
+	}
+	return *new(K), *new(V)
+}
+
+// String returns a string representation of container
+func (m *Map[K, V]) String() string {
+	str := "PersistentTreeMap\nmap["
+	it := m.Iterator()
+	for it.Next() {
+		str += fmt.Sprintf("%v:%v ", it.Key(), it.Value())
+	}
+	return strings.TrimRight(str, " ") + "]"
+}
+
+// put returns a copy of the subtree with the key-value pair inserted and true if the key was not present before.
+func (m *Map[K, V]) put(n *node[K, V], key K, value V) (*node[K, V], bool) {
+	if n == nil {
+		// Assert key is of comparator's type
+		m.comparator(key, key)
+		return &node[K, V]{key: key, value: value, height: 1}, true
+	}
+	compare := m.comparator(key, n.key)
+	switch {
+	case compare < 0:
+		left, added := m.put(n.left, key, value)
+		return balance(n.key, n.value, left, n.right), added
+	case compare > 0:
+		right, added := m.put(n.right, key, value)
+		return balance(n.key, n.value, n.left, right), added
+	default:
+		return &node[K, V]{key: key, value: value, left: n.left, right: n.right, height: n.height}, false
+	}
+}
+
+// remove returns a copy of the subtree without the key and true if the key was found.
+// If the key was not found, the subtree itself is returned.
+func (m *Map[K, V]) remove(n *node[K, V], key K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	compare := m.comparator(key, n.key)
+	switch {
+	case compare < 0:
+		left, removed := m.remove(n.left, key)
+		if !removed {
+			return n, false
+		}
+		return balance(n.key, n.value, left, n.right), true
+	case compare > 0:
+		right, removed := m.remove(n.right, key)
+		if !removed {
+			return n, false
+		}
+		return balance(n.key, n.value, n.left, right), true
+	}
+	if n.left == nil {
+		return n.right, true
+	}
+	if n.right == nil {
+		return n.left, true
+	}
+	successor := n.right.bottom(true)
+	return balance(successor.key, successor.value, n.left, removeMin(n.right)), true
+}
+
+func removeMin[K, V any](n *node[K, V]) *node[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	return balance(n.key, n.value, removeMin(n.left), n.right)
+}
+
+// balance creates a new node from the given parts and restores the AVL property with rotations if needed.
+// Only new nodes are created, the given subtrees are never modified.
+func balance[K, V any](key K, value V, left *node[K, V], right *node[K, V]) *node[K, V] {
+	switch hl, hr := left.getHeight(), right.getHeight(); {
+	case hl > hr+1:
+		if left.left.getHeight() >= left.right.getHeight() {
+			return newNode(left.key, left.value, left.left, newNode(key, value, left.right, right))
+		}
+		return newNode(left.right.key, left.right.value,
+			newNode(left.key, left.value, left.left, left.right.left),
+			newNode(key, value, left.right.right, right))
+	case hr > hl+1:
+		if right.right.getHeight() >= right.left.getHeight() {
+			return newNode(right.key, right.value, newNode(key, value, left, right.left), right.right)
+		}
+		return newNode(right.left.key, right.left.value,
+			newNode(key, value, left, right.left.left),
+			newNode(right.key, right.value, right.left.right, right.right))
+	}
+	return newNode(key, value, left, right)
+}
+
+func newNode[K, V any](key K, value V, left *node[K, V], right *node[K, V]) *node[K, V] {
+	height := left.getHeight()
+	if h := right.getHeight(); h > height {
+		height = h
+	}
+	return &node[K, V]{key: key, value: value, left: left, right: right, height: height + 1}
+}
+
+func (n *node[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// bottom returns the left-most (min) or right-most (max) node of the subtree or nil if the subtree is empty.
+func (n *node[K, V]) bottom(left bool) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	for {
+		child := n.right
+		if left {
+			child = n.left
+		}
+		if child == nil {
+			return n
+		}
+		n = child
+	}
+}
@@ -0,0 +1,401 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package persistenttreemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMapPut(t *testing.T) {
+	m0 := NewWithIntComparator[string]()
+	m1 := m0.Put(5, "e")
+	m2 := m1.Put(6, "f").Put(7, "g").Put(3, "c").Put(4, "d").Put(1, "x").Put(2, "b")
+	m3 := m2.Put(1, "a") //overwrite
+
+	if actualValue := m0.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := m1.Size(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue := m3.Size(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m3.Keys()), "[1 2 3 4 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m3.Values()), "[a b c d e f g]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// older versions are untouched
+	if actualValue, expectedValue := fmt.Sprint(m2.Values()), "[x b c d e f g]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m1.Keys()), "[5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := [][]interface{}{
+		{1, "a", true},
+		{2, "b", true},
+		{7, "g", true},
+		{8, "", false},
+	}
+	for _, test := range tests {
+		actualValue, actualFound := m3.Get(test[0].(int))
+		if actualValue != test[1] || actualFound != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+	}
+}
+
+func TestMapRemove(t *testing.T) {
+	m := NewWithIntComparator[string]().Put(5, "e").Put(6, "f").Put(7, "g").Put(3, "c").Put(4, "d").Put(1, "a").Put(2, "b")
+	removed := m.Remove(5).Remove(6).Remove(7).Remove(8)
+
+	if actualValue, expectedValue := fmt.Sprint(removed.Keys()), "[1 2 3 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys()), "[1 2 3 4 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := removed.Remove(8); actualValue != removed {
+		t.Errorf("Removing a missing key should return the same version")
+	}
+	empty := removed.Remove(1).Remove(2).Remove(3).Remove(4)
+	if actualValue := empty.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := m.Clear().Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := m.Size(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+}
+
+func TestMapStructuralSharing(t *testing.T) {
+	m := NewWithIntComparator[int]()
+	for i := 0; i < 1024; i++ {
+		m = m.Put(i, i)
+	}
+	updated := m.Put(0, -1)
+
+	// only the path to the updated key is copied, everything else is shared
+	old := map[*node[int, int]]bool{}
+	var collect func(n *node[int, int])
+	collect = func(n *node[int, int]) {
+		if n != nil {
+			old[n] = true
+			collect(n.left)
+			collect(n.right)
+		}
+	}
+	collect(m.root)
+	copied := 0
+	var walk func(n *node[int, int])
+	walk = func(n *node[int, int]) {
+		if n != nil && !old[n] {
+			copied++
+			walk(n.left)
+			walk(n.right)
+		}
+	}
+	walk(updated.root)
+	if actualValue, expectedValue := copied, m.root.height; actualValue > expectedValue || actualValue == 0 {
+		t.Errorf("Got %v copied nodes expected at most %v", actualValue, expectedValue)
+	}
+	if actualValue, _ := m.Get(0); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestMapRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewWithIntComparator[int]()
+	expected := map[int]int{}
+	var versions []*Map[int, int]
+	var snapshots []string
+	for i := 0; i < 2000; i++ {
+		key := r.Intn(200)
+		if r.Intn(3) == 0 {
+			m = m.Remove(key)
+			delete(expected, key)
+		} else {
+			m = m.Put(key, i)
+			expected[key] = i
+		}
+		if i%200 == 0 {
+			versions = append(versions, m)
+			snapshots = append(snapshots, fmt.Sprint(m.Keys(), m.Values()))
+		}
+	}
+	assertBalanced(t, m.root)
+	if actualValue, expectedValue := m.Size(), len(expected); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for key, value := range expected {
+		if actualValue, found := m.Get(key); actualValue != value || !found {
+			t.Errorf("Got %v expected %v", actualValue, value)
+		}
+	}
+	for i, version := range versions {
+		if actualValue, expectedValue := fmt.Sprint(version.Keys(), version.Values()), snapshots[i]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMapMinMaxFloorCeiling(t *testing.T) {
+	m := NewWithIntComparator[string]()
+	if k, v := m.Min(); k != 0 || v != "" {
+		t.Errorf("Got %v->%v expected %v->%v", k, v, 0, "")
+	}
+	m = m.Put(5, "e").Put(6, "f").Put(7, "g").Put(3, "c").Put(4, "d").Put(1, "a")
+
+	if k, v := m.Min(); k != 1 || v != "a" {
+		t.Errorf("Got %v->%v expected %v->%v", k, v, 1, "a")
+	}
+	if k, v := m.Max(); k != 7 || v != "g" {
+		t.Errorf("Got %v->%v expected %v->%v", k, v, 7, "g")
+	}
+
+	floors := [][]interface{}{
+		{0, 0, ""},
+		{1, 1, "a"},
+		{2, 1, "a"},
+		{8, 7, "g"},
+	}
+	for _, test := range floors {
+		if k, v := m.Floor(test[0].(int)); k != test[1] || v != test[2] {
+			t.Errorf("Got %v->%v expected %v->%v", k, v, test[1], test[2])
+		}
+	}
+	ceilings := [][]interface{}{
+		{0, 1, "a"},
+		{2, 3, "c"},
+		{7, 7, "g"},
+		{8, 0, ""},
+	}
+	for _, test := range ceilings {
+		if k, v := m.Ceiling(test[0].(int)); k != test[1] || v != test[2] {
+			t.Errorf("Got %v->%v expected %v->%v", k, v, test[1], test[2])
+		}
+	}
+}
+
+func TestMapIterator(t *testing.T) {
+	m := NewWithIntComparator[string]()
+	it := m.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+
+	for i := 10; i > 0; i-- {
+		m = m.Put(i, fmt.Sprint(i))
+	}
+	it = m.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := count, 10; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count--
+	}
+	if actualValue, expectedValue := count, 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if !it.First() || it.Key() != 1 {
+		t.Errorf("Got %v expected %v", it.Key(), 1)
+	}
+	if !it.Last() || it.Value() != "10" {
+		t.Errorf("Got %v expected %v", it.Value(), "10")
+	}
+	it.Begin()
+	if !it.NextTo(func(key int, value string) bool { return value == "5" }) || it.Key() != 5 {
+		t.Errorf("Got %v expected %v", it.Key(), 5)
+	}
+	if !it.Next() || it.Key() != 6 {
+		t.Errorf("Got %v expected %v", it.Key(), 6)
+	}
+	it.End()
+	if !it.PrevTo(func(key int, value string) bool { return key%4 == 0 }) || it.Key() != 8 {
+		t.Errorf("Got %v expected %v", it.Key(), 8)
+	}
+
+	// iterating an old version is unaffected by newer versions
+	it = m.Iterator()
+	m.Remove(1).Put(11, "11")
+	if !it.First() || it.Key() != 1 {
+		t.Errorf("Got %v expected %v", it.Key(), 1)
+	}
+}
+
+func TestMapEnumerable(t *testing.T) {
+	m := NewWithStringComparator[int]().Put("c", 3).Put("a", 1).Put("b", 2)
+
+	count := 0
+	m.Each(func(key string, value int) {
+		count++
+	})
+	if actualValue, expectedValue := count, 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	mapped := m.Map(func(key string, value int) (string, int) {
+		return "mapped:" + key, value * value
+	})
+	if actualValue, expectedValue := fmt.Sprint(mapped.Keys(), mapped.Values()), "[mapped:a mapped:b mapped:c] [1 4 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	selected := m.Select(func(key string, value int) bool {
+		return value >= 2
+	})
+	if actualValue, expectedValue := fmt.Sprint(selected.Keys()), "[b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := m.Any(func(key string, value int) bool { return value == 3 }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := m.All(func(key string, value int) bool { return value < 3 }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if k, v := m.Find(func(key string, value int) bool { return value > 1 }); k != "b" || v != 2 {
+		t.Errorf("Got %v->%v expected %v->%v", k, v, "b", 2)
+	}
+}
+
+func TestMapConcurrentReaders(t *testing.T) {
+	m := NewWithIntComparator[int]()
+	for i := 0; i < 1000; i++ {
+		m = m.Put(i, i)
+	}
+	snapshot := m
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if value, found := snapshot.Get(i); !found || value != i {
+					t.Errorf("Got %v expected %v", value, i)
+				}
+			}
+			if actualValue, expectedValue := len(snapshot.Keys()), 1000; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}()
+	}
+	for i := 0; i < 1000; i++ {
+		m = m.Remove(i).Put(i+1000, i)
+	}
+	wg.Wait()
+}
+
+func TestMapSerialization(t *testing.T) {
+	original := NewWithStringComparator[string]().Put("c", "3").Put("b", "2").Put("a", "1")
+
+	bytes, err := original.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded := NewWithStringComparator[string]()
+	if err := decoded.FromJSON(bytes); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(decoded.Keys(), decoded.Values()), "[a b c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	_, err = json.Marshal([]interface{}{"a", "b", "c", original})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	intMap := NewWithStringComparator[int]()
+	err = json.Unmarshal([]byte(`{"a":1,"b":2}`), &intMap)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(intMap.Keys(), intMap.Values()), "[a b] [1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapString(t *testing.T) {
+	c := NewWithStringComparator[int]().Put("a", 1)
+	if !strings.HasPrefix(c.String(), "PersistentTreeMap") {
+		t.Errorf("String should start with container name")
+	}
+}
+
+func assertBalanced[K, V comparable](t *testing.T, n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	lh, rh := assertBalanced(t, n.left), assertBalanced(t, n.right)
+	if lh-rh > 1 || rh-lh > 1 {
+		t.Errorf("Got unbalanced node %v with heights %v and %v", n.key, lh, rh)
+	}
+	height := lh + 1
+	if rh > lh {
+		height = rh + 1
+	}
+	if n.height != height {
+		t.Errorf("Got height %v expected %v at node %v", n.height, height, n.key)
+	}
+	return height
+}
+
+func benchmarkGet(b *testing.B, m *Map[int, struct{}], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			m.Get(n)
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, m *Map[int, struct{}], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			m = m.Put(n, struct{}{})
+		}
+	}
+}
+
+func BenchmarkPersistentTreeMapGet10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	m := NewWithIntComparator[struct{}]()
+	for n := 0; n < size; n++ {
+		m = m.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, m, size)
+}
+
+func BenchmarkPersistentTreeMapPut10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	m := NewWithIntComparator[struct{}]()
+	b.StartTimer()
+	benchmarkPut(b, m, size)
+}
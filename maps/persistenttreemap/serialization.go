@@ -0,0 +1,64 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package persistenttreemap
+
+import (
+	"encoding/json"
+	"github.com/kcswag/kcgods/utils"
+)
+
+// Assert Serialization implementation
+//var _ containers.JSONSerializer = (*Map)(nil)
+//var _ containers.JSONDeserializer = (*Map)(nil)
+
+// ToJSON outputs the JSON representation of the map.
+func (m *Map[K, V]) ToJSON() ([]byte, error) {
+	elements := make(map[string]V)
+	it := m.Iterator()
+	for it.Next() {
+		key, err := utils.MarshalKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		elements[key] = it.Value()
+	}
+	return json.Marshal(&elements)
+}
+
+// FromJSON populates the map from the input JSON representation.
+// Unlike all other operations it overwrites the receiver, so it should only be used on a map that has not been shared yet,
+// e.g. one that has just been created with NewWith for decoding.
+func (m *Map[K, V]) FromJSON(data []byte) error {
+	elements := make(map[string]V)
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	keys := make([]K, 0, len(elements))
+	values := make([]V, 0, len(elements))
+	for name, value := range elements {
+		key, err := utils.UnmarshalKey[K](name)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	decoded := m.Clear()
+	for i, key := range keys {
+		decoded = decoded.Put(key, values[i])
+	}
+	*m = *decoded
+	return nil
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (m *Map[K, V]) UnmarshalJSON(bytes []byte) error {
+	return m.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
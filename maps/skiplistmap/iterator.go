@@ -0,0 +1,176 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package skiplistmap
+
+// Assert Iterator implementation
+//var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
+
+// Iterator holding the iterator's state
+type Iterator[K, V any] struct {
+	m        *Map[K, V]
+	node     *node[K, V]
+	position position
+}
+
+type position byte
+
+const (
+	begin, between, end position = 0, 1, 2
+)
+
+// Iterator returns a stateful iterator whose elements are key/value pairs.
+func (m *Map[K, V]) Iterator() Iterator[K, V] {
+	return Iterator[K, V]{m: m, node: nil, position: begin}
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Next() bool {
+	switch iterator.position {
+	case end:
+		return false
+	case begin:
+		iterator.node = iterator.m.head.next[0]
+	case between:
+		iterator.node = iterator.node.next[0]
+	}
+	if iterator.node == nil {
+		iterator.position = end
+		return false
+	}
+	iterator.position = between
+	return true
+}
+
+// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
+// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Prev() bool {
+	switch iterator.position {
+	case begin:
+		return false
+	case end:
+		iterator.node = iterator.m.tail
+	case between:
+		iterator.node = iterator.node.prev
+	}
+	if iterator.node == nil {
+		iterator.position = begin
+		return false
+	}
+	iterator.position = between
+	return true
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[K, V]) Value() V {
+	return iterator.node.value
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[K, V]) Key() K {
+	return iterator.node.key
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator[K, V]) Begin() {
+	iterator.node = nil
+	iterator.position = begin
+}
+
+// End moves the iterator past the last element (one-past-the-end).
+// Call Prev() to fetch the last element if any.
+func (iterator *Iterator[K, V]) End() {
+	iterator.node = nil
+	iterator.position = end
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator
+func (iterator *Iterator[K, V]) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// Last moves the iterator to the last element and returns true if there was a last element in the container.
+// If Last() returns true, then last element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Last() bool {
+	iterator.End()
+	return iterator.Prev()
+}
+
+// NextTo moves the iterator to the next element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If NextTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) NextTo(f func(key K, value V) bool) bool {
+	for iterator.Next() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrevTo moves the iterator to the previous element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If PrevTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) PrevTo(f func(key K, value V) bool) bool {
+	for iterator.Prev() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// RangeIterator is a forward-only iterator over the elements whose keys lie within [lo, hi).
+type RangeIterator[K, V any] struct {
+	m    *Map[K, V]
+	node *node[K, V]
+	next *node[K, V]
+	hi   K
+}
+
+// Range returns a forward-only iterator over the elements whose keys are equal or bigger than lo and smaller than hi.
+// Finding the first element takes O(log n), every further element is reached by following the bottom level.
+// Keys should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Range(lo K, hi K) RangeIterator[K, V] {
+	return RangeIterator[K, V]{m: m, next: m.ceiling(lo), hi: hi}
+}
+
+// Next moves the iterator to the next element within the range and returns true if there was one.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *RangeIterator[K, V]) Next() bool {
+	if iterator.next == nil || iterator.m.Comparator(iterator.next.key, iterator.hi) >= 0 {
+		iterator.next = nil
+		return false
+	}
+	iterator.node, iterator.next = iterator.next, iterator.next.next[0]
+	return true
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *RangeIterator[K, V]) Value() V {
+	return iterator.node.value
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *RangeIterator[K, V]) Key() K {
+	return iterator.node.key
+}
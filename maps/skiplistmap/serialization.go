@@ -0,0 +1,60 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package skiplistmap
+
+import (
+	"encoding/json"
+	"github.com/kcswag/kcgods/utils"
+)
+
+// Assert Serialization implementation
+//var _ containers.JSONSerializer = (*Map)(nil)
+//var _ containers.JSONDeserializer = (*Map)(nil)
+
+// ToJSON outputs the JSON representation of the map.
+func (m *Map[K, V]) ToJSON() ([]byte, error) {
+	elements := make(map[string]V)
+	for n := m.head.next[0]; n != nil; n = n.next[0] {
+		key, err := utils.MarshalKey(n.key)
+		if err != nil {
+			return nil, err
+		}
+		elements[key] = n.value
+	}
+	return json.Marshal(&elements)
+}
+
+// FromJSON populates the map from the input JSON representation.
+func (m *Map[K, V]) FromJSON(data []byte) error {
+	elements := make(map[string]V)
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	keys := make([]K, 0, len(elements))
+	values := make([]V, 0, len(elements))
+	for name, value := range elements {
+		key, err := utils.UnmarshalKey[K](name)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	m.Clear()
+	for i, key := range keys {
+		m.Put(key, values[i])
+	}
+	return nil
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (m *Map[K, V]) UnmarshalJSON(bytes []byte) error {
+	return m.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
@@ -0,0 +1,381 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package skiplistmap implements a sorted map backed by a skip list.
+//
+// Elements are ordered by key in the map.
+//
+// A skip list keeps its elements in a sorted linked list and adds express lanes on top of it. Every node is
+// promoted to the next level with a fixed probability, which gives O(log n) expected time for search, insertion and
+// removal without any rebalancing. Every forward link also records its span, i.e. how many elements it skips, so
+// elements can be accessed and ranked by index in O(log n) as well.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Skip_list
+package skiplistmap
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/utils"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Assert Map implementation
+//var _ maps.Map = (*Map)(nil)
+
+const (
+	// DefaultProbability is the probability of promoting a node to the next level used by NewWith.
+	DefaultProbability = 0.25
+
+	// DefaultMaxLevel is the maximum number of levels used by NewWith, enough for 4^32 elements with DefaultProbability.
+	DefaultMaxLevel = 32
+)
+
+// Map holds the elements in a skip list
+type Map[K, V any] struct {
+	Comparator  utils.Comparator
+	head        *node[K, V] // sentinel, its key and value are never used
+	tail        *node[K, V]
+	level       int // number of levels in use, at least 1
+	size        int
+	probability float64
+	maxLevel    int
+	random      *rand.Rand
+}
+
+// node is a single element with its forward links on every level it was promoted to
+type node[K, V any] struct {
+	key   K
+	value V
+	next  []*node[K, V] // next node on each level
+	span  []int         // number of elements skipped by following next on each level, including the next node
+	prev  *node[K, V]   // previous node on the bottom level, nil for the first node
+}
+
+// NewWith instantiates a skip list map with the custom comparator, DefaultProbability and DefaultMaxLevel.
+func NewWith[K, V any](comparator utils.Comparator) *Map[K, V] {
+	return NewWithLevels[K, V](comparator, DefaultProbability, DefaultMaxLevel)
+}
+
+// NewWithIntComparator instantiates a skip list map with the IntComparator, i.e. keys are of type int.
+func NewWithIntComparator[V any]() *Map[int, V] {
+	return NewWith[int, V](utils.IntComparator)
+}
+
+// NewWithStringComparator instantiates a skip list map with the StringComparator, i.e. keys are of type string.
+func NewWithStringComparator[V any]() *Map[string, V] {
+	return NewWith[string, V](utils.StringComparator)
+}
+
+// NewWithLevels instantiates a skip list map with the custom comparator, the probability of promoting a node
+// to the next level and the maximum number of levels.
+// Probability must be within (0, 1) and maxLevel must be positive, otherwise method panics.
+func NewWithLevels[K, V any](comparator utils.Comparator, probability float64, maxLevel int) *Map[K, V] {
+	if probability <= 0 || probability >= 1 {
+		panic("Invalid probability, should be within (0, 1)")
+	}
+	if maxLevel < 1 {
+		panic("Invalid max level, should be at least 1")
+	}
+	m := &Map[K, V]{
+		Comparator:  comparator,
+		probability: probability,
+		maxLevel:    maxLevel,
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	m.Clear()
+	return m
+}
+
+// Seed reseeds the random number generator that picks the level of new nodes.
+// Maps seeded with the same value and fed the same operations end up with the same structure,
+// which is useful for deterministic tests and benchmarks.
+func (m *Map[K, V]) Seed(seed int64) {
+	m.random = rand.New(rand.NewSource(seed))
+}
+
+// Probability returns the probability of promoting a node to the next level.
+func (m *Map[K, V]) Probability() float64 {
+	return m.probability
+}
+
+// MaxLevel returns the maximum number of levels.
+func (m *Map[K, V]) MaxLevel() int {
+	return m.maxLevel
+}
+
+// Put inserts key-value pair into the map.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Put(key K, value V) {
+	update := make([]*node[K, V], m.maxLevel)
+	rank := make([]int, m.maxLevel)
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		if i < m.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && m.Comparator(x.next[i].key, key) < 0 {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	if next := x.next[0]; next != nil && m.Comparator(next.key, key) == 0 {
+		next.value = value
+		return
+	}
+	if m.size == 0 {
+		// Assert key is of comparator's type for initial map
+		m.Comparator(key, key)
+	}
+
+	level := m.randomLevel()
+	if level > m.level {
+		for i := m.level; i < level; i++ {
+			update[i] = m.head
+			m.head.span[i] = m.size
+		}
+		m.level = level
+	}
+	n := &node[K, V]{key: key, value: value, next: make([]*node[K, V], level), span: make([]int, level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+		n.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := level; i < m.level; i++ {
+		update[i].span[i]++
+	}
+	if update[0] != m.head {
+		n.prev = update[0]
+	}
+	if n.next[0] != nil {
+		n.next[0].prev = n
+	} else {
+		m.tail = n
+	}
+	m.size++
+}
+
+// Get searches the element in the map by key and returns its value or nil if key is not found in map.
+// Second return parameter is true if key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Get(key K) (value V, found bool) {
+	if n := m.lookup(key); n != nil {
+		return n.value, true
+	}
+	return *new(V), false
+}
+
+// Remove removes the element from the map by key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Remove(key K) {
+	update := make([]*node[K, V], m.level)
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && m.Comparator(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	n := x.next[0]
+	if n == nil || m.Comparator(n.key, key) != 0 {
+		return
+	}
+	for i := 0; i < m.level; i++ {
+		if update[i].next[i] == n {
+			update[i].span[i] += n.span[i] - 1
+			update[i].next[i] = n.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	if n.next[0] != nil {
+		n.next[0].prev = n.prev
+	} else {
+		m.tail = n.prev
+	}
+	for m.level > 1 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+	m.size--
+}
+
+// Empty returns true if map does not contain any elements
+func (m *Map[K, V]) Empty() bool {
+	return m.size == 0
+}
+
+// Size returns number of elements in the map.
+func (m *Map[K, V]) Size() int {
+	return m.size
+}
+
+// Keys returns all keys in-order
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	for n := m.head.next[0]; n != nil; n = n.next[0] {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// Values returns all values in-order based on the key.
+func (m *Map[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	for n := m.head.next[0]; n != nil; n = n.next[0] {
+		values = append(values, n.value)
+	}
+	return values
+}
+
+// Clear removes all elements from the map.
+func (m *Map[K, V]) Clear() {
+	m.head = &node[K, V]{next: make([]*node[K, V], m.maxLevel), span: make([]int, m.maxLevel)}
+	m.tail = nil
+	m.level = 1
+	m.size = 0
+}
+
+// Min returns the minimum key and its value from the map.
+// Returns nil, nil if map is empty.
+func (m *Map[K, V]) Min() (key K, value V) {
+	if n := m.head.next[0]; n != nil {
+		return n.key, n.value
+	}
+	return *new(K), *new(V)
+}
+
+// Max returns the maximum key and its value from the map.
+// Returns nil, nil if map is empty.
+func (m *Map[K, V]) Max() (key K, value V) {
+	if m.tail != nil {
+		return m.tail.key, m.tail.value
+	}
+	return *new(K), *new(V)
+}
+
+// Floor finds the floor key-value pair for the input key.
+// In case that no floor is found, then both returned values will be nil.
+// It's generally enough to check the first value (key) for nil, which determines if floor was found.
+//
+// Floor key is defined as the largest key that is smaller than or equal to the given key.
+// A floor key may not be found, either because the map is empty, or because
+// all keys in the map are larger than the given key.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Floor(key K) (foundKey K, foundValue V) {
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && m.Comparator(x.next[i].key, key) <= 0 {
+			x = x.next[i]
+		}
+	}
+	if x != m.head {
+		return x.key, x.value
+	}
+	return *new(K), *new(V)
+}
+
+// Ceiling finds the ceiling key-value pair for the input key.
+// In case that no ceiling is found, then both returned values will be nil.
+// It's generally enough to check the first value (key) for nil, which determines if ceiling was found.
+//
+// Ceiling key is defined as the smallest key that is larger than or equal to the given key.
+// A ceiling key may not be found, either because the map is empty, or because
+// all keys in the map are smaller than the given key.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) Ceiling(key K) (foundKey K, foundValue V) {
+	if n := m.ceiling(key); n != nil {
+		return n.key, n.value
+	}
+	return *new(K), *new(V)
+}
+
+// GetAt returns the key-value pair at the given index in key order in O(log n).
+// Third return parameter is true if index is within bounds of the map, otherwise false.
+func (m *Map[K, V]) GetAt(index int) (key K, value V, found bool) {
+	if n := m.nodeAt(index); n != nil {
+		return n.key, n.value, true
+	}
+	return *new(K), *new(V), false
+}
+
+// IndexOf returns the index of the given key in key order in O(log n), or -1 if the key is not found.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) IndexOf(key K) int {
+	rank := 0
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && m.Comparator(x.next[i].key, key) <= 0 {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+		if x != m.head && m.Comparator(x.key, key) == 0 {
+			return rank - 1
+		}
+	}
+	return -1
+}
+
+// String returns a string representation of container
+func (m *Map[K, V]) String() string {
+	str := "SkipListMap\nmap["
+	for n := m.head.next[0]; n != nil; n = n.next[0] {
+		str += fmt.Sprintf("%v:%v ", n.key, n.value)
+	}
+	return strings.TrimRight(str, " ") + "]"
+}
+
+// lookup returns the node holding the key or nil if the key is not found.
+func (m *Map[K, V]) lookup(key K) *node[K, V] {
+	if n := m.ceiling(key); n != nil && m.Comparator(n.key, key) == 0 {
+		return n
+	}
+	return nil
+}
+
+// ceiling returns the first node whose key is equal or bigger than the given key or nil if there is none.
+func (m *Map[K, V]) ceiling(key K) *node[K, V] {
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && m.Comparator(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+	}
+	return x.next[0]
+}
+
+// nodeAt returns the node at the given index or nil if index is out of bounds.
+func (m *Map[K, V]) nodeAt(index int) *node[K, V] {
+	if index < 0 || index >= m.size {
+		return nil
+	}
+	rank := index + 1
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.span[i] <= rank {
+			rank -= x.span[i]
+			x = x.next[i]
+		}
+		if rank == 0 {
+			return x
+		}
+	}
+	return nil
+}
+
+// randomLevel picks the level of a new node, every further level is taken with the configured probability.
+func (m *Map[K, V]) randomLevel() int {
+	level := 1
+	for level < m.maxLevel && m.random.Float64() < m.probability {
+		level++
+	}
+	return level
+}
@@ -18,22 +18,36 @@ func (m *Map[K, V]) ToJSON() ([]byte, error) {
 	elements := make(map[string]V)
 	it := m.Iterator()
 	for it.Next() {
-		elements[utils.ToString(it.Key())] = it.Value()
+		key, err := utils.MarshalKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		elements[key] = it.Value()
 	}
 	return json.Marshal(&elements)
 }
 
 // FromJSON populates the map from the input JSON representation.
 func (m *Map[K, V]) FromJSON(data []byte) error {
-	elements := make(map[K]V)
-	err := json.Unmarshal(data, &elements)
-	if err == nil {
-		m.Clear()
-		for key, value := range elements {
-			m.Put(key, value)
+	elements := make(map[string]V)
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	keys := make([]K, 0, len(elements))
+	values := make([]V, 0, len(elements))
+	for name, value := range elements {
+		key, err := utils.UnmarshalKey[K](name)
+		if err != nil {
+			return err
 		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	m.Clear()
+	for i, key := range keys {
+		m.Put(key, values[i])
 	}
-	return err
+	return nil
 }
 
 // UnmarshalJSON @implements json.Unmarshaler
@@ -28,20 +28,20 @@ import (
 //var _ maps.BidiMap = (*Map)(nil)
 
 // Map holds the elements in two red-black trees.
-type Map[K, V comparable] struct {
+type Map[K, V any] struct {
 	forwardMap      redblacktree.Tree[K, V]
 	inverseMap      redblacktree.Tree[V, K]
 	keyComparator   utils.Comparator
 	valueComparator utils.Comparator
 }
 
-/*type data[K, V comparable] struct {
+/*type data[K, V any] struct {
 	key   K
 	value V
 }*/
 
 // NewWith instantiates a bidirectional map.
-func NewWith[K, V comparable](keyComparator utils.Comparator, valueComparator utils.Comparator) *Map[K, V] {
+func NewWith[K, V any](keyComparator utils.Comparator, valueComparator utils.Comparator) *Map[K, V] {
 	return &Map[K, V]{
 		forwardMap:      *redblacktree.NewWith[K, V](keyComparator),
 		inverseMap:      *redblacktree.NewWith[V, K](valueComparator),
@@ -51,12 +51,12 @@ func NewWith[K, V comparable](keyComparator utils.Comparator, valueComparator ut
 }
 
 // NewWithIntComparators instantiates a bidirectional map with the IntComparator for key and value, i.e. keys and values are of type int.
-func NewWithIntComparators[K, V comparable]() *Map[K, V] {
+func NewWithIntComparators[K, V any]() *Map[K, V] {
 	return NewWith[K, V](utils.IntComparator, utils.IntComparator)
 }
 
 // NewWithStringComparators instantiates a bidirectional map with the StringComparator for key and value, i.e. keys and values are of type string.
-func NewWithStringComparators[K, V comparable]() *Map[K, V] {
+func NewWithStringComparators[K, V any]() *Map[K, V] {
 	return NewWith[K, V](utils.StringComparator, utils.StringComparator)
 }
 
@@ -654,6 +654,91 @@ func assertSerialization(m *Map[string, string], txt string, t *testing.T) {
 	}
 }
 
+func TestMapIteratorFrom(t *testing.T) {
+	m := NewWith[int, string](utils.IntComparator, utils.StringComparator)
+	for i := 1; i <= 5; i++ {
+		m.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	// key, inclusive, whether Next() finds an element and its key, whether Prev() finds an element and its key
+	tests := [][]interface{}{
+		{30, true, true, 30, true, 20},
+		{30, false, true, 40, true, 30},
+		{25, true, true, 30, true, 20},
+		{25, false, true, 30, true, 20},
+		{50, false, false, 0, true, 50},
+		{60, true, false, 0, true, 50},
+		{5, true, true, 10, false, 0},
+		{10, true, true, 10, false, 0},
+	}
+	for _, test := range tests {
+		it := m.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Next(), test[2].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Key() != test[3].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[3], test)
+		}
+		it = m.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Prev(), test[4].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Key() != test[5].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[5], test)
+		}
+	}
+
+	it := m.IteratorFrom(20, true)
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50 50 40 30 20 10]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIteratorSeek(t *testing.T) {
+	m := NewWith[int, string](utils.IntComparator, utils.StringComparator)
+	for i := 1; i <= 5; i++ {
+		m.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	it := m.Iterator()
+	assertIteratorAt := func(actualFound bool, expectedFound bool, expectedKey int) {
+		t.Helper()
+		if actualFound != expectedFound {
+			t.Errorf("Got %v expected %v", actualFound, expectedFound)
+		} else if actualFound && it.Key() != expectedKey {
+			t.Errorf("Got %v expected %v", it.Key(), expectedKey)
+		}
+	}
+	assertIteratorAt(it.SeekGE(35), true, 40)
+	assertIteratorAt(it.Next(), true, 50)
+	assertIteratorAt(it.SeekGE(40), true, 40)
+	assertIteratorAt(it.Prev(), true, 30)
+	assertIteratorAt(it.SeekGE(60), false, 0)
+	assertIteratorAt(it.Prev(), true, 50)
+	assertIteratorAt(it.SeekLE(35), true, 30)
+	assertIteratorAt(it.Prev(), true, 20)
+	assertIteratorAt(it.SeekLE(30), true, 30)
+	assertIteratorAt(it.Next(), true, 40)
+	assertIteratorAt(it.SeekLE(5), false, 0)
+	assertIteratorAt(it.Next(), true, 10)
+	it.Seek(20)
+	assertIteratorAt(it.Next(), true, 20)
+	it.Seek(21)
+	assertIteratorAt(it.Prev(), true, 20)
+	it.Seek(60)
+	assertIteratorAt(it.Next(), false, 0)
+	it.Seek(60)
+	assertIteratorAt(it.Prev(), true, 50)
+	it.Seek(0)
+	assertIteratorAt(it.Prev(), false, 0)
+}
+
 func benchmarkGet(b *testing.B, m *Map[int, int], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
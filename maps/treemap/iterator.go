@@ -12,7 +12,7 @@ import (
 //var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
 
 // Iterator holding the iterator's state
-type Iterator[K, V comparable] struct {
+type Iterator[K, V any] struct {
 	iterator rbt.Iterator[K, V]
 }
 
@@ -21,6 +21,14 @@ func (m *Map[K, V]) Iterator() Iterator[K, V] {
 	return Iterator[K, V]{iterator: m.tree.Iterator()}
 }
 
+// IteratorFrom returns a stateful iterator positioned right before the first element whose key is bigger than
+// (or equal to, if inclusive) the given key, or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the element before it.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) IteratorFrom(key K, inclusive bool) Iterator[K, V] {
+	return Iterator[K, V]{iterator: m.tree.IteratorFrom(key, inclusive)}
+}
+
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
 // If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
@@ -60,6 +68,30 @@ func (iterator *Iterator[K, V]) End() {
 	iterator.iterator.End()
 }
 
+// Seek moves the iterator right before the first element whose key is equal or bigger than the given key,
+// or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the last element whose key is smaller than the given key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) Seek(key K) {
+	iterator.iterator.Seek(key)
+}
+
+// SeekGE moves the iterator to the first element whose key is equal or bigger than the given key and returns true
+// if there was such an element, whose key and value can then be retrieved by Key() and Value().
+// Otherwise the iterator is moved past the last element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) SeekGE(key K) bool {
+	return iterator.iterator.SeekGE(key)
+}
+
+// SeekLE moves the iterator to the last element whose key is equal or smaller than the given key and returns true
+// if there was such an element, whose key and value can then be retrieved by Key() and Value().
+// Otherwise the iterator is moved before the first element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) SeekLE(key K) bool {
+	return iterator.iterator.SeekLE(key)
+}
+
 // First moves the iterator to the first element and returns true if there was a first element in the container.
 // If First() returns true, then first element's key and value can be retrieved by Key() and Value().
 // Modifies the state of the iterator
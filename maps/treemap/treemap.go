@@ -22,25 +22,35 @@ import (
 //var _ maps.Map = (*Map)(nil)
 
 // Map holds the elements in a red-black tree
-type Map[K, V comparable] struct {
+type Map[K, V any] struct {
 	tree *rbt.Tree[K, V]
 }
 
 // NewWith instantiates a tree map with the custom comparator.
-func NewWith[K, V comparable](comparator utils.Comparator) *Map[K, V] {
+func NewWith[K, V any](comparator utils.Comparator) *Map[K, V] {
 	return &Map[K, V]{tree: rbt.NewWith[K, V](comparator)}
 }
 
 // NewWithIntComparator instantiates a tree map with the IntComparator, i.e. keys are of type int.
-func NewWithIntComparator[V comparable]() *Map[int, V] {
+func NewWithIntComparator[V any]() *Map[int, V] {
 	return &Map[int, V]{tree: rbt.NewWithIntComparator[V]()}
 }
 
 // NewWithStringComparator instantiates a tree map with the StringComparator, i.e. keys are of type string.
-func NewWithStringComparator[V comparable]() *Map[string, V] {
+func NewWithStringComparator[V any]() *Map[string, V] {
 	return &Map[string, V]{tree: rbt.NewWithStringComparator[V]()}
 }
 
+// NewFromSorted instantiates a tree map with the custom comparator and bulk-loads the given key-value pairs in O(n).
+// Keys must be strictly ascending under the comparator and have the same length as values, otherwise an error is returned.
+func NewFromSorted[K, V any](comparator utils.Comparator, keys []K, values []V) (*Map[K, V], error) {
+	m := NewWith[K, V](comparator)
+	if err := m.tree.BuildFromSorted(keys, values); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Put inserts key-value pair into the map.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (m *Map[K, V]) Put(key K, value V) {
@@ -60,6 +70,20 @@ func (m *Map[K, V]) Remove(key K) {
 	m.tree.Remove(key)
 }
 
+// RemoveRange removes all elements whose keys lie between lo and hi and returns the number of removed elements.
+// Each bound is included if its inclusive flag is set.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) RemoveRange(lo K, hi K, loInclusive bool, hiInclusive bool) int {
+	return m.tree.RemoveRange(lo, hi, loInclusive, hiInclusive)
+}
+
+// CountRange returns the number of elements whose keys lie between lo and hi.
+// Each bound is included if its inclusive flag is set.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[K, V]) CountRange(lo K, hi K, loInclusive bool, hiInclusive bool) int {
+	return m.tree.CountRange(lo, hi, loInclusive, hiInclusive)
+}
+
 // Empty returns true if map does not contain any elements
 func (m *Map[K, V]) Empty() bool {
 	return m.tree.Empty()
@@ -673,6 +673,26 @@ func TestMapIteratorPrevTo(t *testing.T) {
 	}
 }
 
+func TestMapNewFromSorted(t *testing.T) {
+	m, err := NewFromSorted[int, string](utils.IntComparator, []int{1, 2, 3}, []string{"a", "b", "c"})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys(), m.Values()), "[1 2 3] [a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	m.Put(0, "z")
+	if actualValue, expectedValue := m.Size(), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, err := NewFromSorted[int, string](utils.IntComparator, []int{1, 1}, []string{"a", "b"}); err == nil {
+		t.Errorf("Expected error for duplicate keys")
+	}
+}
+
 func TestMapSerialization(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		original := NewWithStringComparator[string]()
@@ -745,6 +765,184 @@ func assertSerialization(m *Map[string, string], txt string, t *testing.T) {
 	}
 }
 
+func TestMapRemoveRange(t *testing.T) {
+	m := NewWithIntComparator[string]()
+	for i := 1; i <= 10; i++ {
+		m.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	if actualValue, expectedValue := m.CountRange(20, 50, true, false), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.CountRange(20, 50, false, true), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.RemoveRange(0, 35, true, true), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys()), "[40 50 60 70 80 90 100]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.RemoveRange(60, 100, false, false), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys()), "[40 50 60 100]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.RemoveRange(100, 40, true, true), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.RemoveRange(40, 100, true, true), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIteratorFrom(t *testing.T) {
+	m := NewWithIntComparator[string]()
+	for i := 1; i <= 5; i++ {
+		m.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	// key, inclusive, whether Next() finds an element and its key, whether Prev() finds an element and its key
+	tests := [][]interface{}{
+		{30, true, true, 30, true, 20},
+		{30, false, true, 40, true, 30},
+		{25, true, true, 30, true, 20},
+		{25, false, true, 30, true, 20},
+		{50, false, false, 0, true, 50},
+		{60, true, false, 0, true, 50},
+		{5, true, true, 10, false, 0},
+		{10, true, true, 10, false, 0},
+	}
+	for _, test := range tests {
+		it := m.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Next(), test[2].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Key() != test[3].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[3], test)
+		}
+		it = m.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Prev(), test[4].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Key() != test[5].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[5], test)
+		}
+	}
+
+	it := m.IteratorFrom(20, true)
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50 50 40 30 20 10]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIteratorSeek(t *testing.T) {
+	m := NewWithIntComparator[string]()
+	for i := 1; i <= 5; i++ {
+		m.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	it := m.Iterator()
+	assertIteratorAt := func(actualFound bool, expectedFound bool, expectedKey int) {
+		t.Helper()
+		if actualFound != expectedFound {
+			t.Errorf("Got %v expected %v", actualFound, expectedFound)
+		} else if actualFound && it.Key() != expectedKey {
+			t.Errorf("Got %v expected %v", it.Key(), expectedKey)
+		}
+	}
+	assertIteratorAt(it.SeekGE(35), true, 40)
+	assertIteratorAt(it.Next(), true, 50)
+	assertIteratorAt(it.SeekGE(40), true, 40)
+	assertIteratorAt(it.Prev(), true, 30)
+	assertIteratorAt(it.SeekGE(60), false, 0)
+	assertIteratorAt(it.Prev(), true, 50)
+	assertIteratorAt(it.SeekLE(35), true, 30)
+	assertIteratorAt(it.Prev(), true, 20)
+	assertIteratorAt(it.SeekLE(30), true, 30)
+	assertIteratorAt(it.Next(), true, 40)
+	assertIteratorAt(it.SeekLE(5), false, 0)
+	assertIteratorAt(it.Next(), true, 10)
+	it.Seek(20)
+	assertIteratorAt(it.Next(), true, 20)
+	it.Seek(21)
+	assertIteratorAt(it.Prev(), true, 20)
+	it.Seek(60)
+	assertIteratorAt(it.Next(), false, 0)
+	it.Seek(60)
+	assertIteratorAt(it.Prev(), true, 50)
+	it.Seek(0)
+	assertIteratorAt(it.Prev(), false, 0)
+}
+
+type version struct {
+	Parts []int
+}
+
+func versionComparator(a, b interface{}) int {
+	x, y := a.(version).Parts, b.(version).Parts
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if c := utils.IntComparator(x[i], y[i]); c != 0 {
+			return c
+		}
+	}
+	return utils.IntComparator(len(x), len(y))
+}
+
+func TestMapNonComparable(t *testing.T) {
+	m := NewWith[version, []string](versionComparator)
+	m.Put(version{[]int{1, 10}}, []string{"c"})
+	m.Put(version{[]int{1, 2}}, []string{"a"})
+	m.Put(version{[]int{1, 2, 1}}, []string{"b", "b"})
+	m.Put(version{[]int{1, 2}}, []string{"a", "a"})
+
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get(version{[]int{1, 2}}); !found || fmt.Sprint(actualValue) != "[a a]" {
+		t.Errorf("Got %v expected %v", actualValue, "[a a]")
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys()), "[{[1 2]} {[1 2 1]} {[1 10]}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	selected := m.Select(func(key version, value []string) bool {
+		return len(value) == 2
+	})
+	if actualValue, expectedValue := fmt.Sprint(selected.Values()), "[[a a] [b b]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	serialized, err := m.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(serialized), `{"{\"Parts\":[1,10]}":["c"],"{\"Parts\":[1,2,1]}":["b","b"],"{\"Parts\":[1,2]}":["a","a"]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	deserialized := NewWith[version, []string](versionComparator)
+	if err := deserialized.FromJSON(serialized); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := deserialized.String(), m.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	m.Remove(version{[]int{1, 2, 1}})
+	if actualValue, expectedValue := fmt.Sprint(m.Values()), "[[a a] [c]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func benchmarkGet[V comparable](b *testing.B, m *Map[int, V], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
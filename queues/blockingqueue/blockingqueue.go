@@ -0,0 +1,239 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package blockingqueue implements a blocking queue on top of any queue.
+//
+// Put blocks while the queue is full and Take blocks while it is empty, both until the context is done.
+// Offer and Poll do the same with a timeout. The order of the elements is defined by the underlying queue,
+// e.g. a priority queue makes Take return the highest priority element.
+//
+// Closing the queue rejects further elements, while the remaining elements can still be taken (drained).
+//
+// Structure is thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Producer%E2%80%93consumer_problem
+package blockingqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kcswag/kcgods/queues"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by Put once the queue has been closed and by Take once it has been closed and drained.
+var ErrClosed = errors.New("blockingqueue: queue is closed")
+
+// Queue guards the underlying queue and lets callers wait for elements or free space.
+type Queue[E any] struct {
+	queue    queues.Queue[E]
+	capacity int // maximum number of elements, 0 if only limited by the underlying queue
+	closed   bool
+	mutex    sync.Mutex
+	notEmpty chan struct{} // closed when an element has been added or the queue has been closed, nil without waiters
+	notFull  chan struct{} // closed when an element has been removed or the queue has been closed, nil without waiters
+}
+
+// New instantiates a new blocking queue on top of the queue, which must not be used directly afterwards.
+// The queue is full only if the underlying queue has a Full method that says so, e.g. a circular buffer.
+func New[E any](queue queues.Queue[E]) *Queue[E] {
+	return &Queue[E]{queue: queue}
+}
+
+// NewBounded instantiates a new blocking queue on top of the queue, which holds at most capacity elements.
+func NewBounded[E any](queue queues.Queue[E], capacity int) *Queue[E] {
+	if capacity < 1 {
+		panic("Invalid capacity, should be at least 1")
+	}
+	return &Queue[E]{queue: queue, capacity: capacity}
+}
+
+// Put adds the value to the queue, waiting while the queue is full.
+// Returns ErrClosed if the queue has been closed, or the context's error if it was done before there was room
+// for the value. If there is room, the value is added even if the context is already done.
+func (queue *Queue[E]) Put(ctx context.Context, value E) error {
+	for {
+		queue.mutex.Lock()
+		if queue.closed {
+			queue.mutex.Unlock()
+			return ErrClosed
+		}
+		if !queue.full() {
+			queue.queue.Enqueue(value)
+			signal(&queue.notEmpty)
+			queue.mutex.Unlock()
+			return nil
+		}
+		wait := channel(&queue.notFull)
+		queue.mutex.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Take removes the first element of the queue and returns it, waiting while the queue is empty.
+// Returns ErrClosed if the queue has been closed and all its elements have been taken, or the context's error
+// if it was done before there was an element. If there is an element, it is taken even if the context is already done.
+func (queue *Queue[E]) Take(ctx context.Context) (value E, err error) {
+	for {
+		queue.mutex.Lock()
+		if value, ok := queue.queue.Dequeue(); ok {
+			signal(&queue.notFull)
+			queue.mutex.Unlock()
+			return value, nil
+		}
+		if queue.closed {
+			queue.mutex.Unlock()
+			return value, ErrClosed
+		}
+		wait := channel(&queue.notEmpty)
+		queue.mutex.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return value, ctx.Err()
+		}
+	}
+}
+
+// Offer adds the value to the queue, waiting at most timeout while the queue is full.
+// Returns true if the value was added, a zero or negative timeout only adds the value if there is room right away.
+func (queue *Queue[E]) Offer(value E, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return queue.Put(ctx, value) == nil
+}
+
+// Poll removes the first element of the queue and returns it, waiting at most timeout while the queue is empty.
+// Second return parameter is true, unless there was no element within the timeout or the queue has been drained
+// after it was closed. A zero or negative timeout only takes an element that is there right away.
+func (queue *Queue[E]) Poll(timeout time.Duration) (value E, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	value, err := queue.Take(ctx)
+	return value, err == nil
+}
+
+// Peek returns first element of the queue without removing it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *Queue[E]) Peek() (value E, ok bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Peek()
+}
+
+// Drain removes all elements from the queue and returns them in the order they would have been taken.
+func (queue *Queue[E]) Drain() []E {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	values := make([]E, 0, queue.queue.Size())
+	for {
+		value, ok := queue.queue.Dequeue()
+		if !ok {
+			break
+		}
+		values = append(values, value)
+	}
+	signal(&queue.notFull)
+	return values
+}
+
+// Close rejects any further elements and wakes up all waiting callers. Elements that are still in the queue
+// can be taken until it is empty, after which Take returns ErrClosed. Closing a closed queue does nothing.
+func (queue *Queue[E]) Close() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.closed {
+		return
+	}
+	queue.closed = true
+	signal(&queue.notEmpty)
+	signal(&queue.notFull)
+}
+
+// Closed returns true if the queue has been closed.
+func (queue *Queue[E]) Closed() bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.closed
+}
+
+// Capacity returns the maximum number of elements of a bounded queue, or 0 if the queue is only limited by
+// the underlying queue.
+func (queue *Queue[E]) Capacity() int {
+	return queue.capacity
+}
+
+// Empty returns true if queue does not contain any elements.
+func (queue *Queue[E]) Empty() bool {
+	return queue.Size() == 0
+}
+
+// Size returns number of elements within the queue.
+func (queue *Queue[E]) Size() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Size()
+}
+
+// Clear removes all elements from the queue.
+func (queue *Queue[E]) Clear() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.queue.Clear()
+	signal(&queue.notFull)
+}
+
+// Values returns all elements in the queue in the order of the underlying queue.
+func (queue *Queue[E]) Values() []E {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Values()
+}
+
+// String returns a string representation of container
+func (queue *Queue[E]) String() string {
+	str := "BlockingQueue\n"
+	values := []string{}
+	for _, value := range queue.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// full returns true if the queue has reached its capacity or the underlying queue is full.
+func (queue *Queue[E]) full() bool {
+	if queue.capacity > 0 && queue.queue.Size() >= queue.capacity {
+		return true
+	}
+	if bounded, ok := queue.queue.(interface{ Full() bool }); ok {
+		return bounded.Full()
+	}
+	return false
+}
+
+// channel returns the channel to wait on, creating it if there are no other waiters yet.
+func channel(ch *chan struct{}) chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	return *ch
+}
+
+// signal wakes up all callers waiting on the channel, if any.
+func signal(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockingqueue
+
+import (
+	"context"
+	"github.com/kcswag/kcgods/queues/arrayqueue"
+	"github.com/kcswag/kcgods/queues/circularbuffer"
+	"github.com/kcswag/kcgods/queues/linkedlistqueue"
+	"github.com/kcswag/kcgods/queues/priorityqueue"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueuePutTake(t *testing.T) {
+	queue := NewBounded[int](arrayqueue.New[int](), 2)
+	ctx := context.Background()
+
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := queue.Capacity(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	for _, value := range []int{1, 2} {
+		if err := queue.Put(ctx, value); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	if actualValue := queue.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, ok := queue.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue := queue.Values(); actualValue[0] != 1 || actualValue[1] != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2]")
+	}
+	for _, expectedValue := range []int{1, 2} {
+		if actualValue, err := queue.Take(ctx); actualValue != expectedValue || err != nil {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if _, ok := queue.Peek(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+}
+
+func TestBlockingQueuePutBlocksWhileFull(t *testing.T) {
+	queue := NewBounded[int](linkedlistqueue.New[int](), 1)
+	ctx := context.Background()
+	queue.Put(ctx, 1)
+
+	done := make(chan error)
+	go func() {
+		done <- queue.Put(ctx, 2)
+	}()
+	select {
+	case <-done:
+		t.Fatalf("Put returned while the queue was full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if actualValue, _ := queue.Take(ctx); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, _ := queue.Take(ctx); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+}
+
+func TestBlockingQueueTakeBlocksWhileEmpty(t *testing.T) {
+	queue := New[int](arrayqueue.New[int]())
+	ctx := context.Background()
+
+	done := make(chan int)
+	go func() {
+		value, _ := queue.Take(ctx)
+		done <- value
+	}()
+	select {
+	case <-done:
+		t.Fatalf("Take returned while the queue was empty")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	queue.Put(ctx, 1)
+	if actualValue := <-done; actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestBlockingQueueContext(t *testing.T) {
+	queue := NewBounded[int](arrayqueue.New[int](), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := queue.Take(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Got %v expected %v", err, context.DeadlineExceeded)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := queue.Put(ctx, 1); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if err := queue.Put(ctx, 2); err != context.Canceled {
+		t.Errorf("Got %v expected %v", err, context.Canceled)
+	}
+	if actualValue, err := queue.Take(ctx); actualValue != 1 || err != nil {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue := queue.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestBlockingQueueOfferPoll(t *testing.T) {
+	queue := NewBounded[string](arrayqueue.New[string](), 1)
+
+	if actualValue := queue.Offer("a", 0); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	start := time.Now()
+	if actualValue := queue.Offer("b", 10*time.Millisecond); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Got %v expected at least %v", elapsed, 10*time.Millisecond)
+	}
+	if actualValue, ok := queue.Poll(0); actualValue != "a" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+	if _, ok := queue.Poll(10 * time.Millisecond); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Offer("c", time.Second)
+	}()
+	if actualValue, ok := queue.Poll(time.Second); actualValue != "c" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "c")
+	}
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	queue := New[int](arrayqueue.New[int]())
+	ctx := context.Background()
+	queue.Put(ctx, 1)
+	queue.Put(ctx, 2)
+
+	queue.Close()
+	queue.Close()
+	if actualValue := queue.Closed(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if err := queue.Put(ctx, 3); err != ErrClosed {
+		t.Errorf("Got %v expected %v", err, ErrClosed)
+	}
+	if actualValue := queue.Offer(3, time.Second); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	for _, expectedValue := range []int{1, 2} {
+		if actualValue, err := queue.Take(ctx); actualValue != expectedValue || err != nil {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if _, err := queue.Take(ctx); err != ErrClosed {
+		t.Errorf("Got %v expected %v", err, ErrClosed)
+	}
+	if _, ok := queue.Poll(time.Second); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+}
+
+func TestBlockingQueueCloseWakesWaiters(t *testing.T) {
+	empty := New[int](arrayqueue.New[int]())
+	full := NewBounded[int](arrayqueue.New[int](), 1)
+	full.Put(context.Background(), 1)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := empty.Take(context.Background())
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- full.Put(context.Background(), 2)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	empty.Close()
+	full.Close()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != ErrClosed {
+			t.Errorf("Got %v expected %v", err, ErrClosed)
+		}
+	}
+	if actualValue := full.Drain(); len(actualValue) != 1 || actualValue[0] != 1 {
+		t.Errorf("Got %v expected %v", actualValue, "[1]")
+	}
+}
+
+func TestBlockingQueueDrainClear(t *testing.T) {
+	queue := NewBounded[int](arrayqueue.New[int](), 3)
+	ctx := context.Background()
+	queue.Put(ctx, 1)
+	queue.Put(ctx, 2)
+	queue.Put(ctx, 3)
+
+	if actualValue := queue.Drain(); len(actualValue) != 3 || actualValue[0] != 1 || actualValue[2] != 3 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2,3]")
+	}
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	queue.Put(ctx, 4)
+	queue.Put(ctx, 5)
+	queue.Put(ctx, 6)
+	done := make(chan error)
+	go func() {
+		done <- queue.Put(ctx, 7)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Clear()
+	if err := <-done; err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue := queue.Values(); len(actualValue) != 1 || actualValue[0] != 7 {
+		t.Errorf("Got %v expected %v", actualValue, "[7]")
+	}
+}
+
+func TestBlockingQueueCircularBuffer(t *testing.T) {
+	queue := New[int](circularbuffer.New[int](2))
+	ctx := context.Background()
+	queue.Put(ctx, 1)
+	queue.Put(ctx, 2)
+
+	if actualValue := queue.Offer(3, 0); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := queue.Values(); actualValue[0] != 1 || actualValue[1] != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2]")
+	}
+}
+
+func TestBlockingQueuePriority(t *testing.T) {
+	queue := NewBounded[int](priorityqueue.NewWith[int](utils.IntComparator), 3)
+	ctx := context.Background()
+	queue.Put(ctx, 3)
+	queue.Put(ctx, 1)
+	queue.Put(ctx, 2)
+
+	if actualValue := queue.Offer(0, 0); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	for _, expectedValue := range []int{1, 2, 3} {
+		if actualValue, _ := queue.Take(ctx); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestBlockingQueueString(t *testing.T) {
+	queue := New[int](arrayqueue.New[int]())
+	queue.Put(context.Background(), 1)
+	queue.Put(context.Background(), 2)
+	if actualValue := queue.String(); !strings.HasPrefix(actualValue, "BlockingQueue") || !strings.HasSuffix(actualValue, "1, 2") {
+		t.Errorf("Got %v expected %v", actualValue, "BlockingQueue\n1, 2")
+	}
+}
+
+func TestBlockingQueueInvalidCapacity(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for capacity 0")
+		}
+	}()
+	NewBounded[int](arrayqueue.New[int](), 0)
+}
+
+// stress runs producers and consumers concurrently and checks that every value is taken exactly once.
+func stress(t *testing.T, queue *Queue[int], producers, consumers, count int) {
+	ctx := context.Background()
+	var producing, consuming sync.WaitGroup
+	taken := make([][]int, consumers)
+	for p := 0; p < producers; p++ {
+		producing.Add(1)
+		go func(p int) {
+			defer producing.Done()
+			for i := 0; i < count; i++ {
+				value := p*count + i
+				if i%2 == 0 {
+					if err := queue.Put(ctx, value); err != nil {
+						t.Errorf("Got error %v", err)
+					}
+				} else {
+					for !queue.Offer(value, time.Millisecond) {
+					}
+				}
+			}
+		}(p)
+	}
+	for c := 0; c < consumers; c++ {
+		consuming.Add(1)
+		go func(c int) {
+			defer consuming.Done()
+			for i := 0; ; i++ {
+				var value int
+				var err error
+				if i%2 == 0 {
+					value, err = queue.Take(ctx)
+				} else if v, ok := queue.Poll(time.Millisecond); ok {
+					value = v
+				} else if queue.Closed() && queue.Empty() {
+					err = ErrClosed
+				} else {
+					continue
+				}
+				if err == ErrClosed {
+					return
+				}
+				if err != nil {
+					t.Errorf("Got error %v", err)
+					return
+				}
+				taken[c] = append(taken[c], value)
+			}
+		}(c)
+	}
+
+	producing.Wait()
+	queue.Close()
+	consuming.Wait()
+
+	seen := make([]bool, producers*count)
+	for _, values := range taken {
+		for _, value := range values {
+			if seen[value] {
+				t.Fatalf("Value %v was taken twice", value)
+			}
+			seen[value] = true
+		}
+	}
+	for value, ok := range seen {
+		if !ok {
+			t.Fatalf("Value %v was never taken", value)
+		}
+	}
+	if actualValue := queue.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestBlockingQueueStress(t *testing.T) {
+	stress(t, NewBounded[int](arrayqueue.New[int](), 4), 8, 8, 2000)
+}
+
+func TestBlockingQueueStressUnbounded(t *testing.T) {
+	stress(t, New[int](linkedlistqueue.New[int]()), 4, 2, 2000)
+}
+
+func TestBlockingQueueStressPriority(t *testing.T) {
+	stress(t, NewBounded[int](priorityqueue.NewWith[int](utils.IntComparator), 16), 8, 4, 2000)
+}
+
+func TestBlockingQueueStressCircularBuffer(t *testing.T) {
+	stress(t, New[int](circularbuffer.New[int](8)), 4, 8, 2000)
+}
+
+func benchmarkPutTake(b *testing.B, queue *Queue[int], producers int) {
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				queue.Put(ctx, i)
+			}
+		}(b.N / producers)
+	}
+	for i := 0; i < b.N/producers*producers; i++ {
+		queue.Take(ctx)
+	}
+	wg.Wait()
+}
+
+func BenchmarkBlockingQueuePutTake1(b *testing.B) {
+	benchmarkPutTake(b, NewBounded[int](arrayqueue.New[int](), 100), 1)
+}
+
+func BenchmarkBlockingQueuePutTake8(b *testing.B) {
+	benchmarkPutTake(b, NewBounded[int](arrayqueue.New[int](), 100), 8)
+}
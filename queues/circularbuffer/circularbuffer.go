@@ -6,6 +6,10 @@
 //
 // In computer science, a circular buffer, circular queue, cyclic buffer or ring buffer is a data structure that uses a single, fixed-size buffer as if it were connected end-to-end. This structure lends itself easily to buffering data streams.
 //
+// The policy decides what happens when a value is enqueued into a full buffer: the oldest element is overwritten
+// (default), the new value is rejected, or the buffer grows. Dropped elements are counted and can be reported to a
+// callback.
+//
 // Structure is not thread safe.
 //
 // Reference: https://en.wikipedia.org/wiki/Circular_buffer
@@ -13,10 +17,21 @@ package circularbuffer
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 )
 
+// Policy decides what happens when a value is enqueued into a full buffer.
+type Policy int
+
+const (
+	// Overwrite drops the oldest element to make room for the new value.
+	Overwrite Policy = iota
+	// Reject drops the new value and keeps the buffer unchanged.
+	Reject
+	// Grow doubles the maximum size of the buffer, so that no element is dropped.
+	Grow
+)
+
 // Queue holds values in a slice.
 type Queue[T any] struct {
 	values  []T
@@ -25,23 +40,47 @@ type Queue[T any] struct {
 	full    bool
 	maxSize int
 	size    int
+	policy  Policy
+	dropped int
+	onDrop  func(value T)
 }
 
 // New instantiates a new empty queue with the specified size of maximum number of elements that it can hold.
-// This max size of the buffer cannot be changed.
+// When the queue is full, enqueueing a value overwrites the oldest element.
 func New[T any](maxSize int) *Queue[T] {
+	return NewWith[T](maxSize, Overwrite)
+}
+
+// NewWith instantiates a new empty queue with the specified size of maximum number of elements that it can hold
+// and the policy that decides what happens when a value is enqueued into the full queue.
+func NewWith[T any](maxSize int, policy Policy) *Queue[T] {
 	if maxSize < 1 {
 		panic("Invalid maxSize, should be at least 1")
 	}
-	queue := &Queue[T]{maxSize: maxSize}
+	queue := &Queue[T]{maxSize: maxSize, policy: policy}
 	queue.Clear()
 	return queue
 }
 
-// Enqueue adds a value to the end of the queue
+// Enqueue adds a value to the end of the queue, applying the policy if the queue is full.
 func (queue *Queue[T]) Enqueue(value T) {
-	if queue.Full() {
-		queue.Dequeue()
+	queue.TryEnqueue(value)
+}
+
+// TryEnqueue adds a value to the end of the queue, applying the policy if the queue is full.
+// Returns false if the value has been rejected, which only happens with the Reject policy.
+func (queue *Queue[T]) TryEnqueue(value T) bool {
+	if queue.size == queue.maxSize {
+		switch queue.policy {
+		case Reject:
+			queue.drop(value)
+			return false
+		case Grow:
+			queue.resize(2 * queue.maxSize)
+		default:
+			oldest, _ := queue.Dequeue()
+			queue.drop(oldest)
+		}
 	}
 	queue.values[queue.end] = value
 	queue.end = queue.end + 1
@@ -53,6 +92,19 @@ func (queue *Queue[T]) Enqueue(value T) {
 	}
 
 	queue.size = queue.calculateSize()
+	return true
+}
+
+// EnqueueAll adds the values to the end of the queue one after another, applying the policy whenever the queue
+// is full. Returns the number of values that have not been rejected.
+func (queue *Queue[T]) EnqueueAll(values ...T) int {
+	count := 0
+	for _, value := range values {
+		if queue.TryEnqueue(value) {
+			count++
+		}
+	}
+	return count
 }
 
 // Dequeue removes first element of the queue and returns it, or nil if queue is empty.
@@ -64,20 +116,33 @@ func (queue *Queue[T]) Dequeue() (value T, ok bool) {
 
 	value, ok = queue.values[queue.start], true
 
-	if !reflect.DeepEqual(value, *new(T)) {
-		queue.values[queue.start] = *new(T)
-		queue.start = queue.start + 1
-		if queue.start >= queue.maxSize {
-			queue.start = 0
-		}
-		queue.full = false
+	queue.values[queue.start] = *new(T)
+	queue.start = queue.start + 1
+	if queue.start >= queue.maxSize {
+		queue.start = 0
 	}
+	queue.full = false
 
 	queue.size = queue.size - 1
 
 	return
 }
 
+// DequeueN removes up to n elements from the front of the queue and returns them (FIFO order).
+func (queue *Queue[T]) DequeueN(n int) []T {
+	if n > queue.size {
+		n = queue.size
+	}
+	if n < 0 {
+		n = 0
+	}
+	values := make([]T, n, n)
+	for i := range values {
+		values[i], _ = queue.Dequeue()
+	}
+	return values
+}
+
 // Peek returns first element of the queue without removing it, or nil if queue is empty.
 // Second return parameter is true, unless the queue was empty and there was nothing to peek.
 func (queue *Queue[T]) Peek() (value T, ok bool) {
@@ -87,14 +152,59 @@ func (queue *Queue[T]) Peek() (value T, ok bool) {
 	return queue.values[queue.start], true
 }
 
+// Get returns the element at index, counted from the first element of the queue.
+// Second return parameter is true if index is within bounds of the queue and queue is not empty, otherwise false.
+func (queue *Queue[T]) Get(index int) (T, bool) {
+	if !queue.withinRange(index) {
+		return *new(T), false
+	}
+	return queue.values[(queue.start+index)%queue.maxSize], true
+}
+
+// Resize changes the maximum number of elements that the queue can hold and keeps the order of the elements.
+// If the queue holds more elements than the new maximum, the oldest elements are dropped.
+func (queue *Queue[T]) Resize(maxSize int) {
+	if maxSize < 1 {
+		panic("Invalid maxSize, should be at least 1")
+	}
+	for queue.size > maxSize {
+		oldest, _ := queue.Dequeue()
+		queue.drop(oldest)
+	}
+	queue.resize(maxSize)
+}
+
+// MaxSize returns the maximum number of elements that the queue can hold before the policy applies.
+func (queue *Queue[T]) MaxSize() int {
+	return queue.maxSize
+}
+
+// Policy returns the policy that applies when a value is enqueued into the full queue.
+func (queue *Queue[T]) Policy() Policy {
+	return queue.policy
+}
+
+// Dropped returns the number of elements that have been dropped so far, either overwritten, rejected,
+// or removed by Resize.
+func (queue *Queue[T]) Dropped() int {
+	return queue.dropped
+}
+
+// OnDrop registers a function that is called with every element that is dropped from now on,
+// nil removes the function.
+func (queue *Queue[T]) OnDrop(f func(value T)) {
+	queue.onDrop = f
+}
+
 // Empty returns true if queue does not contain any elements.
 func (queue *Queue[T]) Empty() bool {
 	return queue.Size() == 0
 }
 
 // Full returns true if the queue is full, i.e. has reached the maximum number of elements that it can hold.
+// A queue with the Grow policy is never full.
 func (queue *Queue[T]) Full() bool {
-	return queue.Size() == queue.maxSize
+	return queue.policy != Grow && queue.Size() == queue.maxSize
 }
 
 // Size returns number of elements within the queue.
@@ -136,6 +246,27 @@ func (queue *Queue[T]) withinRange(index int) bool {
 	return index >= 0 && index < queue.size
 }
 
+// drop counts the dropped value and reports it.
+func (queue *Queue[T]) drop(value T) {
+	queue.dropped++
+	if queue.onDrop != nil {
+		queue.onDrop(value)
+	}
+}
+
+// resize moves the elements, which must fit, into a new buffer of the size starting at its first index.
+func (queue *Queue[T]) resize(maxSize int) {
+	values := make([]T, maxSize, maxSize)
+	for i := 0; i < queue.size; i++ {
+		values[i] = queue.values[(queue.start+i)%queue.maxSize]
+	}
+	queue.values = values
+	queue.maxSize = maxSize
+	queue.start = 0
+	queue.end = queue.size % maxSize
+	queue.full = queue.size == maxSize
+}
+
 func (queue *Queue[T]) calculateSize() int {
 	if queue.end < queue.start {
 		return queue.maxSize - queue.start + queue.end
@@ -150,6 +150,22 @@ func TestQueueDequeueFull(t *testing.T) {
 	assert(len(queue.Values()), 0)
 }
 
+func TestQueueDequeueZeroValue(t *testing.T) {
+	queue := New[int](2)
+	queue.Enqueue(0)
+	queue.Enqueue(1)
+
+	for _, expectedValue := range []int{0, 1} {
+		if actualValue, ok := queue.Dequeue(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	queue.Enqueue(2)
+	if actualValue := queue.Values(); len(actualValue) != 1 || actualValue[0] != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[2]")
+	}
+}
+
 func TestQueueIteratorOnEmpty(t *testing.T) {
 	queue := New[int](3)
 	it := queue.Iterator()
@@ -481,6 +497,167 @@ func TestQueueIterator(t *testing.T) {
 	}
 }
 
+func TestQueuePolicyOverwrite(t *testing.T) {
+	queue := New[int](2)
+	var dropped []int
+	queue.OnDrop(func(value int) {
+		dropped = append(dropped, value)
+	})
+
+	if actualValue := queue.Policy(); actualValue != Overwrite {
+		t.Errorf("Got %v expected %v", actualValue, Overwrite)
+	}
+	if actualValue := queue.EnqueueAll(1, 2, 3, 4); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := queue.TryEnqueue(5); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := queue.Values(); actualValue[0] != 4 || actualValue[1] != 5 {
+		t.Errorf("Got %v expected %v", actualValue, "[4,5]")
+	}
+	if actualValue := queue.Dropped(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue := fmt.Sprint(dropped); actualValue != "[1 2 3]" {
+		t.Errorf("Got %v expected %v", actualValue, "[1 2 3]")
+	}
+}
+
+func TestQueuePolicyReject(t *testing.T) {
+	queue := NewWith[int](2, Reject)
+	var dropped []int
+	queue.OnDrop(func(value int) {
+		dropped = append(dropped, value)
+	})
+
+	if actualValue := queue.EnqueueAll(1, 2, 3, 4); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := queue.Full(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := queue.TryEnqueue(5); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	queue.Enqueue(6)
+	if actualValue := queue.Values(); actualValue[0] != 1 || actualValue[1] != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2]")
+	}
+	if actualValue := queue.Dropped(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := fmt.Sprint(dropped); actualValue != "[3 4 5 6]" {
+		t.Errorf("Got %v expected %v", actualValue, "[3 4 5 6]")
+	}
+
+	queue.Dequeue()
+	if actualValue := queue.TryEnqueue(7); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := queue.Values(); actualValue[0] != 2 || actualValue[1] != 7 {
+		t.Errorf("Got %v expected %v", actualValue, "[2,7]")
+	}
+}
+
+func TestQueuePolicyGrow(t *testing.T) {
+	queue := NewWith[int](2, Grow)
+	queue.Enqueue(0)
+	queue.Dequeue()
+
+	for i := 1; i <= 5; i++ {
+		queue.Enqueue(i)
+		if actualValue := queue.Full(); actualValue != false {
+			t.Errorf("Got %v expected %v", actualValue, false)
+		}
+	}
+	if actualValue := queue.MaxSize(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue := fmt.Sprint(queue.Values()); actualValue != "[1 2 3 4 5]" {
+		t.Errorf("Got %v expected %v", actualValue, "[1 2 3 4 5]")
+	}
+	if actualValue := queue.Dropped(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestQueueDequeueN(t *testing.T) {
+	queue := New[int](4)
+	queue.EnqueueAll(1, 2, 3, 4, 5)
+
+	if actualValue := fmt.Sprint(queue.DequeueN(2)); actualValue != "[2 3]" {
+		t.Errorf("Got %v expected %v", actualValue, "[2 3]")
+	}
+	if actualValue := fmt.Sprint(queue.DequeueN(5)); actualValue != "[4 5]" {
+		t.Errorf("Got %v expected %v", actualValue, "[4 5]")
+	}
+	if actualValue := queue.DequeueN(1); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	if actualValue := queue.DequeueN(-1); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+}
+
+func TestQueueGet(t *testing.T) {
+	queue := New[string](3)
+	queue.EnqueueAll("a", "b", "c", "d")
+
+	tests := [][]interface{}{
+		{0, "b", true},
+		{1, "c", true},
+		{2, "d", true},
+		{3, "", false},
+		{-1, "", false},
+	}
+	for _, test := range tests {
+		if actualValue, ok := queue.Get(test[0].(int)); actualValue != test[1] || ok != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+	}
+}
+
+func TestQueueResize(t *testing.T) {
+	queue := New[int](4)
+	queue.EnqueueAll(1, 2, 3, 4, 5, 6)
+	var dropped []int
+	queue.OnDrop(func(value int) {
+		dropped = append(dropped, value)
+	})
+
+	queue.Resize(6)
+	if actualValue := queue.MaxSize(); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	queue.EnqueueAll(7, 8)
+	if actualValue := fmt.Sprint(queue.Values()); actualValue != "[3 4 5 6 7 8]" {
+		t.Errorf("Got %v expected %v", actualValue, "[3 4 5 6 7 8]")
+	}
+
+	queue.Resize(3)
+	if actualValue := fmt.Sprint(queue.Values()); actualValue != "[6 7 8]" {
+		t.Errorf("Got %v expected %v", actualValue, "[6 7 8]")
+	}
+	if actualValue := fmt.Sprint(dropped); actualValue != "[3 4 5]" {
+		t.Errorf("Got %v expected %v", actualValue, "[3 4 5]")
+	}
+	if actualValue := queue.Dropped(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	queue.Enqueue(9)
+	if actualValue := fmt.Sprint(queue.Values()); actualValue != "[7 8 9]" {
+		t.Errorf("Got %v expected %v", actualValue, "[7 8 9]")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for maxSize 0")
+		}
+	}()
+	queue.Resize(0)
+}
+
 func TestQueueSerialization(t *testing.T) {
 	queue := New[any](3)
 	queue.Enqueue("a")
@@ -508,6 +685,18 @@ func TestQueueSerialization(t *testing.T) {
 	err = queue.FromJSON(bytes)
 	assert()
 
+	queue.Enqueue("a") // wraps around
+	queue.Dequeue()
+	queue.Enqueue("b")
+	bytes, err = queue.ToJSON()
+	if actualValue, expectedValue := string(bytes), `["c","a","b"]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = queue.FromJSON([]byte(`["a","b","c","d"]`))
+	if actualValue, expectedValue := fmt.Sprintf("%s%s%s", queue.Values()...), "bcd"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
 	bytes, err = json.Marshal([]interface{}{"a", "b", "c", queue})
 	if err != nil {
 		t.Errorf("Got error %v", err)
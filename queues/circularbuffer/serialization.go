@@ -12,19 +12,18 @@ import (
 //var _ containers.JSONSerializer = (*Queue)(nil)
 //var _ containers.JSONDeserializer = (*Queue)(nil)
 
-// ToJSON outputs the JSON representation of queue's elements.
+// ToJSON outputs the JSON representation of queue's elements (FIFO order).
 func (queue *Queue[T]) ToJSON() ([]byte, error) {
-	return json.Marshal(queue.values[:queue.maxSize])
+	return json.Marshal(queue.Values())
 }
 
-// FromJSON populates list's elements from the input JSON representation.
+// FromJSON populates queue's elements from the input JSON representation, applying the policy if they do not fit.
 func (queue *Queue[T]) FromJSON(data []byte) error {
 	var values []T
 	err := json.Unmarshal(data, &values)
 	if err == nil {
-		for _, value := range values {
-			queue.Enqueue(value)
-		}
+		queue.Clear()
+		queue.EnqueueAll(values...)
 	}
 	return err
 }
@@ -0,0 +1,177 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package delayqueue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BlockingQueue guards a delay queue and lets callers wait until its next element is ready.
+type BlockingQueue[E any] struct {
+	queue  *Queue[E]
+	mutex  sync.Mutex
+	notify chan struct{} // closed when an element has been added, nil without waiters
+}
+
+// NewBlocking instantiates a new empty blocking queue that uses the system clock.
+func NewBlocking[E any]() *BlockingQueue[E] {
+	return NewBlockingWithClock[E](SystemClock{})
+}
+
+// NewBlockingWithClock instantiates a new empty blocking queue that uses the clock to tell which elements are
+// ready and to wait for them.
+func NewBlockingWithClock[E any](clock Clock) *BlockingQueue[E] {
+	return &BlockingQueue[E]{queue: NewWithClock[E](clock)}
+}
+
+// Enqueue adds a value that becomes ready at the time and wakes up the waiting callers.
+func (queue *BlockingQueue[E]) Enqueue(value E, readyAt time.Time) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.queue.Enqueue(value, readyAt)
+	signal(&queue.notify)
+}
+
+// EnqueueAfter adds a value that becomes ready once the delay has elapsed from now on.
+func (queue *BlockingQueue[E]) EnqueueAfter(value E, delay time.Duration) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.queue.EnqueueAfter(value, delay)
+	signal(&queue.notify)
+}
+
+// Dequeue removes the first ready element of the queue and returns it without waiting, or nil if no element is ready.
+// Second return parameter is true, unless no element was ready and there was nothing to dequeue.
+func (queue *BlockingQueue[E]) Dequeue() (value E, ok bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Dequeue()
+}
+
+// Take removes the first ready element of the queue and returns it, waiting until the next element is ready.
+// An element enqueued while waiting that is ready earlier is taken at its own time.
+// Returns the context's error if it was done before an element was ready. If an element is ready, it is taken
+// even if the context is already done.
+func (queue *BlockingQueue[E]) Take(ctx context.Context) (value E, err error) {
+	for {
+		queue.mutex.Lock()
+		if value, ok := queue.queue.Dequeue(); ok {
+			queue.mutex.Unlock()
+			return value, nil
+		}
+		var timer Timer
+		var ready <-chan time.Time // nil, i.e. never ready, while the queue is empty
+		if readyAt, ok := queue.queue.NextReadyAt(); ok {
+			timer = queue.queue.clock.NewTimer(readyAt.Sub(queue.queue.clock.Now()))
+			ready = timer.C()
+		}
+		wait := channel(&queue.notify)
+		queue.mutex.Unlock()
+
+		select {
+		case <-ready:
+		case <-wait:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop() // a new timer is started on the next iteration
+		}
+		if err != nil {
+			return value, err
+		}
+	}
+}
+
+// Poll removes the first ready element of the queue and returns it, waiting at most timeout for it.
+// Second return parameter is true, unless no element was ready within the timeout.
+// The timeout is measured by the system clock, not by the clock of the queue.
+func (queue *BlockingQueue[E]) Poll(timeout time.Duration) (value E, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	value, err := queue.Take(ctx)
+	return value, err == nil
+}
+
+// DrainReady removes all ready elements from the queue and returns them, earliest first, without waiting.
+func (queue *BlockingQueue[E]) DrainReady() []E {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.DrainReady()
+}
+
+// Peek returns the element that becomes ready first without removing it, whether it is ready or not,
+// or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *BlockingQueue[E]) Peek() (value E, ok bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Peek()
+}
+
+// NextReadyAt returns the time at which the first element becomes ready, which may be in the past.
+// Second return parameter is true, unless the queue was empty.
+func (queue *BlockingQueue[E]) NextReadyAt() (readyAt time.Time, ok bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.NextReadyAt()
+}
+
+// Empty returns true if queue does not contain any elements, ready or not.
+func (queue *BlockingQueue[E]) Empty() bool {
+	return queue.Size() == 0
+}
+
+// Size returns number of elements within the queue, ready or not.
+func (queue *BlockingQueue[E]) Size() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Size()
+}
+
+// Clear removes all elements from the queue.
+func (queue *BlockingQueue[E]) Clear() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.queue.Clear()
+}
+
+// Values returns all elements in the queue in the order they become ready.
+func (queue *BlockingQueue[E]) Values() []E {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Values()
+}
+
+// String returns a string representation of container
+func (queue *BlockingQueue[E]) String() string {
+	str := "BlockingDelayQueue\n"
+	values := []string{}
+	for _, value := range queue.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// channel returns the channel to wait on, creating it if there are no other waiters yet.
+func channel(ch *chan struct{}) chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	return *ch
+}
+
+// signal wakes up all callers waiting on the channel, if any.
+func signal(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
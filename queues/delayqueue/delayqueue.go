@@ -0,0 +1,197 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package delayqueue implements a queue whose elements become available at scheduled times.
+//
+// Every element is enqueued with the time at which it becomes ready. Dequeue only returns elements whose time
+// has come, earliest first, and elements that are ready at the same time in the order they were enqueued.
+// The elements are kept in a priority queue ordered by their ready time.
+//
+// The current time is read from a Clock, which can be replaced, e.g. by a fake clock in tests.
+//
+// Queue is not thread safe, BlockingQueue is thread safe and waits until the next element is ready.
+//
+// Reference: https://en.wikipedia.org/wiki/Priority_queue
+package delayqueue
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/queues/priorityqueue"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+	"time"
+)
+
+// Clock tells the current time and waits for a duration.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a timer that sends the current time on its channel once the duration has elapsed.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event of a Clock, which can be stopped before it fires.
+type Timer interface {
+	// C returns the channel on which the current time is sent when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing and returns true, or returns false if it already fired or was stopped.
+	Stop() bool
+}
+
+// SystemClock is the Clock of the time package.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer returns a time.Timer that sends the current time on its channel once the duration has elapsed.
+func (SystemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// systemTimer adapts a time.Timer to the Timer interface.
+type systemTimer struct {
+	*time.Timer
+}
+
+// C returns the channel of the time.Timer.
+func (timer systemTimer) C() <-chan time.Time {
+	return timer.Timer.C
+}
+
+// item is an element with the time at which it becomes ready.
+type item[E any] struct {
+	value    E
+	readyAt  time.Time
+	sequence uint64 // keeps the order of elements that are ready at the same time
+}
+
+// Queue holds elements in a priority queue ordered by their ready time.
+type Queue[E any] struct {
+	queue    *priorityqueue.Queue[*item[E]]
+	clock    Clock
+	sequence uint64
+}
+
+// New instantiates a new empty queue that uses the system clock.
+func New[E any]() *Queue[E] {
+	return NewWithClock[E](SystemClock{})
+}
+
+// NewWithClock instantiates a new empty queue that uses the clock to tell which elements are ready.
+func NewWithClock[E any](clock Clock) *Queue[E] {
+	return &Queue[E]{queue: priorityqueue.NewWith[*item[E]](byReadyAt[E]), clock: clock}
+}
+
+// Enqueue adds a value that becomes ready at the time.
+func (queue *Queue[E]) Enqueue(value E, readyAt time.Time) {
+	queue.queue.Enqueue(&item[E]{value: value, readyAt: readyAt, sequence: queue.sequence})
+	queue.sequence++
+}
+
+// EnqueueAfter adds a value that becomes ready once the delay has elapsed from now on.
+func (queue *Queue[E]) EnqueueAfter(value E, delay time.Duration) {
+	queue.Enqueue(value, queue.clock.Now().Add(delay))
+}
+
+// Dequeue removes the first ready element of the queue and returns it, or nil if no element is ready.
+// Second return parameter is true, unless no element was ready and there was nothing to dequeue.
+func (queue *Queue[E]) Dequeue() (value E, ok bool) {
+	return queue.dequeue(queue.clock.Now())
+}
+
+// DrainReady removes all ready elements from the queue and returns them, earliest first.
+func (queue *Queue[E]) DrainReady() []E {
+	now := queue.clock.Now()
+	var values []E
+	for {
+		value, ok := queue.dequeue(now)
+		if !ok {
+			return values
+		}
+		values = append(values, value)
+	}
+}
+
+// Peek returns the element that becomes ready first without removing it, whether it is ready or not,
+// or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *Queue[E]) Peek() (value E, ok bool) {
+	item, ok := queue.queue.Peek()
+	if !ok {
+		return value, false
+	}
+	return item.value, true
+}
+
+// NextReadyAt returns the time at which the first element becomes ready, which may be in the past.
+// Second return parameter is true, unless the queue was empty.
+func (queue *Queue[E]) NextReadyAt() (readyAt time.Time, ok bool) {
+	item, ok := queue.queue.Peek()
+	if !ok {
+		return readyAt, false
+	}
+	return item.readyAt, true
+}
+
+// Empty returns true if queue does not contain any elements, ready or not.
+func (queue *Queue[E]) Empty() bool {
+	return queue.queue.Empty()
+}
+
+// Size returns number of elements within the queue, ready or not.
+func (queue *Queue[E]) Size() int {
+	return queue.queue.Size()
+}
+
+// Clear removes all elements from the queue.
+func (queue *Queue[E]) Clear() {
+	queue.queue.Clear()
+}
+
+// Values returns all elements in the queue in the order they become ready.
+func (queue *Queue[E]) Values() []E {
+	items := queue.queue.Values()
+	utils.Sort(items, byReadyAt[E])
+	values := make([]E, len(items), len(items))
+	for index, item := range items {
+		values[index] = item.value
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (queue *Queue[E]) String() string {
+	str := "DelayQueue\n"
+	values := []string{}
+	for _, value := range queue.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// dequeue removes the first element and returns it if it is ready at the time.
+func (queue *Queue[E]) dequeue(now time.Time) (value E, ok bool) {
+	item, ok := queue.queue.Peek()
+	if !ok || item.readyAt.After(now) {
+		return value, false
+	}
+	queue.queue.Dequeue()
+	return item.value, true
+}
+
+// byReadyAt orders items by their ready time and then by the order they were enqueued.
+func byReadyAt[E any](a, b interface{}) int {
+	itemA, itemB := a.(*item[E]), b.(*item[E])
+	switch {
+	case itemA.readyAt.Before(itemB.readyAt):
+		return -1
+	case itemA.readyAt.After(itemB.readyAt):
+		return 1
+	}
+	return utils.UInt64Comparator(itemA.sequence, itemB.sequence)
+}
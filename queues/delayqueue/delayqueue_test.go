@@ -0,0 +1,458 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package delayqueue
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock only moves when it is advanced, its timers fire once their time has come.
+type fakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	started int
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	ch    chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (clock *fakeClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.now
+}
+
+func (clock *fakeClock) NewTimer(d time.Duration) Timer {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.started++
+	timer := &fakeTimer{clock: clock, at: clock.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.ch <- clock.now
+		return timer
+	}
+	clock.timers = append(clock.timers, timer)
+	return timer
+}
+
+func (timer *fakeTimer) C() <-chan time.Time {
+	return timer.ch
+}
+
+func (timer *fakeTimer) Stop() bool {
+	clock := timer.clock
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	for i, pending := range clock.timers {
+		if pending == timer {
+			clock.timers = append(clock.timers[:i], clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Advance moves the clock forward and fires the timers that are due.
+func (clock *fakeClock) Advance(d time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = clock.now.Add(d)
+	timers := clock.timers[:0]
+	for _, timer := range clock.timers {
+		if timer.at.After(clock.now) {
+			timers = append(timers, timer)
+			continue
+		}
+		timer.ch <- clock.now
+	}
+	clock.timers = timers
+}
+
+// Timers returns the number of timers that have neither fired nor been stopped yet.
+func (clock *fakeClock) Timers() int {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return len(clock.timers)
+}
+
+// Started returns the number of timers that have been started so far.
+func (clock *fakeClock) Started() int {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.started
+}
+
+// waitForTimers yields until at least n timers have been started, i.e. until waiting callers went to sleep.
+func waitForTimers(t *testing.T, clock *fakeClock, n int) {
+	for deadline := time.Now().Add(5 * time.Second); clock.Started() < n; runtime.Gosched() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Got %v timers expected %v", clock.Started(), n)
+		}
+	}
+}
+
+func TestDelayQueueEnqueueDequeue(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewWithClock[string](clock)
+	now := clock.Now()
+
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if _, ok := queue.NextReadyAt(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	queue.Enqueue("c", now.Add(3*time.Second))
+	queue.Enqueue("a", now.Add(time.Second))
+	queue.EnqueueAfter("b", 2*time.Second)
+	queue.Enqueue("z", now.Add(-time.Second))
+
+	if actualValue := queue.Size(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue, ok := queue.Peek(); actualValue != "z" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "z")
+	}
+	if actualValue, ok := queue.Dequeue(); actualValue != "z" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "z")
+	}
+	if actualValue, ok := queue.Dequeue(); actualValue != "" || ok {
+		t.Errorf("Got %v expected %v", actualValue, "")
+	}
+	if actualValue, ok := queue.Peek(); actualValue != "a" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+	if actualValue, ok := queue.NextReadyAt(); !actualValue.Equal(now.Add(time.Second)) || !ok {
+		t.Errorf("Got %v expected %v", actualValue, now.Add(time.Second))
+	}
+
+	clock.Advance(time.Second)
+	if actualValue, ok := queue.Dequeue(); actualValue != "a" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+	if _, ok := queue.Dequeue(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	clock.Advance(5 * time.Second)
+	for _, expectedValue := range []string{"b", "c"} {
+		if actualValue, ok := queue.Dequeue(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestDelayQueueSameReadyAt(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewWithClock[int](clock)
+	readyAt := clock.Now().Add(time.Minute)
+	for i := 0; i < 100; i++ {
+		queue.Enqueue(i, readyAt)
+	}
+	clock.Advance(time.Minute)
+	for expectedValue := 0; expectedValue < 100; expectedValue++ {
+		if actualValue, ok := queue.Dequeue(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestDelayQueueDrainReady(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewWithClock[int](clock)
+	if actualValue := queue.DrainReady(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	for _, value := range []int{5, 1, 4, 2, 3} {
+		queue.EnqueueAfter(value, time.Duration(value)*time.Second)
+	}
+	clock.Advance(3 * time.Second)
+	if actualValue := queue.DrainReady(); len(actualValue) != 3 || actualValue[0] != 1 || actualValue[1] != 2 || actualValue[2] != 3 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2,3]")
+	}
+	if actualValue := queue.DrainReady(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	if actualValue := queue.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	queue.Clear()
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestDelayQueueRandom(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewWithClock[int](clock)
+	delays := make([]int, 1000)
+	for i := range delays {
+		delays[i] = rand.Intn(100)
+		queue.EnqueueAfter(i, time.Duration(delays[i])*time.Millisecond)
+	}
+
+	// Expected order: by delay, then by enqueue order
+	expected := make([]int, len(delays))
+	for i := range expected {
+		expected[i] = i
+	}
+	sort.SliceStable(expected, func(i, j int) bool { return delays[expected[i]] < delays[expected[j]] })
+
+	values := queue.Values()
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Fatalf("Got %v expected %v at %v", values[i], expected[i], i)
+		}
+	}
+
+	var actual []int
+	for i := 0; i < 100; i++ {
+		for _, value := range queue.DrainReady() {
+			if delays[value] > i {
+				t.Fatalf("Got %v before its delay %v at %v", value, delays[value], i)
+			}
+			actual = append(actual, value)
+		}
+		clock.Advance(time.Millisecond)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("Got %v expected %v at %v", actual[i], expected[i], i)
+		}
+	}
+}
+
+func TestDelayQueueString(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewWithClock[int](clock)
+	queue.EnqueueAfter(2, 2*time.Second)
+	queue.EnqueueAfter(1, time.Second)
+	if actualValue, expectedValue := queue.String(), "DelayQueue\n1, 2"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBlockingDelayQueueTake(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewBlockingWithClock[int](clock)
+	queue.EnqueueAfter(2, 2*time.Second)
+	queue.EnqueueAfter(1, time.Second)
+
+	taken := make(chan int)
+	go func() {
+		for i := 0; i < 3; i++ {
+			value, err := queue.Take(context.Background())
+			if err != nil {
+				t.Errorf("Got error %v", err)
+			}
+			taken <- value
+		}
+	}()
+
+	waitForTimers(t, clock, 1)
+	select {
+	case value := <-taken:
+		t.Fatalf("Got %v before it was ready", value)
+	default:
+	}
+	clock.Advance(time.Second)
+	if actualValue := <-taken; actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	clock.Advance(time.Second)
+	if actualValue := <-taken; actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	// Waiting on an empty queue until an element is enqueued
+	queue.Enqueue(3, clock.Now())
+	if actualValue := <-taken; actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func TestBlockingDelayQueueTakeEarlierElement(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewBlockingWithClock[string](clock)
+	queue.EnqueueAfter("later", time.Hour)
+
+	taken := make(chan string)
+	go func() {
+		value, _ := queue.Take(context.Background())
+		taken <- value
+	}()
+
+	waitForTimers(t, clock, 1)
+	queue.EnqueueAfter("sooner", time.Second)
+	waitForTimers(t, clock, 2)
+	if actualValue := clock.Timers(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	clock.Advance(time.Second)
+	if actualValue := <-taken; actualValue != "sooner" {
+		t.Errorf("Got %v expected %v", actualValue, "sooner")
+	}
+	if actualValue, ok := queue.NextReadyAt(); !ok || !actualValue.Equal(clock.Now().Add(time.Hour-time.Second)) {
+		t.Errorf("Got %v expected %v", actualValue, clock.Now().Add(time.Hour-time.Second))
+	}
+}
+
+func TestBlockingDelayQueueTakeCancel(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewBlockingWithClock[int](clock)
+	queue.EnqueueAfter(1, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := queue.Take(ctx)
+		done <- err
+	}()
+
+	waitForTimers(t, clock, 1)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Got %v expected %v", err, context.Canceled)
+	}
+	if actualValue := clock.Timers(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := queue.Size(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+
+	// A ready element is taken even if the context is done
+	clock.Advance(time.Minute)
+	if actualValue, err := queue.Take(ctx); actualValue != 1 || err != nil {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if _, ok := queue.Poll(time.Millisecond); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+}
+
+func TestBlockingDelayQueueDrainReady(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewBlockingWithClock[int](clock)
+	for _, value := range []int{3, 1, 2} {
+		queue.EnqueueAfter(value, time.Duration(value)*time.Second)
+	}
+	clock.Advance(2 * time.Second)
+	if actualValue := queue.DrainReady(); len(actualValue) != 2 || actualValue[0] != 1 || actualValue[1] != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2]")
+	}
+	if actualValue, ok := queue.Dequeue(); ok {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, ok := queue.Peek(); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, expectedValue := queue.String(), "BlockingDelayQueue\n3"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBlockingDelayQueueSystemClock(t *testing.T) {
+	queue := NewBlocking[int]()
+	start := time.Now()
+	queue.EnqueueAfter(1, 20*time.Millisecond)
+	if actualValue, ok := queue.Poll(time.Second); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Got %v expected at least %v", elapsed, 20*time.Millisecond)
+	}
+}
+
+func TestBlockingDelayQueueStress(t *testing.T) {
+	clock := newFakeClock()
+	queue := NewBlockingWithClock[int](clock)
+	producers, consumers, count := 4, 4, 250
+
+	var consuming sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	taken := make(chan int, producers*count)
+	for c := 0; c < consumers; c++ {
+		consuming.Add(1)
+		go func() {
+			defer consuming.Done()
+			for {
+				value, err := queue.Take(ctx)
+				if err != nil {
+					return
+				}
+				taken <- value
+			}
+		}()
+	}
+	var producing sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producing.Add(1)
+		go func(p int) {
+			defer producing.Done()
+			for i := 0; i < count; i++ {
+				queue.EnqueueAfter(p*count+i, time.Duration(rand.Intn(10))*time.Millisecond)
+			}
+		}(p)
+	}
+	producing.Wait()
+	for i := 0; i < 10; i++ {
+		clock.Advance(time.Millisecond)
+		runtime.Gosched()
+	}
+
+	seen := make([]bool, producers*count)
+	for i := 0; i < producers*count; i++ {
+		value := <-taken
+		if seen[value] {
+			t.Fatalf("Value %v was taken twice", value)
+		}
+		seen[value] = true
+	}
+	cancel()
+	consuming.Wait()
+}
+
+func benchmarkEnqueueDequeue(b *testing.B, queue *Queue[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			queue.Enqueue(n, time.Time{}.Add(time.Duration(size-n)))
+		}
+		for n := 0; n < size; n++ {
+			queue.Dequeue()
+		}
+	}
+}
+
+func BenchmarkDelayQueueEnqueueDequeue100(b *testing.B) {
+	b.StopTimer()
+	queue := NewWithClock[int](newFakeClock())
+	b.StartTimer()
+	benchmarkEnqueueDequeue(b, queue, 100)
+}
+
+func BenchmarkDelayQueueEnqueueDequeue10000(b *testing.B) {
+	b.StopTimer()
+	queue := NewWithClock[int](newFakeClock())
+	b.StartTimer()
+	benchmarkEnqueueDequeue(b, queue, 10000)
+}
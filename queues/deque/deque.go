@@ -0,0 +1,265 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deque implements a double-ended queue backed by a growable ring buffer.
+//
+// Elements can be pushed and popped at both ends in amortized O(1) and accessed by their index in O(1).
+// The ring grows by a factor of two when it is full and shrinks when it is mostly empty.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Double-ended_queue
+package deque
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Assert Queue implementation
+//var _ queues.Queue = (*Deque)(nil)
+
+// Deque holds the elements in a slice used as a ring, the first element is at head.
+type Deque[T any] struct {
+	elements []T
+	head     int
+	size     int
+}
+
+const (
+	growthFactor = float32(2.0)  // growth by 100%
+	shrinkFactor = float32(0.25) // shrink when size is 25% of capacity (0 means never shrink)
+)
+
+// New instantiates a new deque and pushes the passed values, if any, to its back
+func New[T any](values ...T) *Deque[T] {
+	deque := &Deque[T]{}
+	deque.PushBack(values...)
+	return deque
+}
+
+// PushFront adds a value in front of the first element.
+func (deque *Deque[T]) PushFront(value T) {
+	deque.growBy(1)
+	deque.head = deque.index(len(deque.elements) - 1)
+	deque.elements[deque.head] = value
+	deque.size++
+}
+
+// PushBack appends the values after the last element.
+func (deque *Deque[T]) PushBack(values ...T) {
+	deque.growBy(len(values))
+	for _, value := range values {
+		deque.elements[deque.index(deque.size)] = value
+		deque.size++
+	}
+}
+
+// PopFront removes the first element and returns it, or nil if deque is empty.
+// Second return parameter is true, unless the deque was empty and there was nothing to pop.
+func (deque *Deque[T]) PopFront() (value T, ok bool) {
+	if deque.size == 0 {
+		return value, false
+	}
+	value = deque.elements[deque.head]
+	deque.elements[deque.head] = *new(T) // cleanup reference
+	deque.head = deque.index(1)
+	deque.size--
+	deque.shrink()
+	return value, true
+}
+
+// PopBack removes the last element and returns it, or nil if deque is empty.
+// Second return parameter is true, unless the deque was empty and there was nothing to pop.
+func (deque *Deque[T]) PopBack() (value T, ok bool) {
+	if deque.size == 0 {
+		return value, false
+	}
+	index := deque.index(deque.size - 1)
+	value = deque.elements[index]
+	deque.elements[index] = *new(T) // cleanup reference
+	deque.size--
+	deque.shrink()
+	return value, true
+}
+
+// PeekFront returns the first element without removing it, or nil if deque is empty.
+// Second return parameter is true, unless the deque was empty and there was nothing to peek.
+func (deque *Deque[T]) PeekFront() (value T, ok bool) {
+	if deque.size == 0 {
+		return value, false
+	}
+	return deque.elements[deque.head], true
+}
+
+// PeekBack returns the last element without removing it, or nil if deque is empty.
+// Second return parameter is true, unless the deque was empty and there was nothing to peek.
+func (deque *Deque[T]) PeekBack() (value T, ok bool) {
+	if deque.size == 0 {
+		return value, false
+	}
+	return deque.elements[deque.index(deque.size-1)], true
+}
+
+// Enqueue adds a value to the back of the deque, same as PushBack.
+func (deque *Deque[T]) Enqueue(value T) {
+	deque.PushBack(value)
+}
+
+// Dequeue removes the first element and returns it, or nil if deque is empty, same as PopFront.
+// Second return parameter is true, unless the deque was empty and there was nothing to dequeue.
+func (deque *Deque[T]) Dequeue() (value T, ok bool) {
+	return deque.PopFront()
+}
+
+// Peek returns the first element without removing it, or nil if deque is empty, same as PeekFront.
+// Second return parameter is true, unless the deque was empty and there was nothing to peek.
+func (deque *Deque[T]) Peek() (value T, ok bool) {
+	return deque.PeekFront()
+}
+
+// Get returns the element at index, counted from the front.
+// Second return parameter is true if index is within bounds of the deque and deque is not empty, otherwise false.
+func (deque *Deque[T]) Get(index int) (T, bool) {
+	if !deque.withinRange(index) {
+		return *new(T), false
+	}
+	return deque.elements[deque.index(index)], true
+}
+
+// Set the value at specified index, counted from the front.
+// Does not do anything if position is negative or bigger than deque's size
+// Note: position equal to deque's size is valid, i.e. push back.
+func (deque *Deque[T]) Set(index int, value T) {
+	if !deque.withinRange(index) {
+		// Append
+		if index == deque.size {
+			deque.PushBack(value)
+		}
+		return
+	}
+	deque.elements[deque.index(index)] = value
+}
+
+// Rotate moves the elements n steps to the back, the last n elements wrap around to the front.
+// A negative n rotates to the front. Takes O(min(n, size-n)) and O(1) if the deque is at its capacity.
+func (deque *Deque[T]) Rotate(n int) {
+	if deque.size < 2 {
+		return
+	}
+	if n %= deque.size; n < 0 {
+		n += deque.size
+	}
+	if n == 0 {
+		return
+	}
+	if deque.size == len(deque.elements) {
+		deque.head = deque.index(len(deque.elements) - n)
+		return
+	}
+	if n <= deque.size/2 {
+		// Move the last n elements in front of the first one
+		for ; n > 0; n-- {
+			last := deque.index(deque.size - 1)
+			deque.head = deque.index(len(deque.elements) - 1)
+			deque.elements[deque.head] = deque.elements[last]
+			deque.elements[last] = *new(T)
+		}
+		return
+	}
+	// Move the first size-n elements behind the last one
+	for n = deque.size - n; n > 0; n-- {
+		deque.elements[deque.index(deque.size)] = deque.elements[deque.head]
+		deque.elements[deque.head] = *new(T)
+		deque.head = deque.index(1)
+	}
+}
+
+// Values returns all elements in the deque from front to back.
+func (deque *Deque[T]) Values() []T {
+	values := make([]T, deque.size, deque.size)
+	deque.copyTo(values)
+	return values
+}
+
+// Empty returns true if deque does not contain any elements.
+func (deque *Deque[T]) Empty() bool {
+	return deque.size == 0
+}
+
+// Size returns number of elements within the deque.
+func (deque *Deque[T]) Size() int {
+	return deque.size
+}
+
+// Clear removes all elements from the deque.
+func (deque *Deque[T]) Clear() {
+	deque.elements = []T{}
+	deque.head = 0
+	deque.size = 0
+}
+
+// String returns a string representation of container
+func (deque *Deque[T]) String() string {
+	str := "Deque\n"
+	values := []string{}
+	for _, value := range deque.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// index returns the position within the ring of the element at the index counted from the front,
+// the index must be less than twice the capacity.
+func (deque *Deque[T]) index(index int) int {
+	if index += deque.head; index >= len(deque.elements) {
+		index -= len(deque.elements)
+	}
+	return index
+}
+
+// Check that the index is within bounds of the deque
+func (deque *Deque[T]) withinRange(index int) bool {
+	return index >= 0 && index < deque.size
+}
+
+// copyTo copies the elements from front to back into the values, which must hold at least size elements.
+func (deque *Deque[T]) copyTo(values []T) {
+	if deque.head+deque.size <= len(deque.elements) {
+		copy(values, deque.elements[deque.head:deque.head+deque.size])
+		return
+	}
+	n := copy(values, deque.elements[deque.head:])
+	copy(values[n:], deque.elements[:deque.size-n])
+}
+
+func (deque *Deque[T]) resize(cap int) {
+	newElements := make([]T, cap, cap)
+	deque.copyTo(newElements)
+	deque.elements = newElements
+	deque.head = 0
+}
+
+// Expand the ring if necessary, i.e. capacity will be exceeded if we add n elements
+func (deque *Deque[T]) growBy(n int) {
+	// When capacity is reached, grow by a factor of growthFactor and add number of elements
+	currentCapacity := len(deque.elements)
+	if deque.size+n > currentCapacity {
+		newCapacity := int(growthFactor * float32(currentCapacity+n))
+		deque.resize(newCapacity)
+	}
+}
+
+// Shrink the ring if necessary, i.e. when size is shrinkFactor percent of current capacity
+func (deque *Deque[T]) shrink() {
+	if shrinkFactor == 0.0 {
+		return
+	}
+	// Shrink when size is at shrinkFactor * capacity
+	currentCapacity := len(deque.elements)
+	if deque.size <= int(float32(currentCapacity)*shrinkFactor) {
+		deque.resize(deque.size)
+	}
+}
@@ -0,0 +1,449 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deque
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func assertValues[T comparable](t *testing.T, deque *Deque[T], expectedValues ...T) {
+	actualValues := deque.Values()
+	if len(actualValues) != len(expectedValues) {
+		t.Fatalf("Got %v expected %v", actualValues, expectedValues)
+	}
+	for i := range actualValues {
+		if actualValues[i] != expectedValues[i] {
+			t.Fatalf("Got %v expected %v", actualValues, expectedValues)
+		}
+	}
+}
+
+func TestDequeNew(t *testing.T) {
+	deque1 := New[int]()
+	if actualValue := deque1.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	deque2 := New[int](1, 2)
+	if actualValue := deque2.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	assertValues(t, deque2, 1, 2)
+}
+
+func TestDequePushPop(t *testing.T) {
+	deque := New[string]()
+	deque.PushBack("b")
+	deque.PushFront("a")
+	deque.PushBack("c", "d")
+	deque.PushFront("z")
+	assertValues(t, deque, "z", "a", "b", "c", "d")
+
+	if actualValue, ok := deque.PeekFront(); actualValue != "z" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "z")
+	}
+	if actualValue, ok := deque.PeekBack(); actualValue != "d" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "d")
+	}
+	if actualValue, ok := deque.PopFront(); actualValue != "z" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "z")
+	}
+	if actualValue, ok := deque.PopBack(); actualValue != "d" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "d")
+	}
+	assertValues(t, deque, "a", "b", "c")
+
+	deque.Enqueue("e")
+	if actualValue, ok := deque.Peek(); actualValue != "a" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+	for _, expectedValue := range []string{"a", "b", "c", "e"} {
+		if actualValue, ok := deque.Dequeue(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if _, ok := deque.PopFront(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := deque.PopBack(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := deque.PeekFront(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := deque.PeekBack(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+}
+
+func TestDequeGetSet(t *testing.T) {
+	deque := New[int]()
+	for i := 1; i <= 3; i++ {
+		deque.PushFront(i)
+	}
+
+	tests := [][]interface{}{
+		{0, 3, true},
+		{1, 2, true},
+		{2, 1, true},
+		{3, 0, false},
+		{-1, 0, false},
+	}
+	for _, test := range tests {
+		if actualValue, ok := deque.Get(test[0].(int)); actualValue != test[1] || ok != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+	}
+
+	deque.Set(0, 30)
+	deque.Set(2, 10)
+	deque.Set(3, 0)
+	deque.Set(5, 50)
+	assertValues(t, deque, 30, 2, 10, 0)
+}
+
+func TestDequeRotate(t *testing.T) {
+	deque := New[int](0, 1, 2, 3, 4)
+	deque.Rotate(2)
+	assertValues(t, deque, 3, 4, 0, 1, 2)
+	deque.Rotate(-1)
+	assertValues(t, deque, 4, 0, 1, 2, 3)
+	deque.Rotate(-3)
+	assertValues(t, deque, 2, 3, 4, 0, 1)
+	deque.Rotate(12)
+	assertValues(t, deque, 0, 1, 2, 3, 4)
+	deque.Rotate(0)
+	assertValues(t, deque, 0, 1, 2, 3, 4)
+
+	New[int]().Rotate(1)
+}
+
+func TestDequeRandom(t *testing.T) {
+	deque := New[int]()
+	var values []int
+	for i := 0; i < 10000; i++ {
+		switch r := rand.Intn(14); {
+		case r < 3:
+			deque.PushFront(i)
+			values = append([]int{i}, values...)
+		case r < 6:
+			deque.PushBack(i, i+1)
+			values = append(values, i, i+1)
+		case r < 8:
+			value, ok := deque.PopFront()
+			if len(values) > 0 {
+				if value != values[0] || !ok {
+					t.Fatalf("Got %v expected %v", value, values[0])
+				}
+				values = values[1:]
+			}
+		case r < 10:
+			value, ok := deque.PopBack()
+			if len(values) > 0 {
+				if value != values[len(values)-1] || !ok {
+					t.Fatalf("Got %v expected %v", value, values[len(values)-1])
+				}
+				values = values[:len(values)-1]
+			}
+		case r < 12:
+			if len(values) > 0 {
+				index := rand.Intn(len(values))
+				if value, _ := deque.Get(index); value != values[index] {
+					t.Fatalf("Got %v expected %v", value, values[index])
+				}
+				deque.Set(index, -i)
+				values[index] = -i
+			}
+		default:
+			n := rand.Intn(20) - 10
+			deque.Rotate(n)
+			if len(values) > 0 {
+				n = ((n % len(values)) + len(values)) % len(values)
+				values = append(values[len(values)-n:], values[:len(values)-n]...)
+			}
+		}
+		if i%100 == 0 {
+			assertValues(t, deque, values...)
+		}
+	}
+	assertValues(t, deque, values...)
+}
+
+func TestDequeClear(t *testing.T) {
+	deque := New[string]("e", "f", "g", "a", "b", "c", "d")
+	deque.Clear()
+	if actualValue := deque.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := deque.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	deque.PushFront("a")
+	assertValues(t, deque, "a")
+}
+
+func TestDequeEach(t *testing.T) {
+	deque := New[string]("b", "c")
+	deque.PushFront("a")
+	deque.Each(func(index int, value string) {
+		switch index {
+		case 0:
+			if actualValue, expectedValue := value, "a"; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		case 1:
+			if actualValue, expectedValue := value, "b"; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		case 2:
+			if actualValue, expectedValue := value, "c"; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		default:
+			t.Errorf("Too many")
+		}
+	})
+}
+
+func TestDequeMap(t *testing.T) {
+	deque := New[string]("b", "c")
+	deque.PushFront("a")
+	mappedDeque := deque.Map(func(index int, value string) string {
+		return "mapped: " + value
+	})
+	assertValues(t, mappedDeque, "mapped: a", "mapped: b", "mapped: c")
+}
+
+func TestDequeSelect(t *testing.T) {
+	deque := New[string]("b", "c")
+	deque.PushFront("a")
+	selectedDeque := deque.Select(func(index int, value string) bool {
+		return value >= "a" && value <= "b"
+	})
+	assertValues(t, selectedDeque, "a", "b")
+}
+
+func TestDequeAnyAll(t *testing.T) {
+	deque := New[string]("b", "c")
+	deque.PushFront("a")
+	if actualValue := deque.Any(func(index int, value string) bool { return value == "c" }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := deque.Any(func(index int, value string) bool { return value == "x" }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := deque.All(func(index int, value string) bool { return value >= "a" && value <= "c" }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := deque.All(func(index int, value string) bool { return value >= "a" && value <= "b" }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestDequeFind(t *testing.T) {
+	deque := New[string]("b", "c")
+	deque.PushFront("a")
+	foundIndex, foundValue := deque.Find(func(index int, value string) bool {
+		return value == "c"
+	})
+	if foundValue != "c" || foundIndex != 2 {
+		t.Errorf("Got %v at %v expected %v at %v", foundValue, foundIndex, "c", 2)
+	}
+	foundIndex, foundValue = deque.Find(func(index int, value string) bool {
+		return value == "x"
+	})
+	if foundValue != "" || foundIndex != -1 {
+		t.Errorf("Got %v at %v expected %v at %v", foundValue, foundIndex, nil, nil)
+	}
+}
+
+func TestDequeIteratorNext(t *testing.T) {
+	deque := New[string]("b", "c")
+	deque.PushFront("a")
+	it := deque.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		index := it.Index()
+		value := it.Value()
+		switch index {
+		case 0:
+			if actualValue, expectedValue := value, "a"; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		case 1:
+			if actualValue, expectedValue := value, "b"; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		case 2:
+			if actualValue, expectedValue := value, "c"; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		default:
+			t.Errorf("Too many")
+		}
+	}
+	if actualValue, expectedValue := count, 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestDequeIteratorPrev(t *testing.T) {
+	deque := New[string]("b", "c")
+	deque.PushFront("a")
+	it := deque.Iterator()
+	for it.Next() {
+	}
+	count := 0
+	var values []string
+	for it.Prev() {
+		count++
+		values = append(values, it.Value())
+		if actualValue, expectedValue := it.Index(), 3-count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := strings.Join(values, ""), "cba"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.End()
+	if actualValue := it.PrevTo(func(index int, value string) bool { return value == "a" }); actualValue != true || it.Index() != 0 {
+		t.Errorf("Got %v expected %v", it.Index(), 0)
+	}
+	if actualValue := it.Last(); actualValue != true || it.Value() != "c" {
+		t.Errorf("Got %v expected %v", it.Value(), "c")
+	}
+	if actualValue := it.First(); actualValue != true || it.Value() != "a" {
+		t.Errorf("Got %v expected %v", it.Value(), "a")
+	}
+}
+
+func TestDequeSerialization(t *testing.T) {
+	deque := New[any]("b", "c")
+	deque.PushFront("a")
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := fmt.Sprintf("%s%s%s", deque.Values()...), "abc"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := deque.Size(), 3; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	bytes, err := deque.ToJSON()
+	assert()
+	if actualValue, expectedValue := string(bytes), `["a","b","c"]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = deque.FromJSON(bytes)
+	assert()
+
+	bytes, err = json.Marshal([]interface{}{"a", "b", "c", deque})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	err = json.Unmarshal([]byte(`[1,2,3]`), &deque)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	deque.PushFront(0)
+	if actualValue, expectedValue := fmt.Sprint(deque.Values()), "[0 1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestDequeString(t *testing.T) {
+	deque := New[int](1)
+	deque.PushFront(0)
+	if actualValue := deque.String(); !strings.HasPrefix(actualValue, "Deque") || !strings.HasSuffix(actualValue, "0, 1") {
+		t.Errorf("Got %v expected %v", actualValue, "Deque\n0, 1")
+	}
+}
+
+func benchmarkPushFront(b *testing.B, deque *Deque[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			deque.PushFront(n)
+		}
+	}
+}
+
+func benchmarkPopBack(b *testing.B, deque *Deque[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			deque.PopBack()
+		}
+	}
+}
+
+func benchmarkGet(b *testing.B, deque *Deque[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			deque.Get(n)
+		}
+	}
+}
+
+func BenchmarkDequePushFront100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	deque := New[int]()
+	b.StartTimer()
+	benchmarkPushFront(b, deque, size)
+}
+
+func BenchmarkDequePushFront10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	deque := New[int]()
+	b.StartTimer()
+	benchmarkPushFront(b, deque, size)
+}
+
+func BenchmarkDequePopBack100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	deque := New[int]()
+	for n := 0; n < size; n++ {
+		deque.PushFront(n)
+	}
+	b.StartTimer()
+	benchmarkPopBack(b, deque, size)
+}
+
+func BenchmarkDequePopBack10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	deque := New[int]()
+	for n := 0; n < size; n++ {
+		deque.PushFront(n)
+	}
+	b.StartTimer()
+	benchmarkPopBack(b, deque, size)
+}
+
+func BenchmarkDequeGet10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	deque := New[int]()
+	for n := 0; n < size; n++ {
+		deque.PushFront(n)
+	}
+	b.StartTimer()
+	benchmarkGet(b, deque, size)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deque
+
+import (
+	"encoding/json"
+)
+
+// Assert Serialization implementation
+//var _ containers.JSONSerializer = (*Deque)(nil)
+//var _ containers.JSONDeserializer = (*Deque)(nil)
+
+// ToJSON outputs the JSON representation of deque's elements from front to back.
+func (deque *Deque[T]) ToJSON() ([]byte, error) {
+	return json.Marshal(deque.Values())
+}
+
+// FromJSON populates deque's elements from the input JSON representation.
+func (deque *Deque[T]) FromJSON(data []byte) error {
+	var elements []T
+	err := json.Unmarshal(data, &elements)
+	if err == nil {
+		deque.elements = elements
+		deque.head = 0
+		deque.size = len(elements)
+	}
+	return err
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (deque *Deque[T]) UnmarshalJSON(bytes []byte) error {
+	return deque.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (deque *Deque[T]) MarshalJSON() ([]byte, error) {
+	return deque.ToJSON()
+}
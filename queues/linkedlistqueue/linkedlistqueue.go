@@ -26,7 +26,7 @@ type Queue[T any] struct {
 
 // New instantiates a new empty queue
 func New[T any]() *Queue[T] {
-	return &Queue[T]{list: &singlylinkedlist.List[T]{}}
+	return &Queue[T]{list: singlylinkedlist.New[T]()}
 }
 
 // Enqueue adds a value to the end of the queue
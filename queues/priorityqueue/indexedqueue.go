@@ -0,0 +1,155 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package priorityqueue
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/trees/binaryheap"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+)
+
+// Handle refers to an element of an IndexedQueue, it is returned by Enqueue.
+// The zero value refers to no element.
+type Handle[E any] struct {
+	item *item[E]
+}
+
+// item is an element of an IndexedQueue along with its index within the heap.
+type item[E any] struct {
+	value E
+	index int // -1 once the item has been removed from the queue
+	queue *IndexedQueue[E]
+}
+
+// IndexedQueue is a priority queue whose elements can be updated or removed by the handle returned by Enqueue,
+// e.g. to decrease the key of a vertex in Dijkstra's algorithm or to cancel a scheduled task.
+//
+// Unlike Queue it does not implement the queues.Queue interface, since Enqueue returns the element's handle.
+type IndexedQueue[E any] struct {
+	heap       *binaryheap.Heap[*item[E]]
+	Comparator utils.Comparator
+}
+
+// NewIndexedWith instantiates a new empty indexed queue with the custom comparator.
+func NewIndexedWith[E any](comparator utils.Comparator) *IndexedQueue[E] {
+	itemComparator := func(a, b interface{}) int {
+		return comparator(a.(*item[E]).value, b.(*item[E]).value)
+	}
+	indexer := func(item *item[E], index int) {
+		item.index = index
+	}
+	return &IndexedQueue[E]{heap: binaryheap.NewWithIndexer[*item[E]](itemComparator, indexer), Comparator: comparator}
+}
+
+// Enqueue adds a value to the queue in O(log n) and returns its handle.
+func (queue *IndexedQueue[E]) Enqueue(value E) Handle[E] {
+	item := &item[E]{value: value, queue: queue}
+	queue.heap.Push(item)
+	return Handle[E]{item: item}
+}
+
+// Dequeue removes first element of the queue and returns it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to dequeue.
+func (queue *IndexedQueue[E]) Dequeue() (value E, ok bool) {
+	item, ok := queue.heap.Pop()
+	if !ok {
+		return value, false
+	}
+	return item.value, true
+}
+
+// Peek returns top element on the queue without removing it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *IndexedQueue[E]) Peek() (value E, ok bool) {
+	item, ok := queue.heap.Peek()
+	if !ok {
+		return value, false
+	}
+	return item.value, true
+}
+
+// PeekHandle returns the handle of the top element on the queue without removing it.
+// Second return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *IndexedQueue[E]) PeekHandle() (handle Handle[E], ok bool) {
+	item, ok := queue.heap.Peek()
+	if !ok {
+		return handle, false
+	}
+	return Handle[E]{item: item}, true
+}
+
+// Contains returns true if the handle refers to an element of the queue, i.e. one that has been enqueued into
+// this queue and has neither been dequeued nor removed since, in O(1).
+func (queue *IndexedQueue[E]) Contains(handle Handle[E]) bool {
+	return handle.item != nil && handle.item.queue == queue && handle.item.index >= 0
+}
+
+// Get returns the value of the element the handle refers to in O(1).
+// Second return parameter is true if the handle refers to an element of the queue.
+func (queue *IndexedQueue[E]) Get(handle Handle[E]) (value E, found bool) {
+	if !queue.Contains(handle) {
+		return value, false
+	}
+	return handle.item.value, true
+}
+
+// Update replaces the value of the element the handle refers to and restores the order of the queue in O(log n),
+// i.e. it decreases or increases the key of the element.
+// Returns false if the handle does not refer to an element of the queue.
+func (queue *IndexedQueue[E]) Update(handle Handle[E], value E) bool {
+	if !queue.Contains(handle) {
+		return false
+	}
+	handle.item.value = value
+	queue.heap.Fix(handle.item.index)
+	return true
+}
+
+// Remove removes the element the handle refers to from the queue in O(log n) and returns its value.
+// Second return parameter is true, unless the handle did not refer to an element of the queue.
+func (queue *IndexedQueue[E]) Remove(handle Handle[E]) (value E, ok bool) {
+	if !queue.Contains(handle) {
+		return value, false
+	}
+	queue.heap.RemoveAt(handle.item.index)
+	return handle.item.value, true
+}
+
+// Empty returns true if queue does not contain any elements.
+func (queue *IndexedQueue[E]) Empty() bool {
+	return queue.heap.Empty()
+}
+
+// Size returns number of elements within the queue.
+func (queue *IndexedQueue[E]) Size() int {
+	return queue.heap.Size()
+}
+
+// Clear removes all elements from the queue, their handles do not refer to elements of the queue anymore.
+func (queue *IndexedQueue[E]) Clear() {
+	queue.heap.Clear()
+}
+
+// Values returns all elements in the queue.
+func (queue *IndexedQueue[E]) Values() []E {
+	items := queue.heap.Values()
+	values := make([]E, len(items), len(items))
+	for index, item := range items {
+		values[index] = item.value
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (queue *IndexedQueue[E]) String() string {
+	str := "IndexedPriorityQueue\n"
+	values := make([]string, queue.heap.Size(), queue.heap.Size())
+	for index, value := range queue.Values() {
+		values[index] = fmt.Sprintf("%v", value)
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
@@ -8,7 +8,8 @@
 // The elements of the priority queue are ordered by a comparator provided at queue construction time.
 //
 // The heap of this queue is the least/smallest element with respect to the specified ordering.
-// If multiple elements are tied for least value, the heap is one of those elements arbitrarily.
+// If multiple elements are tied for least value, the heap is one of those elements arbitrarily,
+// unless the queue is stable, which dequeues elements with equal priority in the order they were enqueued.
 //
 // Structure is not thread safe.
 //
@@ -36,6 +37,12 @@ func NewWith[E any](comparator utils.Comparator) *Queue[E] {
 	return &Queue[E]{heap: binaryheap.NewWith[E](comparator), Comparator: comparator}
 }
 
+// NewStableWith instantiates a new empty queue with the custom comparator, which dequeues elements with equal
+// priority first in, first out.
+func NewStableWith[E any](comparator utils.Comparator) *Queue[E] {
+	return &Queue[E]{heap: binaryheap.NewStableWith[E](comparator), Comparator: comparator}
+}
+
 // Enqueue adds a value to the end of the queue
 func (queue *Queue[E]) Enqueue(value E) {
 	queue.heap.Push(value)
@@ -466,6 +466,56 @@ func TestBinaryQueueSerialization(t *testing.T) {
 	}
 }
 
+func TestStableQueue(t *testing.T) {
+	queue := NewStableWith[Element](byPriority)
+	for i, priority := range []int{1, 2, 1, 3, 2, 1, 3} {
+		queue.Enqueue(Element{priority: priority, name: fmt.Sprint(i)})
+	}
+	expected := []string{"3", "6", "1", "4", "0", "2", "5"}
+	for _, expectedValue := range expected {
+		if actualValue, ok := queue.Dequeue(); actualValue.name != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue.name, expectedValue)
+		}
+	}
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestStableQueueSerialization(t *testing.T) {
+	// Ordered by the first letter only, the digit tells the order of equal elements
+	byLetter := func(a, b interface{}) int {
+		return utils.ByteComparator(a.(string)[0], b.(string)[0])
+	}
+	queue := NewStableWith[string](byLetter)
+	for _, value := range []string{"b1", "a1", "b2", "a2", "c1", "a3"} {
+		queue.Enqueue(value)
+	}
+
+	bytes, err := json.Marshal(queue)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expectedJSON := `["a1","a2","a3","b1","b2","c1"]`
+	if actualValue := string(bytes); actualValue != expectedJSON {
+		t.Errorf("Got %v expected %v", actualValue, expectedJSON)
+	}
+
+	newQueue := NewStableWith[string](byLetter)
+	if err := json.Unmarshal(bytes, newQueue); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	newQueue.Enqueue("a4")
+	if actualValue, expectedValue := fmt.Sprint(newQueue.Values()), "[a1 a2 a3 a4 b1 b2 c1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, expectedValue := range []string{"a1", "a2", "a3", "a4", "b1", "b2", "c1"} {
+		if actualValue, ok := newQueue.Dequeue(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
 func TestBTreeString(t *testing.T) {
 	c := NewWith[int](byPriority)
 	c.Enqueue(1)
@@ -474,6 +524,165 @@ func TestBTreeString(t *testing.T) {
 	}
 }
 
+func TestIndexedQueueEnqueue(t *testing.T) {
+	queue := NewIndexedWith[Element](byPriority)
+
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if _, ok := queue.Peek(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	a := queue.Enqueue(Element{name: "a", priority: 1})
+	b := queue.Enqueue(Element{name: "b", priority: 2})
+	c := queue.Enqueue(Element{name: "c", priority: 3})
+
+	if actualValue := queue.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, expectedValue := fmt.Sprint(queue.Values()), "[{3 c} {2 b} {1 a}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, ok := queue.Peek(); actualValue.name != "c" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "c")
+	}
+	if actualValue, ok := queue.PeekHandle(); actualValue != c || !ok {
+		t.Errorf("Got %v expected %v", actualValue, c)
+	}
+	for _, handle := range []Handle[Element]{a, b, c} {
+		if actualValue := queue.Contains(handle); actualValue != true {
+			t.Errorf("Got %v expected %v", actualValue, true)
+		}
+	}
+	if actualValue, found := queue.Get(b); actualValue.name != "b" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+	if actualValue := queue.Contains(Handle[Element]{}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := NewIndexedWith[Element](byPriority).Contains(a); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := queue.String(); actualValue != "IndexedPriorityQueue\n{3 c}, {2 b}, {1 a}" {
+		t.Errorf("Got %v expected %v", actualValue, "IndexedPriorityQueue\n{3 c}, {2 b}, {1 a}")
+	}
+}
+
+func TestIndexedQueueUpdate(t *testing.T) {
+	queue := NewIndexedWith[Element](byPriority)
+	a := queue.Enqueue(Element{name: "a", priority: 1})
+	b := queue.Enqueue(Element{name: "b", priority: 2})
+	c := queue.Enqueue(Element{name: "c", priority: 3})
+
+	if actualValue := queue.Update(a, Element{name: "a", priority: 4}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, _ := queue.Peek(); actualValue.name != "a" {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+	queue.Update(a, Element{name: "a", priority: 0})
+	queue.Update(b, Element{name: "b", priority: 5})
+
+	expected := []string{"b", "c", "a"}
+	for _, name := range expected {
+		if actualValue, ok := queue.Dequeue(); actualValue.name != name || !ok {
+			t.Errorf("Got %v expected %v", actualValue, name)
+		}
+	}
+	if _, ok := queue.Dequeue(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if actualValue := queue.Contains(c); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := queue.Update(c, Element{name: "c", priority: 1}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestIndexedQueueRemove(t *testing.T) {
+	queue := NewIndexedWith[int](utils.IntComparator)
+	handles := make([]Handle[int], 10)
+	for i := range handles {
+		handles[i] = queue.Enqueue(i)
+	}
+
+	if actualValue, ok := queue.Remove(handles[0]); actualValue != 0 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue, ok := queue.Remove(handles[5]); actualValue != 5 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if _, ok := queue.Remove(handles[5]); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if actualValue := queue.Contains(handles[5]); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := queue.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue, _ := queue.Peek(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+
+	queue.Clear()
+	if actualValue := queue.Contains(handles[1]); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if _, found := queue.Get(handles[1]); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+}
+
+func TestIndexedQueueRandom(t *testing.T) {
+	queue := NewIndexedWith[int](utils.IntComparator)
+	values := make(map[Handle[int]]int)
+
+	rand.Seed(3)
+	for i := 0; i < 10000; i++ {
+		switch r := rand.Intn(10); {
+		case r < 5 || len(values) == 0:
+			value := rand.Intn(1000)
+			values[queue.Enqueue(value)] = value
+		case r < 7:
+			for handle := range values {
+				value := rand.Intn(1000)
+				queue.Update(handle, value)
+				values[handle] = value
+				break
+			}
+		case r < 9:
+			for handle, value := range values {
+				if actualValue, ok := queue.Remove(handle); actualValue != value || !ok {
+					t.Fatalf("Got %v expected %v", actualValue, value)
+				}
+				delete(values, handle)
+				break
+			}
+		default:
+			expectedValue := -1
+			for _, value := range values {
+				if expectedValue < 0 || value < expectedValue {
+					expectedValue = value
+				}
+			}
+			handle, _ := queue.PeekHandle()
+			if actualValue, _ := queue.Dequeue(); actualValue != expectedValue {
+				t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+			}
+			delete(values, handle)
+		}
+		if actualValue, expectedValue := queue.Size(), len(values); actualValue != expectedValue {
+			t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
 func benchmarkEnqueue(b *testing.B, queue *Queue[int], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
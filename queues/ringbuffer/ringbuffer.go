@@ -0,0 +1,320 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ringbuffer implements lock-free bounded queues backed by a ring of a power-of-two size.
+//
+// Queue allows any number of concurrent producers and consumers (MPMC). Every cell of the ring carries a sequence
+// number that tells whether the cell is ready to be written or read at a given position, so that producers and
+// consumers only compete for their own position counter with a compare-and-swap and never block each other.
+//
+// SPSCQueue allows a single producer and a single consumer only, which need no compare-and-swap at all.
+//
+// Operations never block: TryEnqueue fails when the queue is full and TryDequeue fails when it is empty,
+// instead of overwriting elements like the circular buffer does.
+//
+// Structure is thread safe.
+//
+// Reference: https://www.1024cores.net/home/lock-free-algorithms/queues/bounded-mpmc-queue
+package ringbuffer
+
+import (
+	"sync/atomic"
+)
+
+// cacheLinePad keeps the position counters written by producers and consumers in separate cache lines.
+type cacheLinePad [64]byte
+
+// cell is a slot of the ring. Its sequence equals the position at which it can be written next,
+// and that position plus one once it has been written and can be read.
+type cell[E any] struct {
+	sequence uint64
+	value    E
+}
+
+// Queue is a lock-free multi-producer multi-consumer queue.
+type Queue[E any] struct {
+	_       cacheLinePad
+	enqueue uint64 // next position to be written
+	_       cacheLinePad
+	dequeue uint64 // next position to be read
+	_       cacheLinePad
+	mask    uint64
+	cells   []cell[E]
+}
+
+// New instantiates a new empty queue that holds at most capacity elements, which must be a power of two.
+func New[E any](capacity int) *Queue[E] {
+	checkCapacity(capacity)
+	queue := &Queue[E]{mask: uint64(capacity - 1), cells: make([]cell[E], capacity)}
+	for i := range queue.cells {
+		queue.cells[i].sequence = uint64(i)
+	}
+	return queue
+}
+
+// TryEnqueue adds the value to the end of the queue and returns true, or returns false if the queue is full.
+func (queue *Queue[E]) TryEnqueue(value E) bool {
+	position := atomic.LoadUint64(&queue.enqueue)
+	for {
+		cell := &queue.cells[position&queue.mask]
+		sequence := atomic.LoadUint64(&cell.sequence)
+		switch diff := int64(sequence - position); {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&queue.enqueue, position, position+1) {
+				cell.value = value
+				atomic.StoreUint64(&cell.sequence, position+1)
+				return true
+			}
+			position = atomic.LoadUint64(&queue.enqueue)
+		case diff < 0:
+			// The cell has not been read since the previous round
+			return false
+		default:
+			// Another producer has taken the position
+			position = atomic.LoadUint64(&queue.enqueue)
+		}
+	}
+}
+
+// TryDequeue removes first element of the queue and returns it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to dequeue.
+func (queue *Queue[E]) TryDequeue() (value E, ok bool) {
+	position := atomic.LoadUint64(&queue.dequeue)
+	for {
+		cell := &queue.cells[position&queue.mask]
+		sequence := atomic.LoadUint64(&cell.sequence)
+		switch diff := int64(sequence - (position + 1)); {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&queue.dequeue, position, position+1) {
+				value = cell.value
+				var zero E
+				cell.value = zero
+				atomic.StoreUint64(&cell.sequence, position+queue.mask+1)
+				return value, true
+			}
+			position = atomic.LoadUint64(&queue.dequeue)
+		case diff < 0:
+			// The cell has not been written yet
+			return value, false
+		default:
+			// Another consumer has taken the position
+			position = atomic.LoadUint64(&queue.dequeue)
+		}
+	}
+}
+
+// TryEnqueueBatch adds as many of the values as fit to the end of the queue and returns how many were added.
+// The values are added at consecutive positions, i.e. they are not interleaved with values of other producers.
+func (queue *Queue[E]) TryEnqueueBatch(values []E) int {
+	if len(values) == 0 {
+		return 0
+	}
+	for {
+		position := atomic.LoadUint64(&queue.enqueue)
+		count := 0
+		for count < len(values) && uint64(count) <= queue.mask {
+			sequence := atomic.LoadUint64(&queue.cells[(position+uint64(count))&queue.mask].sequence)
+			if sequence != position+uint64(count) {
+				if count == 0 && int64(sequence-position) < 0 {
+					return 0
+				}
+				break
+			}
+			count++
+		}
+		if count == 0 || !atomic.CompareAndSwapUint64(&queue.enqueue, position, position+uint64(count)) {
+			continue
+		}
+		for i := 0; i < count; i++ {
+			cell := &queue.cells[(position+uint64(i))&queue.mask]
+			cell.value = values[i]
+			atomic.StoreUint64(&cell.sequence, position+uint64(i)+1)
+		}
+		return count
+	}
+}
+
+// TryDequeueBatch removes up to len(values) elements from the front of the queue into values
+// and returns how many were removed.
+func (queue *Queue[E]) TryDequeueBatch(values []E) int {
+	if len(values) == 0 {
+		return 0
+	}
+	for {
+		position := atomic.LoadUint64(&queue.dequeue)
+		count := 0
+		for count < len(values) && uint64(count) <= queue.mask {
+			sequence := atomic.LoadUint64(&queue.cells[(position+uint64(count))&queue.mask].sequence)
+			if sequence != position+uint64(count)+1 {
+				if count == 0 && int64(sequence-(position+1)) < 0 {
+					return 0
+				}
+				break
+			}
+			count++
+		}
+		if count == 0 || !atomic.CompareAndSwapUint64(&queue.dequeue, position, position+uint64(count)) {
+			continue
+		}
+		var zero E
+		for i := 0; i < count; i++ {
+			cell := &queue.cells[(position+uint64(i))&queue.mask]
+			values[i] = cell.value
+			cell.value = zero
+			atomic.StoreUint64(&cell.sequence, position+uint64(i)+queue.mask+1)
+		}
+		return count
+	}
+}
+
+// Empty returns true if queue does not contain any elements.
+// The result is only a snapshot while other goroutines use the queue.
+func (queue *Queue[E]) Empty() bool {
+	return queue.Size() == 0
+}
+
+// Size returns number of elements within the queue.
+// The result is only a snapshot while other goroutines use the queue.
+func (queue *Queue[E]) Size() int {
+	return size(atomic.LoadUint64(&queue.enqueue), atomic.LoadUint64(&queue.dequeue), queue.mask)
+}
+
+// Capacity returns the maximum number of elements that the queue can hold.
+func (queue *Queue[E]) Capacity() int {
+	return int(queue.mask + 1)
+}
+
+// SPSCQueue is a lock-free single-producer single-consumer queue.
+// TryEnqueue and TryEnqueueBatch must only be called by one goroutine at a time,
+// as well as TryDequeue and TryDequeueBatch.
+type SPSCQueue[E any] struct {
+	_          cacheLinePad
+	enqueue    uint64 // next position to be written, written by the producer only
+	cachedHead uint64 // last dequeue position seen by the producer
+	_          cacheLinePad
+	dequeue    uint64 // next position to be read, written by the consumer only
+	cachedTail uint64 // last enqueue position seen by the consumer
+	_          cacheLinePad
+	mask       uint64
+	values     []E
+}
+
+// NewSPSC instantiates a new empty single-producer single-consumer queue that holds at most capacity elements,
+// which must be a power of two.
+func NewSPSC[E any](capacity int) *SPSCQueue[E] {
+	checkCapacity(capacity)
+	return &SPSCQueue[E]{mask: uint64(capacity - 1), values: make([]E, capacity)}
+}
+
+// TryEnqueue adds the value to the end of the queue and returns true, or returns false if the queue is full.
+func (queue *SPSCQueue[E]) TryEnqueue(value E) bool {
+	position := queue.enqueue
+	if position-queue.cachedHead > queue.mask {
+		if queue.cachedHead = atomic.LoadUint64(&queue.dequeue); position-queue.cachedHead > queue.mask {
+			return false
+		}
+	}
+	queue.values[position&queue.mask] = value
+	atomic.StoreUint64(&queue.enqueue, position+1)
+	return true
+}
+
+// TryDequeue removes first element of the queue and returns it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to dequeue.
+func (queue *SPSCQueue[E]) TryDequeue() (value E, ok bool) {
+	position := queue.dequeue
+	if position == queue.cachedTail {
+		if queue.cachedTail = atomic.LoadUint64(&queue.enqueue); position == queue.cachedTail {
+			return value, false
+		}
+	}
+	index := position & queue.mask
+	value = queue.values[index]
+	var zero E
+	queue.values[index] = zero
+	atomic.StoreUint64(&queue.dequeue, position+1)
+	return value, true
+}
+
+// TryEnqueueBatch adds as many of the values as fit to the end of the queue and returns how many were added.
+func (queue *SPSCQueue[E]) TryEnqueueBatch(values []E) int {
+	position := queue.enqueue
+	free := queue.mask + 1 - (position - queue.cachedHead)
+	if free < uint64(len(values)) {
+		queue.cachedHead = atomic.LoadUint64(&queue.dequeue)
+		free = queue.mask + 1 - (position - queue.cachedHead)
+	}
+	count := len(values)
+	if uint64(count) > free {
+		count = int(free)
+	}
+	for i := 0; i < count; i++ {
+		queue.values[(position+uint64(i))&queue.mask] = values[i]
+	}
+	if count > 0 {
+		atomic.StoreUint64(&queue.enqueue, position+uint64(count))
+	}
+	return count
+}
+
+// TryDequeueBatch removes up to len(values) elements from the front of the queue into values
+// and returns how many were removed.
+func (queue *SPSCQueue[E]) TryDequeueBatch(values []E) int {
+	position := queue.dequeue
+	available := queue.cachedTail - position
+	if available < uint64(len(values)) {
+		queue.cachedTail = atomic.LoadUint64(&queue.enqueue)
+		available = queue.cachedTail - position
+	}
+	count := len(values)
+	if uint64(count) > available {
+		count = int(available)
+	}
+	var zero E
+	for i := 0; i < count; i++ {
+		index := (position + uint64(i)) & queue.mask
+		values[i] = queue.values[index]
+		queue.values[index] = zero
+	}
+	if count > 0 {
+		atomic.StoreUint64(&queue.dequeue, position+uint64(count))
+	}
+	return count
+}
+
+// Empty returns true if queue does not contain any elements.
+// The result is only a snapshot while other goroutines use the queue.
+func (queue *SPSCQueue[E]) Empty() bool {
+	return queue.Size() == 0
+}
+
+// Size returns number of elements within the queue.
+// The result is only a snapshot while other goroutines use the queue.
+func (queue *SPSCQueue[E]) Size() int {
+	return size(atomic.LoadUint64(&queue.enqueue), atomic.LoadUint64(&queue.dequeue), queue.mask)
+}
+
+// Capacity returns the maximum number of elements that the queue can hold.
+func (queue *SPSCQueue[E]) Capacity() int {
+	return int(queue.mask + 1)
+}
+
+// checkCapacity panics unless the capacity is a power of two.
+func checkCapacity(capacity int) {
+	if capacity < 1 || capacity&(capacity-1) != 0 {
+		panic("Invalid capacity, should be a power of two")
+	}
+}
+
+// size returns the number of elements between both positions, which may have been loaded at different times.
+func size(enqueue, dequeue, mask uint64) int {
+	diff := int64(enqueue - dequeue)
+	if diff < 0 {
+		return 0
+	}
+	if uint64(diff) > mask+1 {
+		return int(mask + 1)
+	}
+	return int(diff)
+}
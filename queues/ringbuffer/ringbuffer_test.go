@@ -0,0 +1,355 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ringbuffer
+
+import (
+	"github.com/kcswag/kcgods/queues/circularbuffer"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// queue is implemented by both queues to share the tests.
+type queue[E any] interface {
+	TryEnqueue(value E) bool
+	TryDequeue() (value E, ok bool)
+	TryEnqueueBatch(values []E) int
+	TryDequeueBatch(values []E) int
+	Empty() bool
+	Size() int
+	Capacity() int
+}
+
+var queues = []struct {
+	name string
+	new  func(capacity int) queue[int]
+}{
+	{"Queue", func(capacity int) queue[int] { return New[int](capacity) }},
+	{"SPSCQueue", func(capacity int) queue[int] { return NewSPSC[int](capacity) }},
+}
+
+func TestRingBufferEnqueueDequeue(t *testing.T) {
+	for _, test := range queues {
+		queue := test.new(4)
+		if actualValue := queue.Empty(); actualValue != true {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, true)
+		}
+		if actualValue := queue.Capacity(); actualValue != 4 {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, 4)
+		}
+		if _, ok := queue.TryDequeue(); ok {
+			t.Errorf("%v: Got %v expected %v", test.name, ok, false)
+		}
+
+		// Several rounds to wrap around the ring
+		for round := 0; round < 3; round++ {
+			for i := 0; i < 4; i++ {
+				if actualValue := queue.TryEnqueue(round*10 + i); actualValue != true {
+					t.Errorf("%v: Got %v expected %v", test.name, actualValue, true)
+				}
+			}
+			if actualValue := queue.TryEnqueue(-1); actualValue != false {
+				t.Errorf("%v: Got %v expected %v", test.name, actualValue, false)
+			}
+			if actualValue := queue.Size(); actualValue != 4 {
+				t.Errorf("%v: Got %v expected %v", test.name, actualValue, 4)
+			}
+			for i := 0; i < 4; i++ {
+				if actualValue, ok := queue.TryDequeue(); actualValue != round*10+i || !ok {
+					t.Errorf("%v: Got %v expected %v", test.name, actualValue, round*10+i)
+				}
+			}
+			if _, ok := queue.TryDequeue(); ok {
+				t.Errorf("%v: Got %v expected %v", test.name, ok, false)
+			}
+		}
+	}
+}
+
+func TestRingBufferBatch(t *testing.T) {
+	for _, test := range queues {
+		queue := test.new(8)
+		queue.TryEnqueue(0)
+		queue.TryDequeue()
+
+		if actualValue := queue.TryEnqueueBatch(nil); actualValue != 0 {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, 0)
+		}
+		if actualValue := queue.TryEnqueueBatch([]int{1, 2, 3, 4, 5}); actualValue != 5 {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, 5)
+		}
+		if actualValue := queue.TryEnqueueBatch([]int{6, 7, 8, 9, 10}); actualValue != 3 {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, 3)
+		}
+		if actualValue := queue.TryEnqueueBatch([]int{9}); actualValue != 0 {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, 0)
+		}
+
+		values := make([]int, 6)
+		if actualValue := queue.TryDequeueBatch(values); actualValue != 6 || values[0] != 1 || values[5] != 6 {
+			t.Errorf("%v: Got %v expected %v", test.name, values, "[1,2,3,4,5,6]")
+		}
+		if actualValue := queue.TryEnqueueBatch([]int{9, 10}); actualValue != 2 {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, 2)
+		}
+		if actualValue := queue.TryDequeueBatch(values); actualValue != 4 || values[0] != 7 || values[3] != 10 {
+			t.Errorf("%v: Got %v expected %v", test.name, values[:4], "[7,8,9,10]")
+		}
+		if actualValue := queue.TryDequeueBatch(values); actualValue != 0 {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, 0)
+		}
+	}
+}
+
+func TestRingBufferInvalidCapacity(t *testing.T) {
+	for _, capacity := range []int{0, 3, 12} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Got no panic for capacity %v", capacity)
+				}
+			}()
+			New[int](capacity)
+		}()
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for capacity %v", 6)
+		}
+	}()
+	NewSPSC[int](6)
+}
+
+func TestRingBufferStress(t *testing.T) {
+	queue := New[int](16)
+	producers, consumers, count := 8, 8, 5000
+
+	var producing, consuming sync.WaitGroup
+	taken := make([][]int, consumers)
+	done := make(chan struct{})
+	for p := 0; p < producers; p++ {
+		producing.Add(1)
+		go func(p int) {
+			defer producing.Done()
+			batch := make([]int, 0, 3)
+			for i := 0; i < count; i++ {
+				value := p*count + i
+				if p%2 == 0 {
+					for !queue.TryEnqueue(value) {
+						runtime.Gosched()
+					}
+					continue
+				}
+				if batch = append(batch, value); len(batch) == cap(batch) || i == count-1 {
+					for len(batch) > 0 {
+						n := queue.TryEnqueueBatch(batch)
+						batch = batch[:copy(batch, batch[n:])]
+						if n == 0 {
+							runtime.Gosched()
+						}
+					}
+				}
+			}
+		}(p)
+	}
+	for c := 0; c < consumers; c++ {
+		consuming.Add(1)
+		go func(c int) {
+			defer consuming.Done()
+			batch := make([]int, 4)
+			for {
+				if c%2 == 0 {
+					if value, ok := queue.TryDequeue(); ok {
+						taken[c] = append(taken[c], value)
+						continue
+					}
+				} else if n := queue.TryDequeueBatch(batch); n > 0 {
+					taken[c] = append(taken[c], batch[:n]...)
+					continue
+				}
+				select {
+				case <-done:
+					if queue.Empty() {
+						return
+					}
+				default:
+					runtime.Gosched()
+				}
+			}
+		}(c)
+	}
+	producing.Wait()
+	close(done)
+	consuming.Wait()
+
+	seen := make([]bool, producers*count)
+	for _, values := range taken {
+		// Values of a single producer are taken in order by every consumer
+		last := make([]int, producers)
+		for i := range last {
+			last[i] = -1
+		}
+		for _, value := range values {
+			if seen[value] {
+				t.Fatalf("Value %v was taken twice", value)
+			}
+			seen[value] = true
+			if p := value / count; value < last[p] {
+				t.Fatalf("Value %v was taken after %v", value, last[p])
+			} else {
+				last[p] = value
+			}
+		}
+	}
+	for value, ok := range seen {
+		if !ok {
+			t.Fatalf("Value %v was never taken", value)
+		}
+	}
+}
+
+func TestRingBufferStressSPSC(t *testing.T) {
+	queue := NewSPSC[int](8)
+	count := 100000
+
+	go func() {
+		batch := make([]int, 0, 5)
+		for i := 0; i < count; i++ {
+			if i%3 == 0 {
+				for !queue.TryEnqueue(i) {
+					runtime.Gosched()
+				}
+				continue
+			}
+			if batch = append(batch, i); len(batch) == cap(batch) || i == count-1 || (i+1)%3 == 0 {
+				for len(batch) > 0 {
+					n := queue.TryEnqueueBatch(batch)
+					batch = batch[:copy(batch, batch[n:])]
+					if n == 0 {
+						runtime.Gosched()
+					}
+				}
+			}
+		}
+	}()
+
+	batch := make([]int, 7)
+	for expectedValue := 0; expectedValue < count; {
+		if expectedValue%2 == 0 {
+			if actualValue, ok := queue.TryDequeue(); ok {
+				if actualValue != expectedValue {
+					t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+				}
+				expectedValue++
+				continue
+			}
+		} else if n := queue.TryDequeueBatch(batch); n > 0 {
+			for _, actualValue := range batch[:n] {
+				if actualValue != expectedValue {
+					t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+				}
+				expectedValue++
+			}
+			continue
+		}
+		runtime.Gosched()
+	}
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+// mutexQueue wraps a circular buffer with a mutex to compare with the lock-free queues.
+type mutexQueue[E any] struct {
+	queue *circularbuffer.Queue[E]
+	mutex sync.Mutex
+}
+
+func (queue *mutexQueue[E]) TryEnqueue(value E) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.queue.Full() {
+		return false
+	}
+	queue.queue.Enqueue(value)
+	return true
+}
+
+func (queue *mutexQueue[E]) TryDequeue() (value E, ok bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Dequeue()
+}
+
+// benchmarkParallel lets every goroutine enqueue and dequeue in turns.
+func benchmarkParallel(b *testing.B, queue interface {
+	TryEnqueue(value int) bool
+	TryDequeue() (value int, ok bool)
+}) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for !queue.TryEnqueue(1) {
+				runtime.Gosched()
+			}
+			for {
+				if _, ok := queue.TryDequeue(); ok {
+					break
+				}
+				runtime.Gosched()
+			}
+		}
+	})
+}
+
+// benchmarkProducerConsumer passes b.N values from one producer to one consumer.
+func benchmarkProducerConsumer(b *testing.B, queue interface {
+	TryEnqueue(value int) bool
+	TryDequeue() (value int, ok bool)
+}) {
+	go func() {
+		for i := 0; i < b.N; i++ {
+			for !queue.TryEnqueue(i) {
+				runtime.Gosched()
+			}
+		}
+	}()
+	for i := 0; i < b.N; {
+		if _, ok := queue.TryDequeue(); ok {
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
+func BenchmarkRingBufferParallel(b *testing.B) {
+	benchmarkParallel(b, New[int](1024))
+}
+
+func BenchmarkMutexCircularBufferParallel(b *testing.B) {
+	benchmarkParallel(b, &mutexQueue[int]{queue: circularbuffer.New[int](1024)})
+}
+
+func BenchmarkRingBufferProducerConsumer(b *testing.B) {
+	benchmarkProducerConsumer(b, New[int](1024))
+}
+
+func BenchmarkRingBufferSPSCProducerConsumer(b *testing.B) {
+	benchmarkProducerConsumer(b, NewSPSC[int](1024))
+}
+
+func BenchmarkMutexCircularBufferProducerConsumer(b *testing.B) {
+	benchmarkProducerConsumer(b, &mutexQueue[int]{queue: circularbuffer.New[int](1024)})
+}
+
+func BenchmarkRingBufferBatch(b *testing.B) {
+	queue := New[int](1024)
+	values := make([]int, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.TryEnqueueBatch(values)
+		queue.TryDequeueBatch(values)
+	}
+}
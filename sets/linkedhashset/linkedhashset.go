@@ -16,6 +16,7 @@ package linkedhashset
 import (
 	"fmt"
 	"github.com/kcswag/kcgods/lists/doublylinkedlist"
+	"github.com/kcswag/kcgods/utils"
 	"strings"
 )
 
@@ -34,7 +35,7 @@ var itemExists = struct{}{}
 func New[E comparable](values ...E) *Set[E] {
 	set := &Set[E]{
 		table:    make(map[E]struct{}),
-		ordering: doublylinkedlist.New[E](),
+		ordering: doublylinkedlist.NewWithEquals[E](utils.ComparableEquals[E]),
 	}
 	if len(values) > 0 {
 		set.Add(values...)
@@ -59,8 +60,7 @@ func (set *Set[E]) Remove(items ...E) {
 	for _, item := range items {
 		if _, contains := set.table[item]; contains {
 			delete(set.table, item)
-			index := set.ordering.IndexOf(item)
-			set.ordering.Remove(index)
+			set.ordering.RemoveValue(item)
 		}
 	}
 }
@@ -0,0 +1,230 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package skiplistset implements a sorted set backed by a skip list.
+//
+// Besides the usual set operations it supports access to elements by index and ranking of elements in O(log n).
+//
+// Structure is not thread safe.
+//
+// Reference: http://en.wikipedia.org/wiki/Set_%28abstract_data_type%29
+package skiplistset
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/maps/skiplistmap"
+	"github.com/kcswag/kcgods/utils"
+	"reflect"
+	"strings"
+)
+
+// Assert Set implementation
+//var _ sets.Set = (*Set)(nil)
+
+// Set holds elements in a skip list
+type Set[E any] struct {
+	list *skiplistmap.Map[E, struct{}]
+}
+
+var itemExists = struct{}{}
+
+// NewWith instantiates a new empty set with the custom comparator.
+func NewWith[E any](comparator utils.Comparator, values ...E) *Set[E] {
+	set := &Set[E]{list: skiplistmap.NewWith[E, struct{}](comparator)}
+	if len(values) > 0 {
+		set.Add(values...)
+	}
+	return set
+}
+
+// NewWithIntComparator instantiates a new empty set with the IntComparator, i.e. keys are of type int.
+func NewWithIntComparator(values ...int) *Set[int] {
+	return NewWith[int](utils.IntComparator, values...)
+}
+
+// NewWithStringComparator instantiates a new empty set with the StringComparator, i.e. keys are of type string.
+func NewWithStringComparator(values ...string) *Set[string] {
+	return NewWith[string](utils.StringComparator, values...)
+}
+
+// NewWithLevels instantiates a new empty set with the custom comparator, the probability of promoting a node
+// to the next level and the maximum number of levels.
+// Probability must be within (0, 1) and maxLevel must be positive, otherwise method panics.
+func NewWithLevels[E any](comparator utils.Comparator, probability float64, maxLevel int) *Set[E] {
+	return &Set[E]{list: skiplistmap.NewWithLevels[E, struct{}](comparator, probability, maxLevel)}
+}
+
+// Seed reseeds the random number generator that picks the level of new nodes.
+// Sets seeded with the same value and fed the same operations end up with the same structure.
+func (set *Set[E]) Seed(seed int64) {
+	set.list.Seed(seed)
+}
+
+// Add adds the items (one or more) to the set.
+func (set *Set[E]) Add(items ...E) {
+	for _, item := range items {
+		set.list.Put(item, itemExists)
+	}
+}
+
+// Remove removes the items (one or more) from the set.
+func (set *Set[E]) Remove(items ...E) {
+	for _, item := range items {
+		set.list.Remove(item)
+	}
+}
+
+// Contains checks weather items (one or more) are present in the set.
+// All items have to be present in the set for the method to return true.
+// Returns true if no arguments are passed at all, i.e. set is always superset of empty set.
+func (set *Set[E]) Contains(items ...E) bool {
+	for _, item := range items {
+		if _, contains := set.list.Get(item); !contains {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty returns true if set does not contain any elements.
+func (set *Set[E]) Empty() bool {
+	return set.list.Empty()
+}
+
+// Size returns number of elements within the set.
+func (set *Set[E]) Size() int {
+	return set.list.Size()
+}
+
+// Clear clears all values in the set.
+func (set *Set[E]) Clear() {
+	set.list.Clear()
+}
+
+// Values returns all items in the set.
+func (set *Set[E]) Values() []E {
+	return set.list.Keys()
+}
+
+// Get returns the element at the given index in sorted order in O(log n).
+// Second return parameter is true if index is within bounds of the set, otherwise false.
+func (set *Set[E]) Get(index int) (E, bool) {
+	item, _, found := set.list.GetAt(index)
+	return item, found
+}
+
+// IndexOf returns the index of the given element in sorted order in O(log n), or -1 if the element is not found.
+func (set *Set[E]) IndexOf(item E) int {
+	return set.list.IndexOf(item)
+}
+
+// Floor returns the largest element that is smaller than or equal to the given element.
+// Second return parameter is false if there is no such element.
+func (set *Set[E]) Floor(item E) (E, bool) {
+	floor, _ := set.list.Floor(item)
+	// Floor returns the zero value when nothing is found, which may or may not be an element itself
+	if !set.Contains(floor) || set.list.Comparator(floor, item) > 0 {
+		return *new(E), false
+	}
+	return floor, true
+}
+
+// Ceiling returns the smallest element that is larger than or equal to the given element.
+// Second return parameter is false if there is no such element.
+func (set *Set[E]) Ceiling(item E) (E, bool) {
+	ceiling, _ := set.list.Ceiling(item)
+	if !set.Contains(ceiling) || set.list.Comparator(ceiling, item) < 0 {
+		return *new(E), false
+	}
+	return ceiling, true
+}
+
+// String returns a string representation of container
+func (set *Set[E]) String() string {
+	str := "SkipListSet\n"
+	items := []string{}
+	for _, v := range set.list.Keys() {
+		items = append(items, fmt.Sprintf("%v", v))
+	}
+	str += strings.Join(items, ", ")
+	return str
+}
+
+// Intersection returns the intersection between two sets.
+// The new set consists of all elements that are both in "set" and "another".
+// The two sets should have the same comparators, otherwise the result is empty set.
+// Ref: https://en.wikipedia.org/wiki/Intersection_(set_theory)
+func (set *Set[E]) Intersection(another *Set[E]) *Set[E] {
+	result := set.empty()
+	if !set.sameComparator(another) {
+		return result
+	}
+
+	// Iterate over smaller set (optimization)
+	if set.Size() <= another.Size() {
+		for it := set.Iterator(); it.Next(); {
+			if another.Contains(it.Value()) {
+				result.Add(it.Value())
+			}
+		}
+	} else {
+		for it := another.Iterator(); it.Next(); {
+			if set.Contains(it.Value()) {
+				result.Add(it.Value())
+			}
+		}
+	}
+
+	return result
+}
+
+// Union returns the union of two sets.
+// The new set consists of all elements that are in "set" or "another" (possibly both).
+// The two sets should have the same comparators, otherwise the result is empty set.
+// Ref: https://en.wikipedia.org/wiki/Union_(set_theory)
+func (set *Set[E]) Union(another *Set[E]) *Set[E] {
+	result := set.empty()
+	if !set.sameComparator(another) {
+		return result
+	}
+
+	for it := set.Iterator(); it.Next(); {
+		result.Add(it.Value())
+	}
+	for it := another.Iterator(); it.Next(); {
+		result.Add(it.Value())
+	}
+
+	return result
+}
+
+// Difference returns the difference between two sets.
+// The two sets should have the same comparators, otherwise the result is empty set.
+// The new set consists of all elements that are in "set" but not in "another".
+// Ref: https://proofwiki.org/wiki/Definition:Set_Difference
+func (set *Set[E]) Difference(another *Set[E]) *Set[E] {
+	result := set.empty()
+	if !set.sameComparator(another) {
+		return result
+	}
+
+	for it := set.Iterator(); it.Next(); {
+		if !another.Contains(it.Value()) {
+			result.Add(it.Value())
+		}
+	}
+
+	return result
+}
+
+// empty returns a new empty set with the same comparator and levels as the set.
+func (set *Set[E]) empty() *Set[E] {
+	return NewWithLevels[E](set.list.Comparator, set.list.Probability(), set.list.MaxLevel())
+}
+
+func (set *Set[E]) sameComparator(another *Set[E]) bool {
+	setComparator := reflect.ValueOf(set.list.Comparator)
+	anotherComparator := reflect.ValueOf(another.list.Comparator)
+	return setComparator.Pointer() == anotherComparator.Pointer()
+}
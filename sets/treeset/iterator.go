@@ -12,22 +12,45 @@ import (
 //var _ containers.ReverseIteratorWithIndex = (*Iterator)(nil)
 
 // Iterator returns a stateful iterator whose values can be fetched by an index.
-type Iterator[E comparable] struct {
+type Iterator[E any] struct {
 	index    int
 	iterator rbt.Iterator[E, any]
 	tree     *rbt.Tree[E, any]
+	before   bool // whether the iterator was moved right before an element by seeking
 }
 
+// unknownIndex is the index of an iterator that has been moved to an element by seeking, the actual index is only
+// computed when asked for, since that takes linear time.
+const unknownIndex = -2
+
 // Iterator holding the iterator's state
 func (set *Set[E]) Iterator() Iterator[E] {
 	return Iterator[E]{index: -1, iterator: set.tree.Iterator(), tree: set.tree}
 }
 
+// IteratorFrom returns a stateful iterator positioned right before the first element that is bigger than
+// (or equal to, if inclusive) the given item, or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the element before it.
+// Item should adhere to the comparator's type assertion, otherwise method panics.
+func (set *Set[E]) IteratorFrom(item E, inclusive bool) Iterator[E] {
+	iterator := Iterator[E]{iterator: set.tree.IteratorFrom(item, inclusive), tree: set.tree}
+	iterator.seeked(true)
+	return iterator
+}
+
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
 // If Next() returns true, then next element's index and value can be retrieved by Index() and Value().
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
 // Modifies the state of the iterator.
 func (iterator *Iterator[E]) Next() bool {
+	iterator.before = false
+	if iterator.index == unknownIndex {
+		if iterator.iterator.Next() {
+			return true
+		}
+		iterator.index = iterator.tree.Size()
+		return false
+	}
 	if iterator.index < iterator.tree.Size() {
 		iterator.index++
 	}
@@ -38,6 +61,14 @@ func (iterator *Iterator[E]) Next() bool {
 // If Prev() returns true, then previous element's index and value can be retrieved by Index() and Value().
 // Modifies the state of the iterator.
 func (iterator *Iterator[E]) Prev() bool {
+	iterator.before = false
+	if iterator.index == unknownIndex {
+		if iterator.iterator.Prev() {
+			return true
+		}
+		iterator.index = -1
+		return false
+	}
 	if iterator.index >= 0 {
 		iterator.index--
 	}
@@ -51,8 +82,15 @@ func (iterator *Iterator[E]) Value() E {
 }
 
 // Index returns the current element's index.
-// Does not modify the state of the iterator.
+// After seeking, the first call counts the elements before the current one, which takes O(n) time,
+// and caches the result in the iterator; later calls and moves by Next() or Prev() take O(1) time.
 func (iterator *Iterator[E]) Index() int {
+	if iterator.index == unknownIndex {
+		iterator.index = iterator.tree.CountRange(iterator.tree.Left().Key, iterator.Value(), true, false)
+		if iterator.before {
+			iterator.index--
+		}
+	}
 	return iterator.index
 }
 
@@ -60,6 +98,7 @@ func (iterator *Iterator[E]) Index() int {
 // Call Next() to fetch the first element if any.
 func (iterator *Iterator[E]) Begin() {
 	iterator.index = -1
+	iterator.before = false
 	iterator.iterator.Begin()
 }
 
@@ -67,9 +106,56 @@ func (iterator *Iterator[E]) Begin() {
 // Call Prev() to fetch the last element if any.
 func (iterator *Iterator[E]) End() {
 	iterator.index = iterator.tree.Size()
+	iterator.before = false
 	iterator.iterator.End()
 }
 
+// Seek moves the iterator right before the first element that is equal or bigger than the given item,
+// or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the last element that is smaller than the given item.
+// Item should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[E]) Seek(item E) {
+	iterator.iterator.Seek(item)
+	iterator.seeked(true)
+}
+
+// SeekGE moves the iterator to the first element that is equal or bigger than the given item and returns true
+// if there was such an element, whose index and value can then be retrieved by Index() and Value().
+// Otherwise the iterator is moved past the last element.
+// Item should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[E]) SeekGE(item E) bool {
+	if !iterator.iterator.SeekGE(item) {
+		iterator.End()
+		return false
+	}
+	iterator.seeked(false)
+	return true
+}
+
+// SeekLE moves the iterator to the last element that is equal or smaller than the given item and returns true
+// if there was such an element, whose index and value can then be retrieved by Index() and Value().
+// Otherwise the iterator is moved before the first element.
+// Item should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[E]) SeekLE(item E) bool {
+	if !iterator.iterator.SeekLE(item) {
+		iterator.Begin()
+		return false
+	}
+	iterator.seeked(false)
+	return true
+}
+
+// seeked updates the index after the underlying iterator has been moved to (or right before) an element,
+// or past the last element if there was none.
+func (iterator *Iterator[E]) seeked(before bool) {
+	if iterator.iterator.Node() == nil {
+		iterator.End()
+		return
+	}
+	iterator.index = unknownIndex
+	iterator.before = before
+}
+
 // First moves the iterator to the first element and returns true if there was a first element in the container.
 // If First() returns true, then first element's index and value can be retrieved by Index() and Value().
 // Modifies the state of the iterator.
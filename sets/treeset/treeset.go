@@ -21,14 +21,14 @@ import (
 //var _ sets.Set = (*Set)(nil)
 
 // Set holds elements in a red-black tree
-type Set[E comparable] struct {
+type Set[E any] struct {
 	tree *rbt.Tree[E, any]
 }
 
 var itemExists = struct{}{}
 
 // NewWith instantiates a new empty set with the custom comparator.
-func NewWith[E comparable](comparator utils.Comparator, values ...E) *Set[E] {
+func NewWith[E any](comparator utils.Comparator, values ...E) *Set[E] {
 	set := &Set[E]{tree: rbt.NewWith[E, any](comparator)}
 	if len(values) > 0 {
 		set.Add(values...)
@@ -54,6 +54,20 @@ func NewWithStringComparator(values ...string) *Set[string] {
 	return set
 }
 
+// NewFromSorted instantiates a new set with the custom comparator and bulk-loads the given values in O(n).
+// Values must be strictly ascending under the comparator, otherwise an error is returned.
+func NewFromSorted[E any](comparator utils.Comparator, values []E) (*Set[E], error) {
+	set := NewWith[E](comparator)
+	items := make([]any, len(values))
+	for i := range items {
+		items[i] = itemExists
+	}
+	if err := set.tree.BuildFromSorted(values, items); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
 // Add adds the items (one or more) to the set.
 func (set *Set[E]) Add(items ...E) {
 	for _, item := range items {
@@ -68,6 +82,18 @@ func (set *Set[E]) Remove(items ...E) {
 	}
 }
 
+// RemoveRange removes all items between lo and hi and returns the number of removed items.
+// Each bound is included if its inclusive flag is set.
+func (set *Set[E]) RemoveRange(lo E, hi E, loInclusive bool, hiInclusive bool) int {
+	return set.tree.RemoveRange(lo, hi, loInclusive, hiInclusive)
+}
+
+// CountRange returns the number of items between lo and hi.
+// Each bound is included if its inclusive flag is set.
+func (set *Set[E]) CountRange(lo E, hi E, loInclusive bool, hiInclusive bool) int {
+	return set.tree.CountRange(lo, hi, loInclusive, hiInclusive)
+}
+
 // Contains checks weather items (one or more) are present in the set.
 // All items have to be present in the set for the method to return true.
 // Returns true if no arguments are passed at all, i.e. set is always superset of empty set.
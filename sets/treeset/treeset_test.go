@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/kcswag/kcgods/containers"
+	"github.com/kcswag/kcgods/utils"
 	"strings"
 	"testing"
 )
@@ -444,6 +445,22 @@ func TestSetIteratorPrevTo(t *testing.T) {
 	}
 }
 
+func TestSetNewFromSorted(t *testing.T) {
+	set, err := NewFromSorted[string](utils.StringComparator, []string{"a", "b", "c"})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := set.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := set.Contains("a", "b", "c"); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if _, err := NewFromSorted[string](utils.StringComparator, []string{"b", "a"}); err == nil {
+		t.Errorf("Expected error for unsorted values")
+	}
+}
+
 func TestSetSerialization(t *testing.T) {
 	set := NewWithStringComparator()
 	set.Add("a", "b", "c")
@@ -554,6 +571,187 @@ func TestSetDifference(t *testing.T) {
 	}
 }
 
+func TestSetRemoveRange(t *testing.T) {
+	set := NewWithStringComparator("a", "b", "c", "d", "e", "f")
+	if actualValue, expectedValue := set.CountRange("b", "e", true, false), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := set.CountRange("bb", "z", true, true), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := set.RemoveRange("b", "e", false, true), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := set.String(), "TreeSet\na, b, f"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := set.RemoveRange("a", "a", false, true), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := set.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetIteratorFrom(t *testing.T) {
+	set := NewWithIntComparator(10, 20, 30, 40, 50)
+	// key, inclusive, whether Next() finds an element and its key, whether Prev() finds an element and its key
+	tests := [][]interface{}{
+		{30, true, true, 30, true, 20},
+		{30, false, true, 40, true, 30},
+		{25, true, true, 30, true, 20},
+		{25, false, true, 30, true, 20},
+		{50, false, false, 0, true, 50},
+		{60, true, false, 0, true, 50},
+		{5, true, true, 10, false, 0},
+		{10, true, true, 10, false, 0},
+	}
+	for _, test := range tests {
+		it := set.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Next(), test[2].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Value() != test[3].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Value(), test[3], test)
+		}
+		it = set.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Prev(), test[4].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Value() != test[5].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Value(), test[5], test)
+		}
+	}
+
+	it := set.IteratorFrom(20, true)
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Value())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		keys = append(keys, it.Value())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50 50 40 30 20 10]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetIteratorSeek(t *testing.T) {
+	set := NewWithIntComparator(10, 20, 30, 40, 50)
+	it := set.Iterator()
+	assertIteratorAt := func(actualFound bool, expectedFound bool, expectedKey int) {
+		t.Helper()
+		if actualFound != expectedFound {
+			t.Errorf("Got %v expected %v", actualFound, expectedFound)
+		} else if actualFound && it.Value() != expectedKey {
+			t.Errorf("Got %v expected %v", it.Value(), expectedKey)
+		}
+	}
+	assertIteratorAt(it.SeekGE(35), true, 40)
+	assertIteratorAt(it.Next(), true, 50)
+	assertIteratorAt(it.SeekGE(40), true, 40)
+	assertIteratorAt(it.Prev(), true, 30)
+	assertIteratorAt(it.SeekGE(60), false, 0)
+	assertIteratorAt(it.Prev(), true, 50)
+	assertIteratorAt(it.SeekLE(35), true, 30)
+	assertIteratorAt(it.Prev(), true, 20)
+	assertIteratorAt(it.SeekLE(30), true, 30)
+	assertIteratorAt(it.Next(), true, 40)
+	assertIteratorAt(it.SeekLE(5), false, 0)
+	assertIteratorAt(it.Next(), true, 10)
+	it.Seek(20)
+	assertIteratorAt(it.Next(), true, 20)
+	it.Seek(21)
+	assertIteratorAt(it.Prev(), true, 20)
+	it.Seek(60)
+	assertIteratorAt(it.Next(), false, 0)
+	it.Seek(60)
+	assertIteratorAt(it.Prev(), true, 50)
+	it.Seek(0)
+	assertIteratorAt(it.Prev(), false, 0)
+}
+
+func TestSetIteratorSeekIndex(t *testing.T) {
+	set := NewWithIntComparator(10, 20, 30, 40, 50)
+	it := set.IteratorFrom(30, true)
+	it.Next()
+	if actualValue, expectedValue := it.Index(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Next()
+	if actualValue, expectedValue := it.Index(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it = set.IteratorFrom(30, true)
+	if actualValue, expectedValue := it.Index(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Next()
+	if actualValue, expectedValue := it.Index(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.SeekLE(45)
+	if actualValue, expectedValue := it.Index(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Prev()
+	if actualValue, expectedValue := it.Index(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.SeekGE(60)
+	if actualValue, expectedValue := it.Index(), 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.SeekLE(5)
+	if actualValue, expectedValue := it.Index(), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.SeekGE(5)
+	for it.Next() {
+	}
+	if actualValue, expectedValue := it.Index(), 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetNonComparable(t *testing.T) {
+	comparator := func(a, b interface{}) int {
+		return utils.IntComparator(len(a.([]int)), len(b.([]int)))
+	}
+	set := NewWith[[]int](comparator, []int{1, 2}, []int{1}, []int{1, 2, 3})
+	set.Add([]int{2})
+
+	if actualValue, expectedValue := set.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := set.Contains([]int{0, 0}, []int{0, 0, 0}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := fmt.Sprint(set.Values()), "[[2] [1 2] [1 2 3]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	selected := set.Select(func(index int, value []int) bool {
+		return value[0] == 1 && len(value) > 1
+	})
+	if actualValue, expectedValue := fmt.Sprint(selected.Values()), "[[1 2] [1 2 3]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	bytes, err := set.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	deserialized := NewWith[[]int](comparator)
+	if err := deserialized.FromJSON(bytes); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := deserialized.String(), set.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func benchmarkContains(b *testing.B, set *Set[int], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
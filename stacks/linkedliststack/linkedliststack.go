@@ -25,7 +25,7 @@ type Stack[E any] struct {
 
 // New nnstantiates a new empty stack
 func New[E any]() *Stack[E] {
-	return &Stack[E]{list: &singlylinkedlist.List[E]{}}
+	return &Stack[E]{list: singlylinkedlist.New[E]()}
 }
 
 // Push adds a value onto the top of the stack
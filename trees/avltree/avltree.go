@@ -18,7 +18,7 @@ import (
 //var _ trees.Tree = new(Tree)
 
 // Tree holds elements of the AVL tree.
-type Tree[K, V comparable] struct {
+type Tree[K, V any] struct {
 	Root       *Node[K, V]      // Root node
 	Comparator utils.Comparator // Key comparator
 	size       int              // Total number of keys in the tree
@@ -34,17 +34,17 @@ type Node[K, V any] struct {
 }
 
 // NewWith instantiates an AVL tree with the custom comparator.
-func NewWith[K, V comparable](comparator utils.Comparator) *Tree[K, V] {
+func NewWith[K, V any](comparator utils.Comparator) *Tree[K, V] {
 	return &Tree[K, V]{Comparator: comparator}
 }
 
 // NewWithIntComparator instantiates an AVL tree with the IntComparator, i.e. keys are of type int.
-func NewWithIntComparator[V comparable]() *Tree[int, V] {
+func NewWithIntComparator[V any]() *Tree[int, V] {
 	return &Tree[int, V]{Comparator: utils.IntComparator}
 }
 
 // NewWithStringComparator instantiates an AVL tree with the StringComparator, i.e. keys are of type string.
-func NewWithStringComparator[V comparable]() *Tree[string, V] {
+func NewWithStringComparator[V any]() *Tree[string, V] {
 	return &Tree[string, V]{Comparator: utils.StringComparator}
 }
 
@@ -289,7 +289,7 @@ func (t *Tree[K, V]) remove(key K, qp **Node[K, V]) bool {
 	return false
 }
 
-func removeMin[K, V comparable](qp **Node[K, V], minKey *K, minVal *V) bool {
+func removeMin[K, V any](qp **Node[K, V], minKey *K, minVal *V) bool {
 	q := *qp
 	if q.Children[0] == nil {
 		*minKey = q.Key
@@ -307,7 +307,7 @@ func removeMin[K, V comparable](qp **Node[K, V], minKey *K, minVal *V) bool {
 	return false
 }
 
-func putFix[K, V comparable](c int8, t **Node[K, V]) bool {
+func putFix[K, V any](c int8, t **Node[K, V]) bool {
 	s := *t
 	if s.b == 0 {
 		s.b = c
@@ -328,7 +328,7 @@ func putFix[K, V comparable](c int8, t **Node[K, V]) bool {
 	return false
 }
 
-func removeFix[K, V comparable](c int8, t **Node[K, V]) bool {
+func removeFix[K, V any](c int8, t **Node[K, V]) bool {
 	s := *t
 	if s.b == 0 {
 		s.b = c
@@ -357,14 +357,14 @@ func removeFix[K, V comparable](c int8, t **Node[K, V]) bool {
 	return true
 }
 
-func singlerot[K, V comparable](c int8, s *Node[K, V]) *Node[K, V] {
+func singlerot[K, V any](c int8, s *Node[K, V]) *Node[K, V] {
 	s.b = 0
 	s = rotate(c, s)
 	s.b = 0
 	return s
 }
 
-func doublerot[K, V comparable](c int8, s *Node[K, V]) *Node[K, V] {
+func doublerot[K, V any](c int8, s *Node[K, V]) *Node[K, V] {
 	a := (c + 1) / 2
 	r := s.Children[a]
 	s.Children[a] = rotate(-c, s.Children[a])
@@ -386,7 +386,7 @@ func doublerot[K, V comparable](c int8, s *Node[K, V]) *Node[K, V] {
 	return p
 }
 
-func rotate[K, V comparable](c int8, s *Node[K, V]) *Node[K, V] {
+func rotate[K, V any](c int8, s *Node[K, V]) *Node[K, V] {
 	a := (c + 1) / 2
 	r := s.Children[a]
 	s.Children[a] = r.Children[a^1]
@@ -444,7 +444,7 @@ func (n *Node[K, V]) walk1(a int) *Node[K, V] {
 	return p
 }
 
-func output[K, V comparable](node *Node[K, V], prefix string, isTail bool, str *string) {
+func output[K, V any](node *Node[K, V], prefix string, isTail bool, str *string) {
 	if node.Children[1] != nil {
 		newPrefix := prefix
 		if isTail {
@@ -0,0 +1,60 @@
+// Copyright (c) 2017, Benjamin Scher Purcell. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/containers"
+)
+
+// BuildFromSorted replaces the contents of the tree with the given key-value pairs in O(n).
+// Keys must be strictly ascending under the tree's comparator and have the same length as values,
+// otherwise an error is returned and the tree is left untouched.
+//
+// The resulting tree is perfectly balanced, i.e. the sizes of any two sibling subtrees differ by at most one.
+func (t *Tree[K, V]) BuildFromSorted(keys []K, values []V) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("avltree: got %d keys and %d values", len(keys), len(values))
+	}
+	for i := 1; i < len(keys); i++ {
+		if t.Comparator(keys[i-1], keys[i]) >= 0 {
+			return fmt.Errorf("avltree: key %v at index %d is not greater than previous key %v", keys[i], i, keys[i-1])
+		}
+	}
+	t.Clear()
+	t.Root, _ = buildSorted(keys, values, nil)
+	t.size = len(keys)
+	return nil
+}
+
+// BuildFromSortedIterator replaces the contents of the tree with the key-value pairs read from the iterator in O(n).
+// The iterator is consumed by calling Next() until it returns false.
+// Keys must be strictly ascending under the tree's comparator, otherwise an error is returned and the tree is left untouched.
+func (t *Tree[K, V]) BuildFromSortedIterator(iterator containers.ForwardIteratorWithKey[K, V]) error {
+	var keys []K
+	var values []V
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+		values = append(values, iterator.Value())
+	}
+	return t.BuildFromSorted(keys, values)
+}
+
+// buildSorted builds the subtree of the given sorted pairs and returns its root and height.
+func buildSorted[K, V any](keys []K, values []V, p *Node[K, V]) (*Node[K, V], int) {
+	if len(keys) == 0 {
+		return nil, 0
+	}
+	m := len(keys) / 2
+	n := &Node[K, V]{Key: keys[m], Value: values[m], Parent: p}
+	var lh, rh int
+	n.Children[0], lh = buildSorted(keys[:m], values[:m], n)
+	n.Children[1], rh = buildSorted(keys[m+1:], values[m+1:], n)
+	n.b = int8(rh - lh)
+	if lh > rh {
+		return n, lh + 1
+	}
+	return n, rh + 1
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kcswag/kcgods/internal/dot"
+	"io"
+)
+
+// ToDOT writes the tree as a Graphviz digraph to w, which shows how well it is balanced once rendered,
+// e.g. with "dot -Tsvg".
+// Every node is labelled with its key and its balance factor, i.e. the height of its right subtree minus the height
+// of its left subtree. An only child is paired with a point standing in for its missing sibling.
+func (t *Tree[K, V]) ToDOT(w io.Writer) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph AVLTree {\n")
+	buffer.WriteString("\tnode [shape=circle];\n")
+	id := 0
+	var output func(node *Node[K, V]) int
+	output = func(node *Node[K, V]) int {
+		nodeID := id
+		id++
+		label := dot.Escape(fmt.Sprintf("%v", node.Key)) + fmt.Sprintf(`\n%+d`, node.b)
+		buffer.WriteString(fmt.Sprintf("\tn%d [label=\"%s\"];\n", nodeID, label))
+		if node.Children[0] == nil && node.Children[1] == nil {
+			return nodeID
+		}
+		for _, child := range node.Children {
+			if child == nil {
+				buffer.WriteString(fmt.Sprintf("\tn%d [shape=point];\n", id))
+				buffer.WriteString(fmt.Sprintf("\tn%d -> n%d;\n", nodeID, id))
+				id++
+				continue
+			}
+			buffer.WriteString(fmt.Sprintf("\tn%d -> n%d;\n", nodeID, output(child)))
+		}
+		return nodeID
+	}
+	if t.Root != nil {
+		output(t.Root)
+	}
+	buffer.WriteString("}\n")
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
@@ -10,7 +10,7 @@ import "github.com/kcswag/kcgods/containers"
 //var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
 
 // Iterator holding the iterator's state
-type Iterator[K, V comparable] struct {
+type Iterator[K, V any] struct {
 	tree     *Tree[K, V]
 	node     *Node[K, V]
 	position position
@@ -19,7 +19,7 @@ type Iterator[K, V comparable] struct {
 type position byte
 
 const (
-	begin, between, end position = 0, 1, 2
+	begin, between, end, before position = 0, 1, 2, 3
 )
 
 // Iterator returns a stateful iterator whose elements are key/value pairs.
@@ -27,6 +27,17 @@ func (tree *Tree[K, V]) Iterator() containers.ReverseIteratorWithKey[K, V] {
 	return &Iterator[K, V]{tree: tree, node: nil, position: begin}
 }
 
+// IteratorFrom returns a stateful iterator positioned right before the first element whose key is bigger than
+// (or equal to, if inclusive) the given key, or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the element before it.
+// Like the one returned by Iterator(), the iterator can be seeked further after asserting it to *Iterator.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) IteratorFrom(key K, inclusive bool) containers.ReverseIteratorWithKey[K, V] {
+	iterator := &Iterator[K, V]{tree: tree, node: nil, position: begin}
+	iterator.seek(tree.rangeStart(key, inclusive))
+	return iterator
+}
+
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
 // If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
@@ -38,6 +49,8 @@ func (iterator *Iterator[K, V]) Next() bool {
 		iterator.node = iterator.tree.Left()
 	case between:
 		iterator.node = iterator.node.Next()
+	case before:
+		iterator.position = between
 	}
 
 	if iterator.node == nil {
@@ -58,6 +71,9 @@ func (iterator *Iterator[K, V]) Prev() bool {
 		iterator.node = iterator.tree.Right()
 	case between:
 		iterator.node = iterator.node.Prev()
+	case before:
+		iterator.position = between
+		iterator.node = iterator.node.Prev()
 	}
 
 	if iterator.node == nil {
@@ -67,6 +83,52 @@ func (iterator *Iterator[K, V]) Prev() bool {
 	return true
 }
 
+// Seek moves the iterator right before the first element whose key is equal or bigger than the given key,
+// or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the last element whose key is smaller than the given key.
+// Takes O(log n) time, moving on from there takes amortized constant time per element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) Seek(key K) {
+	iterator.seek(iterator.tree.rangeStart(key, true))
+}
+
+// SeekGE moves the iterator to the first element whose key is equal or bigger than the given key and returns true
+// if there was such an element, whose key and value can then be retrieved by Key() and Value().
+// Otherwise the iterator is moved past the last element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) SeekGE(key K) bool {
+	node, found := iterator.tree.Ceiling(key)
+	if !found {
+		iterator.End()
+		return false
+	}
+	iterator.node, iterator.position = node, between
+	return true
+}
+
+// SeekLE moves the iterator to the last element whose key is equal or smaller than the given key and returns true
+// if there was such an element, whose key and value can then be retrieved by Key() and Value().
+// Otherwise the iterator is moved before the first element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) SeekLE(key K) bool {
+	node, found := iterator.tree.Floor(key)
+	if !found {
+		iterator.Begin()
+		return false
+	}
+	iterator.node, iterator.position = node, between
+	return true
+}
+
+// seek moves the iterator right before the given node or past the last element if the node is nil.
+func (iterator *Iterator[K, V]) seek(node *Node[K, V]) {
+	if node == nil {
+		iterator.End()
+		return
+	}
+	iterator.node, iterator.position = node, before
+}
+
 // Value returns the current element's value.
 // Does not modify the state of the iterator.
 func (iterator *Iterator[K, V]) Value() V {
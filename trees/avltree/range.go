@@ -0,0 +1,139 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "math/bits"
+
+// CountRange returns the number of keys between lo and hi, each bound is included if its inclusive flag is set.
+// Takes O(log n + k) time for k counted keys.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (t *Tree[K, V]) CountRange(lo K, hi K, loInclusive bool, hiInclusive bool) int {
+	count := 0
+	for n := t.rangeStart(lo, loInclusive); t.beforeRangeEnd(n, hi, hiInclusive); n = n.Next() {
+		count++
+	}
+	return count
+}
+
+// RemoveRange removes all keys between lo and hi, each bound is included if its inclusive flag is set,
+// and returns the number of removed keys.
+//
+// The keys are located with a single search. If they make up a large share of the tree, the remaining keys are
+// bulk-loaded into a new balanced tree in O(n), otherwise the nodes are removed one by one while walking the tree
+// in-order, without searching them again. Removing k keys takes O(log n + min(k log n, n)) time.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (t *Tree[K, V]) RemoveRange(lo K, hi K, loInclusive bool, hiInclusive bool) int {
+	start := t.rangeStart(lo, loInclusive)
+	count := 0
+	for n := start; t.beforeRangeEnd(n, hi, hiInclusive); n = n.Next() {
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+
+	if count*bits.Len(uint(t.size)) >= t.size {
+		keys := make([]K, 0, t.size-count)
+		values := make([]V, 0, t.size-count)
+		for n := t.Left(); n != nil; n = n.Next() {
+			if n == start {
+				for i := 0; i < count; i++ {
+					n = n.Next()
+				}
+				if n == nil {
+					break
+				}
+			}
+			keys = append(keys, n.Key)
+			values = append(values, n.Value)
+		}
+		t.BuildFromSorted(keys, values)
+		return count
+	}
+
+	for n, i := start, 0; i < count; i++ {
+		n = t.removeNode(n)
+	}
+	return count
+}
+
+// removeNode removes the node from the tree and rebalances it bottom-up, without searching the node's key.
+// A node with two children takes over the entry of its successor, which is removed instead.
+// Returns the node holding the next key in-order afterwards, or nil if there is none.
+func (t *Tree[K, V]) removeNode(n *Node[K, V]) *Node[K, V] {
+	next := n
+	if n.Children[0] != nil && n.Children[1] != nil {
+		s := n.Children[1]
+		for s.Children[0] != nil {
+			s = s.Children[0]
+		}
+		n.Key, n.Value = s.Key, s.Value
+		n = s
+	} else {
+		next = n.Next()
+	}
+	t.size--
+
+	// The node has at most one child, which takes its place
+	child := n.Children[0]
+	if child == nil {
+		child = n.Children[1]
+	}
+	p := n.Parent
+	if child != nil {
+		child.Parent = p
+	}
+	if p == nil {
+		t.Root = child
+		return next
+	}
+	a := 0
+	if p.Children[1] == n {
+		a = 1
+	}
+	p.Children[a] = child
+
+	// Walk up while the subtree on side a of p has become shorter, same as remove does on its way back
+	for p != nil {
+		g := p.Parent
+		qp, ga := &t.Root, 0
+		if g != nil {
+			if g.Children[1] == p {
+				ga = 1
+			}
+			qp = &g.Children[ga]
+		}
+		if !removeFix(int8(1-2*a), qp) {
+			break
+		}
+		p, a = g, ga
+	}
+	return next
+}
+
+// rangeStart returns the node with the smallest key greater than (or equal to, if inclusive) lo, or nil if there is none.
+func (t *Tree[K, V]) rangeStart(lo K, inclusive bool) *Node[K, V] {
+	var start *Node[K, V]
+	n := t.Root
+	for n != nil {
+		c := t.Comparator(lo, n.Key)
+		if c < 0 || (c == 0 && inclusive) {
+			start = n
+			n = n.Children[0]
+		} else {
+			n = n.Children[1]
+		}
+	}
+	return start
+}
+
+// beforeRangeEnd returns true if the node's key is smaller than (or equal to, if inclusive) hi.
+func (t *Tree[K, V]) beforeRangeEnd(n *Node[K, V], hi K, inclusive bool) bool {
+	if n == nil {
+		return false
+	}
+	c := t.Comparator(n.Key, hi)
+	return c < 0 || (c == 0 && inclusive)
+}
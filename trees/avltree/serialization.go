@@ -6,6 +6,7 @@ package avltree
 
 import (
 	"encoding/json"
+	"github.com/kcswag/kcgods/utils"
 )
 
 // Assert Serialization implementation
@@ -14,25 +15,69 @@ import (
 
 // ToJSON outputs the JSON representation of the tree.
 func (tree *Tree[K, V]) ToJSON() ([]byte, error) {
-	elements := make(map[K]V)
+	elements := make(map[string]V)
 	it := tree.Iterator()
 	for it.Next() {
-		elements[it.Key()] = it.Value()
+		key, err := utils.MarshalKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		elements[key] = it.Value()
 	}
 	return json.Marshal(&elements)
 }
 
 // FromJSON populates the tree from the input JSON representation.
 func (tree *Tree[K, V]) FromJSON(data []byte) error {
-	elements := make(map[K]V)
-	err := json.Unmarshal(data, &elements)
-	if err == nil {
-		tree.Clear()
-		for key, value := range elements {
-			tree.Put(key, value)
+	elements := make(map[string]V)
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	keys := make([]K, 0, len(elements))
+	values := make([]V, 0, len(elements))
+	for name, value := range elements {
+		key, err := utils.UnmarshalKey[K](name)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	tree.Clear()
+	for i, key := range keys {
+		tree.Put(key, values[i])
+	}
+	return nil
+}
+
+// structureNode is the JSON representation of a node that keeps the shape of the tree
+type structureNode[K, V any] struct {
+	Key     K                    `json:"key"`
+	Value   V                    `json:"value"`
+	Balance int8                 `json:"balance"`
+	Left    *structureNode[K, V] `json:"left,omitempty"`
+	Right   *structureNode[K, V] `json:"right,omitempty"`
+}
+
+// ToStructureJSON outputs the JSON representation of the shape of the tree for debugging.
+// Every node is an object with its key, value, balance factor (height of the right subtree minus height of the left
+// subtree) and its left and right children, missing children are omitted. An empty tree is represented by null.
+// Unlike ToJSON, the output is not meant to be read back with FromJSON.
+func (tree *Tree[K, V]) ToStructureJSON() ([]byte, error) {
+	var structure func(node *Node[K, V]) *structureNode[K, V]
+	structure = func(node *Node[K, V]) *structureNode[K, V] {
+		if node == nil {
+			return nil
+		}
+		return &structureNode[K, V]{
+			Key:     node.Key,
+			Value:   node.Value,
+			Balance: node.b,
+			Left:    structure(node.Children[0]),
+			Right:   structure(node.Children[1]),
 		}
 	}
-	return err
+	return json.Marshal(structure(tree.Root))
 }
 
 // UnmarshalJSON @implements json.Unmarshaler
@@ -0,0 +1,64 @@
+// Copyright (c) 2017, Benjamin Scher Purcell. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "fmt"
+
+// Validate checks the invariants of the tree and returns an error describing the first violation found, or nil.
+//
+// It checks that keys are ordered under the comparator, that parent pointers are consistent,
+// that every balance factor equals the height difference of the node's subtrees and lies within [-1, 1],
+// and that the cached size matches the number of nodes.
+// Useful to detect corruption, e.g. after a node's Key has been changed through the exported fields.
+func (t *Tree[K, V]) Validate() error {
+	if t.Root != nil && t.Root.Parent != nil {
+		return fmt.Errorf("avltree: root %v has parent %v", t.Root, t.Root.Parent)
+	}
+	_, count, err := t.validate(t.Root, nil, nil)
+	if err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("avltree: size is %d but tree holds %d nodes", t.size, count)
+	}
+	return nil
+}
+
+// validate checks the subtree whose keys must lie strictly between the keys of the lower and upper nodes (if any)
+// and returns its height and number of nodes.
+func (t *Tree[K, V]) validate(n *Node[K, V], lower *Node[K, V], upper *Node[K, V]) (height int, count int, err error) {
+	if n == nil {
+		return 0, 0, nil
+	}
+	if lower != nil && t.Comparator(n.Key, lower.Key) <= 0 {
+		return 0, 0, fmt.Errorf("avltree: node %v is not greater than its ancestor %v", n, lower)
+	}
+	if upper != nil && t.Comparator(n.Key, upper.Key) >= 0 {
+		return 0, 0, fmt.Errorf("avltree: node %v is not smaller than its ancestor %v", n, upper)
+	}
+	for _, c := range n.Children {
+		if c != nil && c.Parent != n {
+			return 0, 0, fmt.Errorf("avltree: node %v has parent %v, expected %v", c, c.Parent, n)
+		}
+	}
+	lh, lc, err := t.validate(n.Children[0], lower, n)
+	if err != nil {
+		return 0, 0, err
+	}
+	rh, rc, err := t.validate(n.Children[1], n, upper)
+	if err != nil {
+		return 0, 0, err
+	}
+	if int(n.b) != rh-lh {
+		return 0, 0, fmt.Errorf("avltree: node %v has balance factor %d, expected %d", n, n.b, rh-lh)
+	}
+	if n.b < -1 || n.b > 1 {
+		return 0, 0, fmt.Errorf("avltree: node %v is unbalanced with balance factor %d", n, n.b)
+	}
+	if lh > rh {
+		return lh + 1, lc + rc + 1, nil
+	}
+	return rh + 1, lc + rc + 1, nil
+}
@@ -5,6 +5,7 @@
 // Package binaryheap implements a binary heap backed by array list.
 //
 // Comparator defines this heap as either min or max heap.
+// A stable heap pops elements that are equal under the comparator in the order they were pushed.
 //
 // Structure is not thread safe.
 //
@@ -14,17 +15,24 @@ package binaryheap
 import (
 	"fmt"
 	"github.com/kcswag/kcgods/lists/arraylist"
+	"github.com/kcswag/kcgods/trees"
 	"github.com/kcswag/kcgods/utils"
+	"sort"
 	"strings"
 )
 
 // Assert Tree implementation
 //var _ trees.Tree = (*Heap)(nil)
+//var _ trees.Heap[int] = (*Heap[int])(nil)
 
 // Heap holds elements in an array-list
 type Heap[E any] struct {
 	list       *arraylist.List[E]
 	Comparator utils.Comparator
+	indexer    func(value E, index int)
+	stable     bool
+	sequences  []uint64 // insertion sequence of the element at the same index, only kept by stable heaps
+	sequence   uint64   // next insertion sequence
 }
 
 // NewWith instantiates a new empty heap tree with the custom comparator.
@@ -32,6 +40,28 @@ func NewWith[E any](comparator utils.Comparator) *Heap[E] {
 	return &Heap[E]{list: arraylist.New[E](), Comparator: comparator}
 }
 
+// NewStableWith instantiates a new empty heap with the custom comparator, which breaks ties by insertion order,
+// i.e. elements that are equal under the comparator are popped first in, first out.
+func NewStableWith[E any](comparator utils.Comparator) *Heap[E] {
+	return &Heap[E]{list: arraylist.New[E](), Comparator: comparator, stable: true}
+}
+
+// NewFrom instantiates a new heap with the custom comparator from the values in O(n).
+// The heap takes ownership of the slice and reorders it in place as its backing array, without copying the values,
+// i.e. the slice must not be used afterwards.
+func NewFrom[E any](values []E, comparator utils.Comparator) *Heap[E] {
+	heap := &Heap[E]{list: arraylist.NewFromSlice(values), Comparator: comparator}
+	heap.heapify()
+	return heap
+}
+
+// NewWithIndexer instantiates a new empty heap with the custom comparator, which reports the index of every element
+// to the indexer whenever the element is added or moved, and -1 when it is removed.
+// This allows to keep track of elements, e.g. to Fix or RemoveAt them after their priority has changed.
+func NewWithIndexer[E any](comparator utils.Comparator, indexer func(value E, index int)) *Heap[E] {
+	return &Heap[E]{list: arraylist.New[E](), Comparator: comparator, indexer: indexer}
+}
+
 // NewWithIntComparator instantiates a new empty heap with the IntComparator, i.e. elements are of type int.
 func NewWithIntComparator() *Heap[int] {
 	return &Heap[int]{list: arraylist.New[int](), Comparator: utils.IntComparator}
@@ -45,17 +75,13 @@ func NewWithStringComparator() *Heap[string] {
 // Push adds a value onto the heap and bubbles it up accordingly.
 func (heap *Heap[E]) Push(values ...E) {
 	if len(values) == 1 {
-		heap.list.Add(values[0])
+		heap.add(values[0])
 		heap.bubbleUp()
 	} else {
-		// Reference: https://en.wikipedia.org/wiki/Binary_heap#Building_a_heap
 		for _, value := range values {
-			heap.list.Add(value)
-		}
-		size := heap.list.Size()/2 + 1
-		for i := size; i >= 0; i-- {
-			heap.bubbleDownIndex(i)
+			heap.add(value)
 		}
+		heap.heapify()
 	}
 }
 
@@ -66,13 +92,86 @@ func (heap *Heap[E]) Pop() (value E, ok bool) {
 	if !ok {
 		return
 	}
-	lastIndex := heap.list.Size() - 1
-	heap.list.Swap(0, lastIndex)
-	heap.list.Remove(lastIndex)
+	heap.swap(0, heap.list.Size()-1)
+	heap.removeLast()
+	heap.removed(value)
 	heap.bubbleDown()
 	return
 }
 
+// PushPop adds a value onto the heap and then removes the top element and returns it in O(log n),
+// which is faster than a Push followed by a Pop, e.g. to keep the k largest elements of a stream in a min-heap.
+// Returns the value itself without touching the heap if it would be the top element.
+func (heap *Heap[E]) PushPop(value E) E {
+	top, ok := heap.list.Get(0)
+	if !ok {
+		return value
+	}
+	// A stable heap pops the top element first if it is equal to the value, since it has been pushed earlier
+	if result := heap.Comparator(value, top); result < 0 || result == 0 && !heap.stable {
+		return value
+	}
+	return heap.replaceTop(value)
+}
+
+// Replace removes the top element and returns it, and then adds the value onto the heap in O(log n),
+// which is faster than a Pop followed by a Push. The value is added even if the heap was empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to remove.
+func (heap *Heap[E]) Replace(value E) (top E, ok bool) {
+	if heap.list.Empty() {
+		heap.Push(value)
+		return top, false
+	}
+	return heap.replaceTop(value), true
+}
+
+// Meld moves all elements of the other heap into the heap in O(n+m) and leaves the other heap empty.
+// The other heap must order its elements the same way.
+// Equal elements of a stable heap are popped before those of the other heap, and the elements of another stable
+// heap keep their order among each other, which takes O(m log m) to restore.
+func (heap *Heap[E]) Meld(other trees.Heap[E]) {
+	if other == trees.Heap[E](heap) {
+		return
+	}
+	var values []E
+	if heap2, ok := other.(*Heap[E]); ok && heap.stable && heap2.stable {
+		values = heap2.insertionOrder()
+	} else if ok {
+		values = heap2.list.Values()
+	} else {
+		values = other.Values()
+	}
+	if len(values) > 0 {
+		other.Clear()
+		heap.Push(values...)
+	}
+}
+
+// Fix restores the heap order after the element at the index has changed its priority in O(log n).
+// Does not do anything if the index is out of bounds.
+func (heap *Heap[E]) Fix(index int) {
+	if !heap.withinRange(index) {
+		return
+	}
+	if heap.bubbleUpIndex(index) == index {
+		heap.bubbleDownIndex(index)
+	}
+}
+
+// RemoveAt removes the element at the index from the heap in O(log n) and returns it.
+// Second return parameter is true, unless the index was out of bounds and there was nothing to remove.
+func (heap *Heap[E]) RemoveAt(index int) (value E, ok bool) {
+	value, ok = heap.list.Get(index)
+	if !ok {
+		return
+	}
+	heap.swap(index, heap.list.Size()-1)
+	heap.removeLast()
+	heap.removed(value)
+	heap.Fix(index)
+	return
+}
+
 // Peek returns top element on the heap without removing it, or nil if heap is empty.
 // Second return parameter is true, unless the heap was empty and there was nothing to peek.
 func (heap *Heap[E]) Peek() (value E, ok bool) {
@@ -91,7 +190,19 @@ func (heap *Heap[E]) Size() int {
 
 // Clear removes all elements from the heap.
 func (heap *Heap[E]) Clear() {
+	if heap.indexer != nil {
+		for _, value := range heap.list.Values() {
+			heap.removed(value)
+		}
+	}
 	heap.list.Clear()
+	heap.sequences = nil
+	heap.sequence = 0
+}
+
+// Sorted returns all elements in the order they would be popped in O(n log n), without removing them.
+func (heap *Heap[E]) Sorted() []E {
+	return heap.valuesBy(func(i, j int) bool { return heap.compare(i, j) < 0 })
 }
 
 // Values returns all elements in the heap.
@@ -127,15 +238,11 @@ func (heap *Heap[E]) bubbleDownIndex(index int) {
 	for leftIndex := index<<1 + 1; leftIndex < size; leftIndex = index<<1 + 1 {
 		rightIndex := index<<1 + 2
 		smallerIndex := leftIndex
-		leftValue, _ := heap.list.Get(leftIndex)
-		rightValue, _ := heap.list.Get(rightIndex)
-		if rightIndex < size && heap.Comparator(leftValue, rightValue) > 0 {
+		if rightIndex < size && heap.compare(leftIndex, rightIndex) > 0 {
 			smallerIndex = rightIndex
 		}
-		indexValue, _ := heap.list.Get(index)
-		smallerValue, _ := heap.list.Get(smallerIndex)
-		if heap.Comparator(indexValue, smallerValue) > 0 {
-			heap.list.Swap(index, smallerIndex)
+		if heap.compare(index, smallerIndex) > 0 {
+			heap.swap(index, smallerIndex)
 		} else {
 			break
 		}
@@ -147,16 +254,116 @@ func (heap *Heap[E]) bubbleDownIndex(index int) {
 // element (i.e. last element in the list) in its correct place so that
 // the heap maintains the min/max-heap order property.
 func (heap *Heap[E]) bubbleUp() {
-	index := heap.list.Size() - 1
+	heap.bubbleUpIndex(heap.list.Size() - 1)
+}
+
+// Performs the "bubble up" operation. This is to place the element that is at the index
+// of the heap in its correct place so that the heap maintains the min/max-heap order property.
+// Returns the index the element has been moved to.
+func (heap *Heap[E]) bubbleUpIndex(index int) int {
 	for parentIndex := (index - 1) >> 1; index > 0; parentIndex = (index - 1) >> 1 {
-		indexValue, _ := heap.list.Get(index)
-		parentValue, _ := heap.list.Get(parentIndex)
-		if heap.Comparator(parentValue, indexValue) <= 0 {
+		if heap.compare(parentIndex, index) <= 0 {
 			break
 		}
-		heap.list.Swap(index, parentIndex)
+		heap.swap(index, parentIndex)
 		index = parentIndex
 	}
+	return index
+}
+
+// Builds the heap from the elements in arbitrary order in O(n).
+// Reference: https://en.wikipedia.org/wiki/Binary_heap#Building_a_heap
+func (heap *Heap[E]) heapify() {
+	for i := heap.list.Size()/2 - 1; i >= 0; i-- {
+		heap.bubbleDownIndex(i)
+	}
+}
+
+// compare compares the elements at the indices, ties of a stable heap are broken by their insertion sequence.
+func (heap *Heap[E]) compare(i, j int) int {
+	a, _ := heap.list.Get(i)
+	b, _ := heap.list.Get(j)
+	if result := heap.Comparator(a, b); result != 0 || !heap.stable {
+		return result
+	}
+	return utils.UInt64Comparator(heap.sequences[i], heap.sequences[j])
+}
+
+// add appends the value to the list and assigns it the next insertion sequence.
+func (heap *Heap[E]) add(value E) {
+	heap.list.Add(value)
+	if heap.stable {
+		heap.sequences = append(heap.sequences, heap.sequence)
+		heap.sequence++
+	}
+	heap.index(heap.list.Size() - 1)
+}
+
+// replaceTop replaces the top element with the value, bubbles it down and returns the former top element.
+func (heap *Heap[E]) replaceTop(value E) E {
+	top, _ := heap.list.Get(0)
+	heap.list.Set(0, value)
+	if heap.stable {
+		heap.sequences[0] = heap.sequence
+		heap.sequence++
+	}
+	heap.removed(top)
+	heap.index(0)
+	heap.bubbleDown()
+	return top
+}
+
+// removeLast removes the last element of the list along with its insertion sequence.
+func (heap *Heap[E]) removeLast() {
+	lastIndex := heap.list.Size() - 1
+	heap.list.Remove(lastIndex)
+	if heap.stable {
+		heap.sequences = heap.sequences[:lastIndex]
+	}
+}
+
+// insertionOrder returns the elements of a stable heap in the order they were pushed.
+func (heap *Heap[E]) insertionOrder() []E {
+	return heap.valuesBy(func(i, j int) bool { return heap.sequences[i] < heap.sequences[j] })
+}
+
+// valuesBy returns the elements sorted by the less function of their indices.
+func (heap *Heap[E]) valuesBy(less func(i, j int) bool) []E {
+	indices := make([]int, heap.list.Size(), heap.list.Size())
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(i, j int) bool { return less(indices[i], indices[j]) })
+	values := make([]E, len(indices), len(indices))
+	for i, index := range indices {
+		values[i], _ = heap.list.Get(index)
+	}
+	return values
+}
+
+// swap swaps the elements at the indices and reports their new indices to the indexer.
+func (heap *Heap[E]) swap(i, j int) {
+	heap.list.Swap(i, j)
+	if heap.stable {
+		heap.sequences[i], heap.sequences[j] = heap.sequences[j], heap.sequences[i]
+	}
+	heap.index(i)
+	heap.index(j)
+}
+
+// index reports the index of the element at the index to the indexer.
+func (heap *Heap[E]) index(index int) {
+	if heap.indexer != nil {
+		value, _ := heap.list.Get(index)
+		heap.indexer(value, index)
+	}
+}
+
+// removed reports the removal of the element to the indexer.
+func (heap *Heap[E]) removed(value E) {
+	if heap.indexer != nil {
+		heap.indexer(value, -1)
+	}
 }
 
 // Check that the index is within bounds of the list
@@ -6,6 +6,8 @@ package binaryheap
 
 import (
 	"encoding/json"
+	"fmt"
+	"github.com/kcswag/kcgods/utils"
 	"math/rand"
 	"strings"
 	"testing"
@@ -100,6 +102,375 @@ func TestBinaryHeapRandom(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapNewFrom(t *testing.T) {
+	values := []int{5, 3, 8, 1, 9, 2, 7, 3}
+	heap := NewFrom[int](values, utils.IntComparator)
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	// The slice has been heapified in place
+	if actualValue, expectedValue := fmt.Sprint(values), fmt.Sprint(heap.list.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := heap.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	for _, expectedValue := range []int{1, 2, 3, 3, 5, 7, 8, 9} {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	heap = NewFrom[int](nil, utils.IntComparator)
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	heap = NewFrom[int]([]int{1}, utils.IntComparator)
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestBinaryHeapPushPop(t *testing.T) {
+	heap := NewWithIntComparator()
+	if actualValue := heap.PushPop(3); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	heap.Push(2, 4, 6)
+	if actualValue := heap.PushPop(1); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue := heap.PushPop(2); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := heap.PushPop(5); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := heap.Sorted(); len(actualValue) != 3 || actualValue[0] != 4 || actualValue[1] != 5 || actualValue[2] != 6 {
+		t.Errorf("Got %v expected %v", actualValue, "[4,5,6]")
+	}
+
+	stable := NewStableWith[job](byPriority)
+	stable.Push(job{1, 0}, job{2, 1})
+	if actualValue := stable.PushPop(job{1, 2}); actualValue.ID != 0 {
+		t.Errorf("Got %v expected %v", actualValue.ID, 0)
+	}
+	if actualValue := stable.PushPop(job{0, 3}); actualValue.ID != 3 {
+		t.Errorf("Got %v expected %v", actualValue.ID, 3)
+	}
+	for _, expectedValue := range []int{2, 1} {
+		if actualValue, ok := stable.Pop(); actualValue.ID != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue.ID, expectedValue)
+		}
+	}
+}
+
+func TestBinaryHeapReplace(t *testing.T) {
+	heap := NewWithIntComparator()
+	if actualValue, ok := heap.Replace(3); actualValue != 0 || ok {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := heap.Size(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	heap.Push(5, 7)
+	if actualValue, ok := heap.Replace(1); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.Replace(9); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue := heap.Sorted(); len(actualValue) != 3 || actualValue[0] != 5 || actualValue[1] != 7 || actualValue[2] != 9 {
+		t.Errorf("Got %v expected %v", actualValue, "[5,7,9]")
+	}
+}
+
+func TestBinaryHeapPushPopRandom(t *testing.T) {
+	// Keep the 10 largest values in a min-heap
+	heap := NewWithIntComparator()
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = rand.Intn(500)
+		if heap.Size() < 10 {
+			heap.Push(values[i])
+		} else {
+			heap.PushPop(values[i])
+		}
+		if err := heap.Validate(); err != nil {
+			t.Fatalf("Got %v expected %v", err, nil)
+		}
+	}
+	utils.Sort(values, utils.IntComparator)
+	for index, value := range heap.Sorted() {
+		if expectedValue := values[len(values)-10+index]; value != expectedValue {
+			t.Errorf("Got %v expected %v", value, expectedValue)
+		}
+	}
+}
+
+func TestBinaryHeapSorted(t *testing.T) {
+	heap := NewWithIntComparator()
+	if actualValue := heap.Sorted(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	heap.Push(3, 1, 4, 1, 5, 9, 2, 6)
+	expected := []int{1, 1, 2, 3, 4, 5, 6, 9}
+	for index, value := range heap.Sorted() {
+		if value != expected[index] {
+			t.Errorf("Got %v expected %v", value, expected[index])
+		}
+	}
+	if actualValue := heap.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	for _, expectedValue := range expected {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestBinaryHeapValidate(t *testing.T) {
+	heap := NewWithIntComparator()
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	for _, value := range []int{5, 3, 8, 1, 9, 2, 7} {
+		heap.Push(value)
+	}
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+
+	heap.list.Swap(0, heap.list.Size()-1)
+	if err := heap.Validate(); err == nil || !strings.Contains(err.Error(), "is ordered before its parent") {
+		t.Errorf("Got %v expected an error about the heap property", err)
+	}
+}
+
+func TestBinaryHeapFix(t *testing.T) {
+	type item struct {
+		priority int
+		index    int
+	}
+	comparator := func(a, b interface{}) int {
+		return utils.IntComparator(a.(*item).priority, b.(*item).priority)
+	}
+	heap := NewWithIndexer[*item](comparator, func(value *item, index int) {
+		value.index = index
+	})
+	items := make([]*item, 20)
+	for i := range items {
+		items[i] = &item{priority: i}
+		heap.Push(items[i])
+	}
+	heap.Push(&item{priority: 20}, &item{priority: 21})
+
+	assertIndices := func() {
+		for index, value := range heap.list.Values() {
+			if value.index != index {
+				t.Errorf("Got %v expected %v", value.index, index)
+			}
+		}
+		if err := heap.Validate(); err != nil {
+			t.Errorf("Got %v expected %v", err, nil)
+		}
+	}
+	assertIndices()
+
+	items[15].priority = -1
+	heap.Fix(items[15].index)
+	assertIndices()
+	if actualValue, _ := heap.Peek(); actualValue != items[15] {
+		t.Errorf("Got %v expected %v", actualValue, items[15])
+	}
+
+	items[15].priority = 100
+	heap.Fix(items[15].index)
+	heap.Fix(-1)
+	heap.Fix(heap.Size())
+	assertIndices()
+
+	if actualValue, ok := heap.RemoveAt(items[7].index); actualValue != items[7] || !ok {
+		t.Errorf("Got %v expected %v", actualValue, items[7])
+	}
+	if actualValue := items[7].index; actualValue != -1 {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+	if _, ok := heap.RemoveAt(heap.Size()); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	assertIndices()
+
+	popped, _ := heap.Pop()
+	if actualValue := popped.index; actualValue != -1 || popped != items[0] {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+	assertIndices()
+
+	if actualValue, ok := heap.Replace(&item{priority: 50}); actualValue != items[1] || actualValue.index != -1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, items[1])
+	}
+	assertIndices()
+	if actualValue := heap.PushPop(&item{priority: 60}); actualValue != items[2] || actualValue.index != -1 {
+		t.Errorf("Got %v expected %v", actualValue, items[2])
+	}
+	assertIndices()
+
+	heap.Clear()
+	if actualValue := items[3].index; actualValue != -1 {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+}
+
+func TestBinaryHeapRemoveAtRandom(t *testing.T) {
+	heap := NewWithIntComparator()
+	for i := 0; i < 1000; i++ {
+		heap.Push(rand.Intn(100))
+	}
+	for !heap.Empty() {
+		heap.RemoveAt(rand.Intn(heap.Size()))
+		if err := heap.Validate(); err != nil {
+			t.Fatalf("Got %v expected %v", err, nil)
+		}
+	}
+}
+
+// job is ordered by its priority only, its id tells the order it was pushed in.
+type job struct {
+	Priority int `json:"priority"`
+	ID       int `json:"id"`
+}
+
+func byPriority(a, b interface{}) int {
+	return utils.IntComparator(a.(job).Priority, b.(job).Priority)
+}
+
+func TestBinaryHeapStable(t *testing.T) {
+	heap := NewStableWith[job](byPriority)
+	jobs := []job{{2, 0}, {1, 1}, {2, 2}, {1, 3}, {2, 4}, {1, 5}}
+	for _, job := range jobs {
+		heap.Push(job)
+	}
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	for _, expectedValue := range []int{1, 3, 5, 0, 2, 4} {
+		if actualValue, ok := heap.Pop(); actualValue.ID != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue.ID, expectedValue)
+		}
+	}
+
+	heap.Push(jobs...)
+	if actualValue, ok := heap.Peek(); actualValue.ID != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue.ID, 1)
+	}
+	heap.Clear()
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestBinaryHeapStableRandom(t *testing.T) {
+	heap := NewStableWith[job](byPriority)
+	id := 0
+	for i := 0; i < 100; i++ {
+		for n := rand.Intn(100); n > 0; n-- {
+			heap.Push(job{rand.Intn(10), id})
+			id++
+		}
+		values := make([]job, rand.Intn(10))
+		for n := range values {
+			values[n] = job{rand.Intn(10), id}
+			id++
+		}
+		heap.Push(values...)
+		if heap.Size() > 0 {
+			heap.RemoveAt(rand.Intn(heap.Size()))
+		}
+		if err := heap.Validate(); err != nil {
+			t.Fatalf("Got %v expected %v", err, nil)
+		}
+	}
+
+	prev, _ := heap.Pop()
+	for !heap.Empty() {
+		curr, _ := heap.Pop()
+		if prev.Priority > curr.Priority || prev.Priority == curr.Priority && prev.ID > curr.ID {
+			t.Fatalf("Got %v after %v", curr, prev)
+		}
+		prev = curr
+	}
+}
+
+func TestBinaryHeapStableIterator(t *testing.T) {
+	heap := NewStableWith[job](byPriority)
+	heap.Push(job{1, 0}, job{2, 1}, job{2, 2}, job{2, 3}, job{2, 4}, job{2, 5}, job{2, 6})
+	expected := []int{0, 1, 2, 3, 4, 5, 6}
+	for it := heap.Iterator(); it.Next(); {
+		if actualValue := it.Value().ID; actualValue != expected[it.Index()] {
+			t.Errorf("Got %v expected %v", actualValue, expected[it.Index()])
+		}
+	}
+	for index, value := range heap.Values() {
+		if value.ID != expected[index] {
+			t.Errorf("Got %v expected %v", value.ID, expected[index])
+		}
+	}
+}
+
+func TestBinaryHeapStableMeld(t *testing.T) {
+	heap := NewStableWith[job](byPriority)
+	heap.Push(job{1, 0}, job{2, 1})
+	other := NewStableWith[job](byPriority)
+	for _, job := range []job{{1, 2}, {2, 3}, {1, 4}, {2, 5}, {1, 6}} {
+		other.Push(job)
+	}
+	heap.Meld(other)
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	for _, expectedValue := range []int{0, 2, 4, 6, 1, 3, 5} {
+		if actualValue, ok := heap.Pop(); actualValue.ID != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue.ID, expectedValue)
+		}
+	}
+}
+
+func TestBinaryHeapStableSerialization(t *testing.T) {
+	heap := NewStableWith[job](byPriority)
+	for _, job := range []job{{2, 0}, {1, 1}, {2, 2}, {1, 3}, {2, 4}} {
+		heap.Push(job)
+	}
+	heap.Pop()
+
+	bytes, err := heap.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expectedJSON := `[{"priority":1,"id":3},{"priority":2,"id":0},{"priority":2,"id":2},{"priority":2,"id":4}]`
+	if actualValue := string(bytes); actualValue != expectedJSON {
+		t.Errorf("Got %v expected %v", actualValue, expectedJSON)
+	}
+
+	newHeap := NewStableWith[job](byPriority)
+	if err := json.Unmarshal(bytes, newHeap); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	newHeap.Push(job{1, 5}, job{2, 6})
+	for _, expectedValue := range []int{3, 5, 0, 2, 4, 6} {
+		if actualValue, ok := newHeap.Pop(); actualValue.ID != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue.ID, expectedValue)
+		}
+	}
+}
+
 func TestBinaryHeapIteratorOnEmpty(t *testing.T) {
 	heap := NewWithIntComparator()
 	it := heap.Iterator()
@@ -410,6 +781,53 @@ func TestBinaryHeapSerialization(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapToDOT(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(4)
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+	var builder strings.Builder
+	if err := heap.ToDOT(&builder); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `digraph BinaryHeap {
+	node [shape=circle];
+	n0 [label="1\n[0]"];
+	n1 [label="2\n[1]"];
+	n0 -> n1;
+	n2 [label="3\n[2]"];
+	n0 -> n2;
+	n3 [label="4\n[3]"];
+	n1 -> n3;
+}
+`
+	if actualValue := builder.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+}
+
+func TestBinaryHeapToStructureJSON(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(4)
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+	structure, err := heap.ToStructureJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `{"index":0,"value":1,"left":{"index":1,"value":2,"left":{"index":3,"value":4}},"right":{"index":2,"value":3}}`
+	if actualValue := string(structure); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	structure, _ = NewWithIntComparator().ToStructureJSON()
+	if actualValue, expectedValue := string(structure), "null"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestBTreeString(t *testing.T) {
 	c := NewWithIntComparator()
 	c.Push(1)
@@ -518,3 +936,31 @@ func BenchmarkBinaryHeapPush100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkPush(b, heap, size)
 }
+
+func BenchmarkBinaryHeapNewFrom10000(b *testing.B) {
+	b.StopTimer()
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = rand.Intn(10000 * 3)
+	}
+	input := make([]int, len(values))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(input, values) // the heap takes ownership of the slice
+		b.StartTimer()
+		NewFrom[int](input, utils.IntComparator)
+	}
+}
+
+func BenchmarkBinaryHeapPushPop10000(b *testing.B) {
+	b.StopTimer()
+	heap := NewWithIntComparator()
+	for n := 0; n < 10000; n++ {
+		heap.Push(rand.Intn(10000 * 3))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		heap.PushPop(rand.Intn(10000 * 3))
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binaryheap
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kcswag/kcgods/internal/dot"
+	"io"
+)
+
+// ToDOT writes the implicit tree of the heap as a Graphviz digraph to w.
+// Every node is labelled with its value and its position within the backing array,
+// the children of the element at index i are the elements at indexes 2i+1 and 2i+2.
+func (heap *Heap[E]) ToDOT(w io.Writer) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph BinaryHeap {\n")
+	buffer.WriteString("\tnode [shape=circle];\n")
+	size := heap.list.Size()
+	for index := 0; index < size; index++ {
+		value, _ := heap.list.Get(index)
+		label := dot.Escape(fmt.Sprintf("%v", value)) + fmt.Sprintf(`\n[%d]`, index)
+		buffer.WriteString(fmt.Sprintf("\tn%d [label=\"%s\"];\n", index, label))
+		if index > 0 {
+			buffer.WriteString(fmt.Sprintf("\tn%d -> n%d;\n", (index-1)>>1, index))
+		}
+	}
+	buffer.WriteString("}\n")
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
@@ -4,6 +4,8 @@
 
 package binaryheap
 
+import "sort"
+
 // Assert Iterator implementation
 //var _ containers.ReverseIteratorWithIndex = (*Iterator)(nil)
 
@@ -46,15 +48,13 @@ func (iterator *Iterator[E]) Value() E {
 	if end > iterator.heap.Size() {
 		end = iterator.heap.Size()
 	}
-	tmpHeap := NewWith[E](iterator.heap.Comparator)
+	// Elements of the same level are ordered among each other, ties of a stable heap by their insertion order
+	indices := make([]int, 0, end-start)
 	for n := start; n < end; n++ {
-		value, _ := iterator.heap.list.Get(n)
-		tmpHeap.Push(value)
-	}
-	for n := 0; n < iterator.index-start; n++ {
-		tmpHeap.Pop()
+		indices = append(indices, n)
 	}
-	value, _ := tmpHeap.Pop()
+	sort.Slice(indices, func(i, j int) bool { return iterator.heap.compare(indices[i], indices[j]) < 0 })
+	value, _ := iterator.heap.list.Get(indices[iterator.index-start])
 	return value
 }
 
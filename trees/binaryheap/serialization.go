@@ -4,20 +4,67 @@
 
 package binaryheap
 
+import (
+	"encoding/json"
+)
+
 // Assert Serialization implementation
 //var _ containers.JSONSerializer = (*Heap)(nil)
 //var _ containers.JSONDeserializer = (*Heap)(nil)
 
 // ToJSON outputs the JSON representation of the heap.
+// A stable heap outputs its elements in the order they would be popped, so that FromJSON restores their order.
 func (heap *Heap[E]) ToJSON() ([]byte, error) {
+	if heap.stable {
+		return json.Marshal(heap.Sorted())
+	}
 	return heap.list.ToJSON()
 }
 
 // FromJSON populates the heap from the input JSON representation.
+// A stable heap is cleared and the elements are pushed in the order of the input, which breaks their ties.
 func (heap *Heap[E]) FromJSON(data []byte) error {
+	if heap.stable {
+		var values []E
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		heap.Clear()
+		heap.Push(values...)
+		return nil
+	}
 	return heap.list.FromJSON(data)
 }
 
+// structureNode is the JSON representation of an element that keeps the shape of the heap
+type structureNode[E any] struct {
+	Index int               `json:"index"`
+	Value E                 `json:"value"`
+	Left  *structureNode[E] `json:"left,omitempty"`
+	Right *structureNode[E] `json:"right,omitempty"`
+}
+
+// ToStructureJSON outputs the JSON representation of the shape of the heap for debugging.
+// Every element is an object with its index within the backing array, its value and its left and right children,
+// missing children are omitted. An empty heap is represented by null.
+// Unlike ToJSON, the output is not meant to be read back with FromJSON.
+func (heap *Heap[E]) ToStructureJSON() ([]byte, error) {
+	var structure func(index int) *structureNode[E]
+	structure = func(index int) *structureNode[E] {
+		value, ok := heap.list.Get(index)
+		if !ok {
+			return nil
+		}
+		return &structureNode[E]{
+			Index: index,
+			Value: value,
+			Left:  structure(2*index + 1),
+			Right: structure(2*index + 2),
+		}
+	}
+	return json.Marshal(structure(0))
+}
+
 // UnmarshalJSON @implements json.Unmarshaler
 func (heap *Heap[E]) UnmarshalJSON(bytes []byte) error {
 	return heap.FromJSON(bytes)
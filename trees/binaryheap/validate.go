@@ -0,0 +1,24 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binaryheap
+
+import "fmt"
+
+// Validate checks the heap property and returns an error describing the first violation found, or nil.
+//
+// Every element must be equal or bigger than its parent under the comparator (smaller for max-heaps,
+// i.e. heaps with an inverted comparator). Equal elements of a stable heap must have been pushed after their parent.
+func (heap *Heap[E]) Validate() error {
+	size := heap.list.Size()
+	for index := 1; index < size; index++ {
+		parentIndex := (index - 1) >> 1
+		if heap.compare(parentIndex, index) > 0 {
+			value, _ := heap.list.Get(index)
+			parentValue, _ := heap.list.Get(parentIndex)
+			return fmt.Errorf("binaryheap: element %v at index %d is ordered before its parent %v at index %d", value, index, parentValue, parentIndex)
+		}
+	}
+	return nil
+}
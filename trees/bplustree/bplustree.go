@@ -0,0 +1,506 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bplustree implements a B+ tree.
+//
+// A B+ tree is a B-tree in which all values are kept in the leaves, while internal nodes only hold
+// separator keys used to route searches. Leaves are chained by next/prev pointers, so range scans
+// move from leaf to leaf without climbing back up the tree.
+//
+// A B+ tree of order m satisfies the following properties:
+// - Every node has at most m children and leaves hold at most m-1 entries.
+// - Every node (except root) holds at least ⌈m/2⌉-1 keys.
+// - An internal node with k keys has k+1 children.
+// - All leaves appear in the same level.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/B%2B_tree
+package bplustree
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+)
+
+// Assert Tree implementation
+//var _ trees.Tree = (*Tree)(nil)
+
+// Tree holds elements of the B+ tree
+type Tree[K, V any] struct {
+	Root       *Node[K, V]      // Root node
+	Comparator utils.Comparator // Key comparator
+	size       int              // Total number of keys in the tree
+	m          int              // order (maximum number of children)
+}
+
+// Node is a single element within the tree.
+// Internal nodes hold separator keys and children, leaves hold keys and values and are chained to their neighbours.
+type Node[K, V any] struct {
+	Parent   *Node[K, V]
+	Keys     []K           // Separator keys in internal nodes, entry keys in leaves
+	Values   []V           // Entry values (leaves only)
+	Children []*Node[K, V] // Children nodes (internal nodes only)
+	Prev     *Node[K, V]   // Previous leaf (leaves only)
+	Next     *Node[K, V]   // Next leaf (leaves only)
+}
+
+// NewWith instantiates a B+ tree with the order (maximum number of children) and a custom key comparator.
+func NewWith[K, V any](order int, comparator utils.Comparator) *Tree[K, V] {
+	if order < 3 {
+		panic("Invalid order, should be at least 3")
+	}
+	return &Tree[K, V]{m: order, Comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a B+ tree with the order (maximum number of children) and the IntComparator, i.e. keys are of type int.
+func NewWithIntComparator[V any](order int) *Tree[int, V] {
+	return NewWith[int, V](order, utils.IntComparator)
+}
+
+// NewWithStringComparator instantiates a B+ tree with the order (maximum number of children) and the StringComparator, i.e. keys are of type string.
+func NewWithStringComparator[V any](order int) *Tree[string, V] {
+	return NewWith[string, V](order, utils.StringComparator)
+}
+
+// Put inserts key-value pair node into the tree.
+// If key already exists, then its value is updated with the new value.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) Put(key K, value V) {
+	if tree.Root == nil {
+		tree.Root = &Node[K, V]{Keys: []K{key}, Values: []V{value}}
+		tree.size++
+		return
+	}
+
+	leaf := tree.findLeaf(key)
+	index, found := tree.search(leaf, key)
+	if found {
+		leaf.Keys[index] = key
+		leaf.Values[index] = value
+		return
+	}
+	leaf.Keys = insertAt(leaf.Keys, index, key)
+	leaf.Values = insertAt(leaf.Values, index, value)
+	tree.size++
+	tree.splitLeaf(leaf)
+}
+
+// Get searches the node in the tree by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) Get(key K) (value V, found bool) {
+	if tree.Root == nil {
+		return *new(V), false
+	}
+	leaf := tree.findLeaf(key)
+	if index, found := tree.search(leaf, key); found {
+		return leaf.Values[index], true
+	}
+	return *new(V), false
+}
+
+// GetNode searches the leaf in the tree by key and returns it or nil if key is not found in tree.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) GetNode(key K) *Node[K, V] {
+	if tree.Root == nil {
+		return nil
+	}
+	leaf := tree.findLeaf(key)
+	if _, found := tree.search(leaf, key); found {
+		return leaf
+	}
+	return nil
+}
+
+// Remove remove the node from the tree by key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) Remove(key K) {
+	if tree.Root == nil {
+		return
+	}
+	leaf := tree.findLeaf(key)
+	index, found := tree.search(leaf, key)
+	if !found {
+		return
+	}
+	leaf.Keys = removeAt(leaf.Keys, index)
+	leaf.Values = removeAt(leaf.Values, index)
+	tree.size--
+	tree.rebalance(leaf)
+}
+
+// Empty returns true if tree does not contain any nodes
+func (tree *Tree[K, V]) Empty() bool {
+	return tree.size == 0
+}
+
+// Size returns number of nodes in the tree.
+func (tree *Tree[K, V]) Size() int {
+	return tree.size
+}
+
+// Size returns the number of nodes in the subtree.
+// Computed dynamically on each call, i.e. the subtree is traversed to count the number of the nodes.
+func (node *Node[K, V]) Size() int {
+	if node == nil {
+		return 0
+	}
+	size := 1
+	for _, child := range node.Children {
+		size += child.Size()
+	}
+	return size
+}
+
+// Keys returns all keys in-order
+func (tree *Tree[K, V]) Keys() []K {
+	keys := make([]K, 0, tree.size)
+	for leaf := tree.Left(); leaf != nil; leaf = leaf.Next {
+		keys = append(keys, leaf.Keys...)
+	}
+	return keys
+}
+
+// Values returns all values in-order based on the key.
+func (tree *Tree[K, V]) Values() []V {
+	values := make([]V, 0, tree.size)
+	for leaf := tree.Left(); leaf != nil; leaf = leaf.Next {
+		values = append(values, leaf.Values...)
+	}
+	return values
+}
+
+// Clear removes all nodes from the tree.
+func (tree *Tree[K, V]) Clear() {
+	tree.Root = nil
+	tree.size = 0
+}
+
+// Height returns the height of the tree.
+func (tree *Tree[K, V]) Height() int {
+	height := 0
+	for node := tree.Root; node != nil; height++ {
+		if node.isLeaf() {
+			return height + 1
+		}
+		node = node.Children[0]
+	}
+	return height
+}
+
+// Left returns the left-most (min) leaf or nil if tree is empty.
+func (tree *Tree[K, V]) Left() *Node[K, V] {
+	node := tree.Root
+	for node != nil && !node.isLeaf() {
+		node = node.Children[0]
+	}
+	return node
+}
+
+// LeftKey returns the left-most (min) key or nil if tree is empty.
+func (tree *Tree[K, V]) LeftKey() K {
+	if left := tree.Left(); left != nil {
+		return left.Keys[0]
+	}
+	return *new(K)
+}
+
+// LeftValue returns the left-most value or nil if tree is empty.
+func (tree *Tree[K, V]) LeftValue() V {
+	if left := tree.Left(); left != nil {
+		return left.Values[0]
+	}
+	return *new(V)
+}
+
+// Right returns the right-most (max) leaf or nil if tree is empty.
+func (tree *Tree[K, V]) Right() *Node[K, V] {
+	node := tree.Root
+	for node != nil && !node.isLeaf() {
+		node = node.Children[len(node.Children)-1]
+	}
+	return node
+}
+
+// RightKey returns the right-most (max) key or nil if tree is empty.
+func (tree *Tree[K, V]) RightKey() K {
+	if right := tree.Right(); right != nil {
+		return right.Keys[len(right.Keys)-1]
+	}
+	return *new(K)
+}
+
+// RightValue returns the right-most value or nil if tree is empty.
+func (tree *Tree[K, V]) RightValue() V {
+	if right := tree.Right(); right != nil {
+		return right.Values[len(right.Values)-1]
+	}
+	return *new(V)
+}
+
+// String returns a string representation of container (for debugging purposes)
+func (tree *Tree[K, V]) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("BPlusTree\n")
+	if !tree.Empty() {
+		tree.output(&buffer, tree.Root, 0)
+	}
+	return buffer.String()
+}
+
+func (tree *Tree[K, V]) output(buffer *bytes.Buffer, node *Node[K, V], level int) {
+	if node.isLeaf() {
+		for _, key := range node.Keys {
+			buffer.WriteString(strings.Repeat("    ", level))
+			buffer.WriteString(fmt.Sprintf("%v", key) + "\n")
+		}
+		return
+	}
+	for e := 0; e < len(node.Keys)+1; e++ {
+		tree.output(buffer, node.Children[e], level+1)
+		if e < len(node.Keys) {
+			buffer.WriteString(strings.Repeat("    ", level))
+			buffer.WriteString(fmt.Sprintf("[%v]", node.Keys[e]) + "\n")
+		}
+	}
+}
+
+func (node *Node[K, V]) isLeaf() bool {
+	return len(node.Children) == 0
+}
+
+func (tree *Tree[K, V]) maxEntries() int {
+	return tree.m - 1
+}
+
+func (tree *Tree[K, V]) minEntries() int {
+	return (tree.m+1)/2 - 1 // ceil(m/2)-1
+}
+
+// search searches only within the single node among its keys and returns the position of the first key
+// that is equal or bigger than the given key
+func (tree *Tree[K, V]) search(node *Node[K, V], key K) (index int, found bool) {
+	low, high := 0, len(node.Keys)-1
+	var mid int
+	for low <= high {
+		mid = (high + low) / 2
+		compare := tree.Comparator(key, node.Keys[mid])
+		switch {
+		case compare > 0:
+			low = mid + 1
+		case compare < 0:
+			high = mid - 1
+		case compare == 0:
+			return mid, true
+		}
+	}
+	return low, false
+}
+
+// findLeaf descends from the root to the leaf that holds or would hold the key (tree must not be empty).
+func (tree *Tree[K, V]) findLeaf(key K) *Node[K, V] {
+	node := tree.Root
+	for !node.isLeaf() {
+		index, found := tree.search(node, key)
+		if found {
+			// separator equals the smallest key of the right subtree
+			index++
+		}
+		node = node.Children[index]
+	}
+	return node
+}
+
+func (tree *Tree[K, V]) splitLeaf(leaf *Node[K, V]) {
+	if len(leaf.Keys) <= tree.maxEntries() {
+		return
+	}
+	middle := len(leaf.Keys) / 2
+	right := &Node[K, V]{
+		Parent: leaf.Parent,
+		Keys:   append([]K(nil), leaf.Keys[middle:]...),
+		Values: append([]V(nil), leaf.Values[middle:]...),
+		Prev:   leaf,
+		Next:   leaf.Next,
+	}
+	leaf.Keys = append([]K(nil), leaf.Keys[:middle]...)
+	leaf.Values = append([]V(nil), leaf.Values[:middle]...)
+	if leaf.Next != nil {
+		leaf.Next.Prev = right
+	}
+	leaf.Next = right
+	tree.insertIntoParent(leaf, right.Keys[0], right)
+}
+
+func (tree *Tree[K, V]) splitInternal(node *Node[K, V]) {
+	if len(node.Keys) <= tree.maxEntries() {
+		return
+	}
+	middle := len(node.Keys) / 2
+	separator := node.Keys[middle]
+	right := &Node[K, V]{
+		Parent:   node.Parent,
+		Keys:     append([]K(nil), node.Keys[middle+1:]...),
+		Children: append([]*Node[K, V](nil), node.Children[middle+1:]...),
+	}
+	setParent(right.Children, right)
+	node.Keys = append([]K(nil), node.Keys[:middle]...)
+	node.Children = append([]*Node[K, V](nil), node.Children[:middle+1]...)
+	tree.insertIntoParent(node, separator, right)
+}
+
+// insertIntoParent links the newly split right node into the parent of the left node under the separator key.
+func (tree *Tree[K, V]) insertIntoParent(left *Node[K, V], separator K, right *Node[K, V]) {
+	parent := left.Parent
+	if parent == nil {
+		tree.Root = &Node[K, V]{Keys: []K{separator}, Children: []*Node[K, V]{left, right}}
+		left.Parent = tree.Root
+		right.Parent = tree.Root
+		return
+	}
+	index := childIndex(parent, left)
+	parent.Keys = insertAt(parent.Keys, index, separator)
+	parent.Children = insertAt(parent.Children, index+1, right)
+	right.Parent = parent
+	tree.splitInternal(parent)
+}
+
+// rebalance restores the minimum number of keys in the node after a deletion by borrowing from or merging with a sibling.
+// ref.: https://en.wikipedia.org/wiki/B%2B_tree#Deletion
+func (tree *Tree[K, V]) rebalance(node *Node[K, V]) {
+	if node == tree.Root {
+		if len(node.Keys) == 0 {
+			if node.isLeaf() {
+				tree.Root = nil
+			} else {
+				tree.Root = node.Children[0]
+				tree.Root.Parent = nil
+			}
+		}
+		return
+	}
+	if len(node.Keys) >= tree.minEntries() {
+		return
+	}
+
+	parent := node.Parent
+	index := childIndex(parent, node)
+	var left, right *Node[K, V]
+	if index > 0 {
+		left = parent.Children[index-1]
+	}
+	if index+1 < len(parent.Children) {
+		right = parent.Children[index+1]
+	}
+
+	if node.isLeaf() {
+		switch {
+		case left != nil && len(left.Keys) > tree.minEntries():
+			last := len(left.Keys) - 1
+			node.Keys = insertAt(node.Keys, 0, left.Keys[last])
+			node.Values = insertAt(node.Values, 0, left.Values[last])
+			left.Keys = removeAt(left.Keys, last)
+			left.Values = removeAt(left.Values, last)
+			parent.Keys[index-1] = node.Keys[0]
+			return
+		case right != nil && len(right.Keys) > tree.minEntries():
+			node.Keys = append(node.Keys, right.Keys[0])
+			node.Values = append(node.Values, right.Values[0])
+			right.Keys = removeAt(right.Keys, 0)
+			right.Values = removeAt(right.Values, 0)
+			parent.Keys[index] = right.Keys[0]
+			return
+		case left != nil:
+			tree.mergeLeaves(left, node, index-1)
+		default:
+			tree.mergeLeaves(node, right, index)
+		}
+	} else {
+		switch {
+		case left != nil && len(left.Keys) > tree.minEntries():
+			// rotate right through the parent's separator
+			last := len(left.Keys) - 1
+			node.Keys = insertAt(node.Keys, 0, parent.Keys[index-1])
+			parent.Keys[index-1] = left.Keys[last]
+			left.Keys = removeAt(left.Keys, last)
+			child := left.Children[last+1]
+			left.Children = removeAt(left.Children, last+1)
+			node.Children = insertAt(node.Children, 0, child)
+			child.Parent = node
+			return
+		case right != nil && len(right.Keys) > tree.minEntries():
+			// rotate left through the parent's separator
+			node.Keys = append(node.Keys, parent.Keys[index])
+			parent.Keys[index] = right.Keys[0]
+			right.Keys = removeAt(right.Keys, 0)
+			child := right.Children[0]
+			right.Children = removeAt(right.Children, 0)
+			node.Children = append(node.Children, child)
+			child.Parent = node
+			return
+		case left != nil:
+			tree.mergeInternals(left, node, index-1)
+		default:
+			tree.mergeInternals(node, right, index)
+		}
+	}
+
+	// parent might underflow, so try to rebalance if necessary
+	tree.rebalance(parent)
+}
+
+// mergeLeaves moves all entries of the right leaf into the left leaf and drops the separator at index from their parent.
+func (tree *Tree[K, V]) mergeLeaves(left *Node[K, V], right *Node[K, V], index int) {
+	left.Keys = append(left.Keys, right.Keys...)
+	left.Values = append(left.Values, right.Values...)
+	left.Next = right.Next
+	if right.Next != nil {
+		right.Next.Prev = left
+	}
+	parent := left.Parent
+	parent.Keys = removeAt(parent.Keys, index)
+	parent.Children = removeAt(parent.Children, index+1)
+}
+
+// mergeInternals pulls the separator at index down from the parent and merges the right node into the left node.
+func (tree *Tree[K, V]) mergeInternals(left *Node[K, V], right *Node[K, V], index int) {
+	parent := left.Parent
+	left.Keys = append(left.Keys, parent.Keys[index])
+	left.Keys = append(left.Keys, right.Keys...)
+	left.Children = append(left.Children, right.Children...)
+	setParent(right.Children, left)
+	parent.Keys = removeAt(parent.Keys, index)
+	parent.Children = removeAt(parent.Children, index+1)
+}
+
+func childIndex[K, V any](parent *Node[K, V], child *Node[K, V]) int {
+	for i, c := range parent.Children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+func setParent[K, V any](nodes []*Node[K, V], parent *Node[K, V]) {
+	for _, node := range nodes {
+		node.Parent = parent
+	}
+}
+
+func insertAt[T any](slice []T, index int, value T) []T {
+	slice = append(slice, value)
+	copy(slice[index+1:], slice[index:])
+	slice[index] = value
+	return slice
+}
+
+func removeAt[T any](slice []T, index int) []T {
+	copy(slice[index:], slice[index+1:])
+	slice[len(slice)-1] = *new(T)
+	return slice[:len(slice)-1]
+}
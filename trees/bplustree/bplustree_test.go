@@ -0,0 +1,499 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestBPlusTreeGet(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+
+	if actualValue := tree.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue, found := tree.Get(1); actualValue != "" || found {
+		t.Errorf("Got %v expected %v", actualValue, "")
+	}
+
+	tree.Put(1, "x") // 1->x
+	tree.Put(2, "b") // 1->x, 2->b (in order)
+	tree.Put(1, "a") // 1->a, 2->b (in order, replacement)
+	tree.Put(3, "c") // 1->a, 2->b, 3->c (in order)
+	tree.Put(4, "d") // 1->a, 2->b, 3->c, 4->d (in order)
+	tree.Put(5, "e") // 1->a, 2->b, 3->c, 4->d, 5->e (in order)
+	tree.Put(6, "f") // 1->a, 2->b, 3->c, 4->d, 5->e, 6->f (in order)
+	tree.Put(7, "g") // 1->a, 2->b, 3->c, 4->d, 5->e, 6->f, 7->g (in order)
+
+	tests := [][]interface{}{
+		{0, "", false},
+		{1, "a", true},
+		{2, "b", true},
+		{3, "c", true},
+		{4, "d", true},
+		{5, "e", true},
+		{6, "f", true},
+		{7, "g", true},
+		{8, "", false},
+	}
+
+	for _, test := range tests {
+		if value, found := tree.Get(test[0].(int)); value != test[1] || found != test[2] {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, test[1], test[2])
+		}
+		if node := tree.GetNode(test[0].(int)); (node != nil) != test[2] {
+			t.Errorf("Got %v expected %v", node != nil, test[2])
+		}
+	}
+	assertValidTree(t, tree, 7)
+}
+
+func TestBPlusTreePut(t *testing.T) {
+	tree := NewWithIntComparator[int](3)
+	for i := 1; i <= 7; i++ {
+		tree.Put(i, i)
+	}
+	assertValidTree(t, tree, 7)
+
+	// all values live in the leaves, internal nodes only route
+	if actualValue, expectedValue := tree.Height(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	var leaves []string
+	for leaf := tree.Left(); leaf != nil; leaf = leaf.Next {
+		leaves = append(leaves, fmt.Sprint(leaf.Keys))
+	}
+	if actualValue, expectedValue := strings.Join(leaves, ""), "[1][2][3][4][5][6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeRemove(t *testing.T) {
+	tree := NewWithIntComparator[int](3)
+	for i := 1; i <= 7; i++ {
+		tree.Put(i, i)
+	}
+	tree.Remove(8) // no-op
+	tree.Remove(4)
+	tree.Remove(1)
+	assertValidTree(t, tree, 5)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[2 3 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 1; i <= 7; i++ {
+		tree.Remove(i)
+	}
+	assertValidTree(t, tree, 0)
+	if tree.Root != nil {
+		t.Errorf("Got %v expected %v", tree.Root, nil)
+	}
+	tree.Remove(1) // no-op on empty
+}
+
+func TestBPlusTreeRandomized(t *testing.T) {
+	for order := 3; order <= 7; order++ {
+		r := rand.New(rand.NewSource(int64(order)))
+		tree := NewWithIntComparator[int](order)
+		expected := map[int]int{}
+		for i := 0; i < 3000; i++ {
+			key := r.Intn(300)
+			if r.Intn(3) == 0 {
+				tree.Remove(key)
+				delete(expected, key)
+			} else {
+				tree.Put(key, i)
+				expected[key] = i
+			}
+			if i%100 == 0 {
+				assertValidTree(t, tree, len(expected))
+			}
+		}
+		assertValidTree(t, tree, len(expected))
+		for key, value := range expected {
+			if actualValue, found := tree.Get(key); actualValue != value || !found {
+				t.Errorf("Got %v expected %v", actualValue, value)
+			}
+		}
+	}
+}
+
+func TestBPlusTreeLeftAndRight(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+
+	if actualValue := tree.Left(); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue := tree.Right(); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	tree.Put(1, "a")
+	tree.Put(5, "e")
+	tree.Put(6, "f")
+	tree.Put(7, "g")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(1, "x") // overwrite
+	tree.Put(2, "b")
+
+	if actualValue, expectedValue := tree.LeftKey(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.LeftValue(), "x"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RightKey(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RightValue(), "g"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeIteratorNextOnEmpty(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	it := tree.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+}
+
+func TestBPlusTreeIteratorNextAndPrev(t *testing.T) {
+	tree := NewWithIntComparator[int](4)
+	for i := 20; i > 0; i-- {
+		tree.Put(i, i*10)
+	}
+
+	it := tree.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := it.Value(), count*10; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := count, 20; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count--
+	}
+	if actualValue, expectedValue := count, 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if !it.First() || it.Key() != 1 {
+		t.Errorf("Got %v expected %v", it.Key(), 1)
+	}
+	if !it.Last() || it.Key() != 20 {
+		t.Errorf("Got %v expected %v", it.Key(), 20)
+	}
+	it.Begin()
+	if !it.NextTo(func(key int, value int) bool { return value == 50 }) || it.Key() != 5 {
+		t.Errorf("Got %v expected %v", it.Key(), 5)
+	}
+	it.End()
+	if !it.PrevTo(func(key int, value int) bool { return key%7 == 0 }) || it.Key() != 14 {
+		t.Errorf("Got %v expected %v", it.Key(), 14)
+	}
+}
+
+func TestBPlusTreeSeek(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	for i := 0; i < 20; i += 2 {
+		tree.Put(i, fmt.Sprint(i))
+	}
+
+	tests := [][]interface{}{
+		// key, next, hasNext, prev, hasPrev
+		{-1, 0, true, 0, false},
+		{0, 0, true, 0, false},
+		{7, 8, true, 6, true},
+		{8, 8, true, 6, true},
+		{18, 18, true, 16, true},
+		{19, 0, false, 18, true},
+	}
+	for _, test := range tests {
+		it := tree.Seek(test[0].(int))
+		if actualValue, expectedValue := it.Next(), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test[0])
+		} else if actualValue && it.Key() != test[1] {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[1], test[0])
+		}
+		it = tree.Seek(test[0].(int))
+		if actualValue, expectedValue := it.Prev(), test[4]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test[0])
+		} else if actualValue && it.Key() != test[3] {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[3], test[0])
+		}
+	}
+
+	// seek on an existing iterator and keep walking
+	it := tree.Iterator()
+	it.Seek(5)
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[6 8 10 12 14 16 18]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	empty := NewWithIntComparator[string](3)
+	it = empty.Seek(1)
+	if it.Next() || it.Prev() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+}
+
+func TestBPlusTreeRange(t *testing.T) {
+	tree := NewWithIntComparator[int](5)
+	for i := 0; i < 100; i++ {
+		tree.Put(i, i*i)
+	}
+
+	tests := [][]interface{}{
+		{10, 15, "[10 11 12 13 14]"},
+		{-5, 3, "[0 1 2]"},
+		{97, 200, "[97 98 99]"},
+		{50, 50, "[]"},
+		{60, 40, "[]"},
+		{100, 200, "[]"},
+	}
+	for _, test := range tests {
+		var keys []int
+		it := tree.Range(test[0].(int), test[1].(int))
+		for it.Next() {
+			if it.Value() != it.Key()*it.Key() {
+				t.Errorf("Got %v expected %v", it.Value(), it.Key()*it.Key())
+			}
+			keys = append(keys, it.Key())
+		}
+		if it.Next() {
+			t.Errorf("Shouldn't iterate past the end of the range")
+		}
+		if actualValue, expectedValue := fmt.Sprint(keys), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestBPlusTreeSerialization(t *testing.T) {
+	tree := NewWithStringComparator[string](3)
+	tree.Put("c", "3")
+	tree.Put("b", "2")
+	tree.Put("a", "1")
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[a b c]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[1 2 3]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	bytes, err := tree.ToJSON()
+	assert()
+
+	err = tree.FromJSON(bytes)
+	assert()
+
+	bytes, err = json.Marshal([]interface{}{"a", "b", "c", tree})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	newTree := NewWithStringComparator[int](3)
+	err = json.Unmarshal([]byte(`{"a":1,"b":2}`), &newTree)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(newTree.Keys(), newTree.Values()), "[a b] [1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeToDOT(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	tree.Put(1, "1")
+	tree.Put(2, "2")
+	tree.Put(3, "3")
+	tree.Put(4, "4")
+	var builder strings.Builder
+	if err := tree.ToDOT(&builder); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `digraph BPlusTree {
+	node [shape=record];
+	n0 [label="<c0>|2|<c1>|3|<c2>"];
+	n1 [label="1"];
+	n0:c0 -> n1;
+	n2 [label="2"];
+	n0:c1 -> n2;
+	n3 [label="3|4"];
+	n0:c2 -> n3;
+	n1 -> n2 [style=dashed, constraint=false];
+	n2 -> n3 [style=dashed, constraint=false];
+	{rank=same; n1; n2; n3;}
+}
+`
+	if actualValue := builder.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+}
+
+func TestBPlusTreeToStructureJSON(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	tree.Put(1, "1")
+	tree.Put(2, "2")
+	tree.Put(3, "3")
+	tree.Put(4, "4")
+	structure, err := tree.ToStructureJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `{"keys":[2,3],"children":[{"keys":[1],"values":["1"]},{"keys":[2],"values":["2"]},{"keys":[3,4],"values":["3","4"]}]}`
+	if actualValue := string(structure); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	structure, _ = NewWithIntComparator[string](3).ToStructureJSON()
+	if actualValue, expectedValue := string(structure), "null"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeString(t *testing.T) {
+	c := NewWithIntComparator[int](3)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	if !strings.HasPrefix(c.String(), "BPlusTree") {
+		t.Errorf("String should start with container name")
+	}
+}
+
+// assertValidTree checks size, key order, separators, entry counts, uniform leaf depth and the leaf chain.
+func assertValidTree[K, V comparable](t *testing.T, tree *Tree[K, V], expectedSize int) {
+	if actualValue, expectedValue := tree.size, expectedSize; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v for tree size", actualValue, expectedValue)
+	}
+	if tree.Root == nil {
+		return
+	}
+	var leaves []*Node[K, V]
+	var walk func(node *Node[K, V], depth int)
+	walk = func(node *Node[K, V], depth int) {
+		if node != tree.Root && (len(node.Keys) < tree.minEntries() || len(node.Keys) > tree.maxEntries()) {
+			t.Errorf("Got %v keys in node at depth %v", len(node.Keys), depth)
+		}
+		if node.isLeaf() {
+			if depth != tree.Height() {
+				t.Errorf("Got leaf at depth %v expected %v", depth, tree.Height())
+			}
+			leaves = append(leaves, node)
+			return
+		}
+		for i, child := range node.Children {
+			if child.Parent != node {
+				t.Errorf("Got wrong parent at depth %v", depth)
+			}
+			// keys of child i lie within [Keys[i-1], Keys[i])
+			for _, key := range child.subtreeKeys() {
+				if i > 0 && tree.Comparator(key, node.Keys[i-1]) < 0 || i < len(node.Keys) && tree.Comparator(key, node.Keys[i]) >= 0 {
+					t.Errorf("Got key %v outside of separators in child %v", key, i)
+				}
+			}
+			walk(child, depth+1)
+		}
+	}
+	walk(tree.Root, 1)
+
+	var keys []K
+	for i, leaf := range leaves {
+		if i > 0 && leaf.Prev != leaves[i-1] || i < len(leaves)-1 && leaf.Next != leaves[i+1] {
+			t.Errorf("Got broken leaf chain at leaf %v", i)
+		}
+		keys = append(keys, leaf.Keys...)
+	}
+	if !sort.SliceIsSorted(keys, func(i, j int) bool { return tree.Comparator(keys[i], keys[j]) < 0 }) || len(keys) != expectedSize {
+		t.Errorf("Got leaf keys %v", keys)
+	}
+}
+
+func (node *Node[K, V]) subtreeKeys() []K {
+	if node.isLeaf() {
+		return node.Keys
+	}
+	var keys []K
+	for _, child := range node.Children {
+		keys = append(keys, child.subtreeKeys()...)
+	}
+	return keys
+}
+
+func benchmarkGet(b *testing.B, tree *Tree[int, struct{}], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Get(n)
+		}
+	}
+}
+
+func benchmarkRange(b *testing.B, tree *Tree[int, struct{}], size int) {
+	for i := 0; i < b.N; i++ {
+		for it := tree.Range(0, size); it.Next(); {
+		}
+	}
+}
+
+func BenchmarkBPlusTreeGet100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator[struct{}](128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, size)
+}
+
+func BenchmarkBPlusTreeRange100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator[struct{}](128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRange(b, tree, size)
+}
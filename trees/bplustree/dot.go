@@ -0,0 +1,59 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kcswag/kcgods/internal/dot"
+	"io"
+	"strings"
+)
+
+// ToDOT writes the index and leaf levels of the tree as a Graphviz digraph to w.
+// Every node is drawn as a record holding its keys, with the edge to each child leaving from the slot between
+// the two separators that bound it. Leaves are kept on one rank and their chain is drawn with dashed edges.
+func (tree *Tree[K, V]) ToDOT(w io.Writer) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph BPlusTree {\n")
+	buffer.WriteString("\tnode [shape=record];\n")
+	id := 0
+	ids := make(map[*Node[K, V]]int)
+	var output func(node *Node[K, V]) int
+	output = func(node *Node[K, V]) int {
+		nodeID := id
+		id++
+		ids[node] = nodeID
+		fields := make([]string, 0, 2*len(node.Keys)+1)
+		for i, key := range node.Keys {
+			if len(node.Children) > 0 {
+				fields = append(fields, fmt.Sprintf("<c%d>", i))
+			}
+			fields = append(fields, dot.EscapeRecord(fmt.Sprintf("%v", key)))
+		}
+		if len(node.Children) > 0 {
+			fields = append(fields, fmt.Sprintf("<c%d>", len(node.Keys)))
+		}
+		buffer.WriteString(fmt.Sprintf("\tn%d [label=\"%s\"];\n", nodeID, strings.Join(fields, "|")))
+		for i, child := range node.Children {
+			buffer.WriteString(fmt.Sprintf("\tn%d:c%d -> n%d;\n", nodeID, i, output(child)))
+		}
+		return nodeID
+	}
+	if tree.Root != nil {
+		output(tree.Root)
+		leaves := []string{}
+		for leaf := tree.Left(); leaf != nil; leaf = leaf.Next {
+			leaves = append(leaves, fmt.Sprintf("n%d", ids[leaf]))
+			if leaf.Next != nil {
+				buffer.WriteString(fmt.Sprintf("\tn%d -> n%d [style=dashed, constraint=false];\n", ids[leaf], ids[leaf.Next]))
+			}
+		}
+		buffer.WriteString(fmt.Sprintf("\t{rank=same; %s;}\n", strings.Join(leaves, "; ")))
+	}
+	buffer.WriteString("}\n")
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+// Assert Iterator implementation
+//var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
+
+// Iterator holding the iterator's state
+type Iterator[K, V any] struct {
+	tree     *Tree[K, V]
+	node     *Node[K, V] // current leaf
+	index    int         // current entry within the leaf
+	position position
+}
+
+type position byte
+
+const (
+	// before means that the iterator sits in the gap right before the entry at (node, index)
+	begin, between, end, before position = 0, 1, 2, 3
+)
+
+// Iterator returns a stateful iterator whose elements are key/value pairs.
+func (tree *Tree[K, V]) Iterator() Iterator[K, V] {
+	return Iterator[K, V]{tree: tree, node: nil, position: begin}
+}
+
+// Seek returns a stateful iterator positioned right before the first element whose key is equal or bigger than the given key.
+// Call Next() to fetch that element, or Prev() to fetch the last element whose key is smaller than the given key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) Seek(key K) Iterator[K, V] {
+	iterator := tree.Iterator()
+	iterator.Seek(key)
+	return iterator
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Next() bool {
+	switch iterator.position {
+	case end:
+		return false
+	case begin:
+		iterator.node, iterator.index = iterator.tree.Left(), 0
+	case between:
+		iterator.index++
+	}
+	for iterator.node != nil && iterator.index >= len(iterator.node.Keys) {
+		iterator.node, iterator.index = iterator.node.Next, 0
+	}
+	if iterator.node == nil {
+		iterator.position = end
+		return false
+	}
+	iterator.position = between
+	return true
+}
+
+// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
+// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Prev() bool {
+	switch iterator.position {
+	case begin:
+		return false
+	case end:
+		iterator.node = iterator.tree.Right()
+		if iterator.node != nil {
+			iterator.index = len(iterator.node.Keys) - 1
+		}
+	case between, before:
+		iterator.index--
+	}
+	for iterator.node != nil && iterator.index < 0 {
+		iterator.node = iterator.node.Prev
+		if iterator.node != nil {
+			iterator.index = len(iterator.node.Keys) - 1
+		}
+	}
+	if iterator.node == nil {
+		iterator.position = begin
+		return false
+	}
+	iterator.position = between
+	return true
+}
+
+// Seek moves the iterator right before the first element whose key is equal or bigger than the given key.
+// Call Next() to fetch that element, or Prev() to fetch the last element whose key is smaller than the given key.
+// Runs in O(log n), the leaf chain is used to move from there.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) Seek(key K) {
+	iterator.position = before
+	iterator.node, iterator.index = nil, 0
+	if iterator.tree.Root != nil {
+		iterator.node = iterator.tree.findLeaf(key)
+		iterator.index, _ = iterator.tree.search(iterator.node, key)
+	}
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[K, V]) Value() V {
+	return iterator.node.Values[iterator.index]
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[K, V]) Key() K {
+	return iterator.node.Keys[iterator.index]
+}
+
+// Node returns the current element's leaf.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[K, V]) Node() *Node[K, V] {
+	return iterator.node
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator[K, V]) Begin() {
+	iterator.node = nil
+	iterator.position = begin
+}
+
+// End moves the iterator past the last element (one-past-the-end).
+// Call Prev() to fetch the last element if any.
+func (iterator *Iterator[K, V]) End() {
+	iterator.node = nil
+	iterator.position = end
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator
+func (iterator *Iterator[K, V]) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// Last moves the iterator to the last element and returns true if there was a last element in the container.
+// If Last() returns true, then last element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) Last() bool {
+	iterator.End()
+	return iterator.Prev()
+}
+
+// NextTo moves the iterator to the next element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If NextTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) NextTo(f func(key K, value V) bool) bool {
+	for iterator.Next() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrevTo moves the iterator to the previous element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If PrevTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[K, V]) PrevTo(f func(key K, value V) bool) bool {
+	for iterator.Prev() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// RangeIterator is a forward-only iterator over the elements whose keys lie within [lo, hi).
+type RangeIterator[K, V any] struct {
+	iterator Iterator[K, V]
+	hi       K
+	done     bool
+}
+
+// Range returns a forward-only iterator over the elements whose keys are equal or bigger than lo and smaller than hi.
+// Finding the first element takes O(log n), every further element is reached by following the leaf chain.
+// Keys should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) Range(lo K, hi K) RangeIterator[K, V] {
+	return RangeIterator[K, V]{iterator: tree.Seek(lo), hi: hi}
+}
+
+// Next moves the iterator to the next element within the range and returns true if there was one.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *RangeIterator[K, V]) Next() bool {
+	if iterator.done {
+		return false
+	}
+	if !iterator.iterator.Next() || iterator.iterator.tree.Comparator(iterator.iterator.Key(), iterator.hi) >= 0 {
+		iterator.done = true
+		return false
+	}
+	return true
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *RangeIterator[K, V]) Value() V {
+	return iterator.iterator.Value()
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *RangeIterator[K, V]) Key() K {
+	return iterator.iterator.Key()
+}
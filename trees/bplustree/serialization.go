@@ -0,0 +1,87 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import (
+	"encoding/json"
+	"github.com/kcswag/kcgods/utils"
+)
+
+// Assert Serialization implementation
+//var _ containers.JSONSerializer = (*Tree)(nil)
+//var _ containers.JSONDeserializer = (*Tree)(nil)
+
+// ToJSON outputs the JSON representation of the tree.
+func (tree *Tree[K, V]) ToJSON() ([]byte, error) {
+	elements := make(map[string]V)
+	it := tree.Iterator()
+	for it.Next() {
+		key, err := utils.MarshalKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		elements[key] = it.Value()
+	}
+	return json.Marshal(&elements)
+}
+
+// FromJSON populates the tree from the input JSON representation.
+func (tree *Tree[K, V]) FromJSON(data []byte) error {
+	elements := make(map[string]V)
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	keys := make([]K, 0, len(elements))
+	values := make([]V, 0, len(elements))
+	for name, value := range elements {
+		key, err := utils.UnmarshalKey[K](name)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	tree.Clear()
+	for i, key := range keys {
+		tree.Put(key, values[i])
+	}
+	return nil
+}
+
+// structureNode is the JSON representation of a node that keeps the shape of the tree
+type structureNode[K, V any] struct {
+	Keys     []K                    `json:"keys"`
+	Values   []V                    `json:"values,omitempty"`
+	Children []*structureNode[K, V] `json:"children,omitempty"`
+}
+
+// ToStructureJSON outputs the JSON representation of the shape of the tree for debugging.
+// Internal nodes are objects with their separator keys and children, leaves are objects with their keys and values.
+// An empty tree is represented by null.
+// Unlike ToJSON, the output is not meant to be read back with FromJSON.
+func (tree *Tree[K, V]) ToStructureJSON() ([]byte, error) {
+	var structure func(node *Node[K, V]) *structureNode[K, V]
+	structure = func(node *Node[K, V]) *structureNode[K, V] {
+		if node == nil {
+			return nil
+		}
+		result := &structureNode[K, V]{Keys: node.Keys, Values: node.Values}
+		for _, child := range node.Children {
+			result.Children = append(result.Children, structure(child))
+		}
+		return result
+	}
+	return json.Marshal(structure(tree.Root))
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (tree *Tree[K, V]) UnmarshalJSON(bytes []byte) error {
+	return tree.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (tree *Tree[K, V]) MarshalJSON() ([]byte, error) {
+	return tree.ToJSON()
+}
@@ -27,28 +27,37 @@ import (
 //var _ trees.Tree = (*Tree)(nil)
 
 // Tree holds elements of the B-tree
-type Tree[K, V comparable] struct {
-	Root       *Node[K, V]      // Root node
-	Comparator utils.Comparator // Key comparator
-	size       int              // Total number of keys in the tree
-	m          int              // order (maximum number of children)
+type Tree[K, V any] struct {
+	Root       *Node[K, V]         // Root node
+	Comparator utils.Comparator    // Key comparator
+	size       int                 // Total number of keys in the tree
+	m          int                 // order (maximum number of children)
+	store      NodeStore[K, V]     // store holding the nodes, nil if all nodes are kept in memory
+	committed  PageID              // root page of the tree committed to the store
+	cleared    bool                // whether the committed tree has been cleared since the last flush
+	seen       map[PageID][]PageID // pages loaded since the last flush and the children they referenced
 }
 
 // Node is a single element within the tree
-type Node[K, V comparable] struct {
+type Node[K, V any] struct {
 	Parent   *Node[K, V]
 	Entries  []*Entry[K, V] // Contained keys in node
 	Children []*Node[K, V]  // Children nodes
+
+	page           PageID         // page holding the node in the tree's store, zero if not stored yet
+	stub           *Tree[K, V]    // tree whose store the node has to be loaded from, nil once loaded
+	stored         []*Entry[K, V] // entries as of the last load or store
+	storedChildren []PageID       // children as of the last load or store
 }
 
 // Entry represents the key-value pair contained within nodes
-type Entry[K, V comparable] struct {
+type Entry[K, V any] struct {
 	Key   K
 	Value V
 }
 
 // NewWith instantiates a B-tree with the order (maximum number of children) and a custom key comparator.
-func NewWith[K, V comparable](order int, comparator utils.Comparator) *Tree[K, V] {
+func NewWith[K, V any](order int, comparator utils.Comparator) *Tree[K, V] {
 	if order < 3 {
 		panic("Invalid order, should be at least 3")
 	}
@@ -56,12 +65,12 @@ func NewWith[K, V comparable](order int, comparator utils.Comparator) *Tree[K, V
 }
 
 // NewWithIntComparator instantiates a B-tree with the order (maximum number of children) and the IntComparator, i.e. keys are of type int.
-func NewWithIntComparator[V comparable](order int) *Tree[int, V] {
+func NewWithIntComparator[V any](order int) *Tree[int, V] {
 	return NewWith[int, V](order, utils.IntComparator)
 }
 
 // NewWithStringComparator instantiates a B-tree with the order (maximum number of children) and the StringComparator, i.e. keys are of type string.
-func NewWithStringComparator[V comparable](order int) *Tree[string, V] {
+func NewWithStringComparator[V any](order int) *Tree[string, V] {
 	return NewWith[string, V](order, utils.StringComparator)
 }
 
@@ -128,7 +137,7 @@ func (node *Node[K, V]) Size() int {
 	}
 	size := 1
 	for _, child := range node.Children {
-		size += child.Size()
+		size += child.load().Size()
 	}
 	return size
 }
@@ -157,6 +166,7 @@ func (tree *Tree[K, V]) Values() []V {
 func (tree *Tree[K, V]) Clear() {
 	tree.Root = nil
 	tree.size = 0
+	tree.cleared = tree.store != nil
 }
 
 // Height returns the height of the tree.
@@ -223,7 +233,7 @@ func (entry *Entry[K, V]) String() string {
 func (tree *Tree[K, V]) output(buffer *bytes.Buffer, node *Node[K, V], level int, isTail bool) {
 	for e := 0; e < len(node.Entries)+1; e++ {
 		if e < len(node.Children) {
-			tree.output(buffer, node.Children[e], level+1, true)
+			tree.output(buffer, node.Children[e].load(), level+1, true)
 		}
 		if e < len(node.Entries) {
 			buffer.WriteString(strings.Repeat("    ", level))
@@ -234,7 +244,7 @@ func (tree *Tree[K, V]) output(buffer *bytes.Buffer, node *Node[K, V], level int
 
 func (node *Node[K, V]) height() int {
 	height := 0
-	for ; node != nil; node = node.Children[0] {
+	for ; node != nil; node = node.Children[0].load() {
 		height++
 		if len(node.Children) == 0 {
 			break
@@ -308,7 +318,7 @@ func (tree *Tree[K, V]) searchRecursively(startNode *Node[K, V], key K) (node *N
 		if tree.isLeaf(node) {
 			return nil, -1, false
 		}
-		node = node.Children[index]
+		node = node.Children[index].load()
 	}
 }
 
@@ -339,7 +349,7 @@ func (tree *Tree[K, V]) insertIntoInternal(node *Node[K, V], entry *Entry[K, V])
 		node.Entries[insertPosition] = entry
 		return false
 	}
-	return tree.insert(node.Children[insertPosition], entry)
+	return tree.insert(node.Children[insertPosition].load(), entry)
 }
 
 func (tree *Tree[K, V]) split(node *Node[K, V]) {
@@ -413,7 +423,7 @@ func (tree *Tree[K, V]) splitRoot() {
 	tree.Root = newRoot
 }
 
-func setParent[K, V comparable](nodes []*Node[K, V], parent *Node[K, V]) {
+func setParent[K, V any](nodes []*Node[K, V], parent *Node[K, V]) {
 	for _, node := range nodes {
 		node.Parent = parent
 	}
@@ -428,7 +438,7 @@ func (tree *Tree[K, V]) left(node *Node[K, V]) *Node[K, V] {
 		if tree.isLeaf(current) {
 			return current
 		}
-		current = current.Children[0]
+		current = current.Children[0].load()
 	}
 }
 
@@ -441,7 +451,7 @@ func (tree *Tree[K, V]) right(node *Node[K, V]) *Node[K, V] {
 		if tree.isLeaf(current) {
 			return current
 		}
-		current = current.Children[len(current.Children)-1]
+		current = current.Children[len(current.Children)-1].load()
 	}
 }
 
@@ -452,7 +462,7 @@ func (tree *Tree[K, V]) leftSibling(node *Node[K, V], key K) (*Node[K, V], int)
 		index, _ := tree.search(node.Parent, key)
 		index--
 		if index >= 0 && index < len(node.Parent.Children) {
-			return node.Parent.Children[index], index
+			return node.Parent.Children[index].load(), index
 		}
 	}
 	return nil, -1
@@ -465,15 +475,16 @@ func (tree *Tree[K, V]) rightSibling(node *Node[K, V], key K) (*Node[K, V], int)
 		index, _ := tree.search(node.Parent, key)
 		index++
 		if index < len(node.Parent.Children) {
-			return node.Parent.Children[index], index
+			return node.Parent.Children[index].load(), index
 		}
 	}
 	return nil, -1
 }
 
-// delete deletes an entry in node at entries' index
+// delete deletes an entry in node at entries' index and returns the leaf node the entry was taken out of,
+// which stays part of the tree unless the tree became empty
 // ref.: https://en.wikipedia.org/wiki/B-tree#Deletion
-func (tree *Tree[K, V]) delete(node *Node[K, V], index int) {
+func (tree *Tree[K, V]) delete(node *Node[K, V], index int) *Node[K, V] {
 	// deleting from a leaf node
 	if tree.isLeaf(node) {
 		deletedKey := node.Entries[index].Key
@@ -482,16 +493,17 @@ func (tree *Tree[K, V]) delete(node *Node[K, V], index int) {
 		if len(tree.Root.Entries) == 0 {
 			tree.Root = nil
 		}
-		return
+		return node
 	}
 
 	// deleting from an internal node
-	leftLargestNode := tree.right(node.Children[index]) // largest node in the left sub-tree (assumed to exist)
+	leftLargestNode := tree.right(node.Children[index].load()) // largest node in the left sub-tree (assumed to exist)
 	leftLargestEntryIndex := len(leftLargestNode.Entries) - 1
 	node.Entries[index] = leftLargestNode.Entries[leftLargestEntryIndex]
 	deletedKey := leftLargestNode.Entries[leftLargestEntryIndex].Key
 	tree.deleteEntry(leftLargestNode, leftLargestEntryIndex)
 	tree.rebalance(leftLargestNode, deletedKey)
+	return leftLargestNode
 }
 
 // rebalance rebalances the tree after deletion if necessary and returns true, otherwise false.
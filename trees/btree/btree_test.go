@@ -8,6 +8,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/kcswag/kcgods/containers"
+	"github.com/kcswag/kcgods/utils"
+	"math/rand"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -1215,6 +1219,51 @@ func TestBTreeSerialization(t *testing.T) {
 	}
 }
 
+func TestBTreeToDOT(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	tree.Put(1, "1")
+	tree.Put(2, "2")
+	tree.Put(3, "3")
+	tree.Put(4, "4")
+	var builder strings.Builder
+	if err := tree.ToDOT(&builder); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `digraph BTree {
+	node [shape=record];
+	n0 [label="<c0>|2|<c1>"];
+	n1 [label="1"];
+	n0:c0 -> n1;
+	n2 [label="3|4"];
+	n0:c1 -> n2;
+}
+`
+	if actualValue := builder.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+}
+
+func TestBTreeToStructureJSON(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	tree.Put(1, "1")
+	tree.Put(2, "2")
+	tree.Put(3, "3")
+	tree.Put(4, "4")
+	structure, err := tree.ToStructureJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `{"entries":[{"key":2,"value":"2"}],"children":[{"entries":[{"key":1,"value":"1"}]},{"entries":[{"key":3,"value":"3"},{"key":4,"value":"4"}]}]}`
+	if actualValue := string(structure); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	structure, _ = NewWithIntComparator[string](3).ToStructureJSON()
+	if actualValue, expectedValue := string(structure), "null"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestBTreeString(t *testing.T) {
 	c := NewWithStringComparator[int](3)
 	c.Put("a", 1)
@@ -1223,6 +1272,740 @@ func TestBTreeString(t *testing.T) {
 	}
 }
 
+func TestBTreeBuildFromSorted(t *testing.T) {
+	for order := 3; order <= 8; order++ {
+		for _, fillFactor := range []float64{0.1, 0.5, 0.75, 1} {
+			for n := 0; n < 200; n++ {
+				keys := make([]int, n)
+				values := make([]int, n)
+				for i := range keys {
+					keys[i], values[i] = i*2, i
+				}
+				tree := NewWithIntComparator[int](order)
+				tree.Put(-1, -1)
+				if err := tree.BuildFromSorted(keys, values, fillFactor); err != nil {
+					t.Fatalf("Got error %v", err)
+				}
+				assertValidTree(t, tree, n)
+				if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(keys); actualValue != expectedValue {
+					t.Errorf("Got %v expected %v", actualValue, expectedValue)
+				}
+				if err := tree.Validate(); err != nil {
+					t.Errorf("Got error %v for %d keys of order %d", err, n, order)
+				}
+				tree.Put(n*2+1, 0)
+				tree.Remove(0)
+				if err := tree.Validate(); err != nil {
+					t.Errorf("Got error %v for %d keys of order %d", err, n, order)
+				}
+			}
+		}
+	}
+}
+
+func TestBTreeBuildFromSortedFillFactor(t *testing.T) {
+	keys := make([]int, 1000)
+	for i := range keys {
+		keys[i] = i
+	}
+	full := NewWithIntComparator[int](11)
+	if err := full.BuildFromSorted(keys, keys, 1); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	half := NewWithIntComparator[int](11)
+	if err := half.BuildFromSorted(keys, keys, 0.5); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := len(full.Left().Entries), 10; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := len(half.Left().Entries), 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := full.BuildFromSorted(keys, keys, 0); err == nil {
+		t.Errorf("Expected error for fill factor 0")
+	}
+	if err := full.BuildFromSorted(keys, keys, 1.5); err == nil {
+		t.Errorf("Expected error for fill factor 1.5")
+	}
+}
+
+func TestBTreeBuildFromSortedInvalid(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	tree.Put(1, "a")
+	if err := tree.BuildFromSorted([]int{1, 3, 2}, []string{"a", "b", "c"}, 1); err == nil {
+		t.Errorf("Expected error for unsorted keys")
+	}
+	if err := tree.BuildFromSorted([]int{1, 2, 2}, []string{"a", "b", "c"}, 1); err == nil {
+		t.Errorf("Expected error for duplicate keys")
+	}
+	if err := tree.BuildFromSorted([]int{1, 2}, []string{"a"}, 1); err == nil {
+		t.Errorf("Expected error for mismatched lengths")
+	}
+	assertValidTree(t, tree, 1)
+}
+
+func TestBTreeBuildFromSortedIterator(t *testing.T) {
+	source := NewWithStringComparator[int](3)
+	source.Put("c", 3)
+	source.Put("a", 1)
+	source.Put("b", 2)
+	tree := NewWithStringComparator[int](3)
+	it := source.Iterator()
+	if err := tree.BuildFromSortedIterator(&it, 1); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Values()), "[a b c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeValidate(t *testing.T) {
+	tree := NewWithIntComparator[int](3)
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		tree.Put((i*37)%100, i)
+	}
+	for i := 0; i < 50; i++ {
+		tree.Remove((i * 13) % 100)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	tests := []struct {
+		corrupt  func(tree *Tree[int, int])
+		expected string
+	}{
+		{func(tree *Tree[int, int]) { tree.Root.Children[0].Entries[0].Key = 1000 }, "not smaller than separator"},
+		{func(tree *Tree[int, int]) { tree.Root.Children[1].Children[0].Entries[0].Key = 0 }, "not greater than separator"},
+		{func(tree *Tree[int, int]) { tree.Root.Children[1].Parent = nil }, "has a different parent"},
+		{func(tree *Tree[int, int]) { tree.Root.Children[1].Children = nil }, "leaf [5] is at depth 2, expected 3"},
+		{func(tree *Tree[int, int]) {
+			leaf := tree.Left()
+			leaf.Entries = append(leaf.Entries, &Entry[int, int]{Key: -1})
+		}, "not greater than previous entry"},
+		{func(tree *Tree[int, int]) { tree.Left().Entries = nil }, "holds 0 entries, at least 1 required"},
+		{func(tree *Tree[int, int]) { tree.size = 0 }, "size is 0 but tree holds 7 entries"},
+	}
+	for _, test := range tests {
+		tree := NewWithIntComparator[int](3)
+		for i := 0; i < 7; i++ {
+			tree.Put(i, i)
+		}
+		test.corrupt(tree)
+		if err := tree.Validate(); err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Got %v expected %v", err, test.expected)
+		}
+	}
+}
+
+func TestBTreeFlushWithoutStore(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	tree.Put(1, "a")
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := tree.Size(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeMemoryStore(t *testing.T) {
+	store := NewMemoryStore[int, string]()
+	tree, err := NewWithStore[int, string](3, utils.IntComparator, store)
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := tree.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 1; i <= 100; i++ {
+		tree.Put(i, fmt.Sprintf("%d", i))
+	}
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+
+	tree, err = NewWithStore[int, string](3, utils.IntComparator, store)
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := tree.Size(), 100; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := tree.Get(42); actualValue != "42" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "42")
+	}
+	for i := 2; i <= 100; i += 2 {
+		tree.Remove(i)
+	}
+	tree.Put(1, "x")
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+
+	tree, err = NewWithStore[int, string](3, utils.IntComparator, store)
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := tree.Size(), 50; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := tree.Get(1); actualValue != "x" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "x")
+	}
+	if actualValue, found := tree.Get(2); actualValue != "" || found {
+		t.Errorf("Got %v expected %v", actualValue, "")
+	}
+	if actualValue, expectedValue := store.Pages(), tree.Root.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Clear()
+	tree.Put(7, "g")
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := store.Pages(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Remove(7)
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := store.Pages(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+// committingStore fails to commit while failing is set
+type committingStore struct {
+	*MemoryStore[int, string]
+	failing bool
+}
+
+func (store *committingStore) Commit(root PageID, size int, freed []PageID) error {
+	if store.failing {
+		return fmt.Errorf("crash")
+	}
+	return store.MemoryStore.Commit(root, size, freed)
+}
+
+func TestBTreeMemoryStoreCommitFails(t *testing.T) {
+	store := &committingStore{MemoryStore: NewMemoryStore[int, string]()}
+	tree, _ := NewWithStore[int, string](3, utils.IntComparator, store)
+	for i := 1; i <= 100; i++ {
+		tree.Put(i, fmt.Sprintf("a%d", i))
+	}
+	if err := tree.Flush(); err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	committed, _, _ := store.Root()
+
+	for i := 1; i <= 100; i += 2 {
+		tree.Remove(i)
+	}
+	tree.Put(1, "b1")
+	store.failing = true
+	if err := tree.Flush(); err == nil {
+		t.Fatalf("Got %v expected an error", err)
+	}
+
+	// The previously committed tree and all of its pages are still readable
+	if actualValue, size, _ := store.Root(); actualValue != committed || size != 100 {
+		t.Errorf("Got %v and %v expected %v and %v", actualValue, size, committed, 100)
+	}
+	reopened, err := NewWithStore[int, string](3, utils.IntComparator, store)
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	for i := 1; i <= 100; i++ {
+		if actualValue, found := reopened.Get(i); actualValue != fmt.Sprintf("a%d", i) || !found {
+			t.Errorf("Got %v expected %v", actualValue, fmt.Sprintf("a%d", i))
+		}
+	}
+	if err := reopened.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+
+	// Flushing again commits the modified tree and releases the pages of the previous one
+	store.failing = false
+	if err := tree.Flush(); err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	reopened, _ = NewWithStore[int, string](3, utils.IntComparator, store)
+	if actualValue, expectedValue := fmt.Sprint(reopened.Values()[:3]), "[b1 a2 a4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := store.Pages(), reopened.Root.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeMemoryStoreRandom(t *testing.T) {
+	for _, order := range []int{3, 4, 5} {
+		store := NewMemoryStore[int, int]()
+		tree, _ := NewWithStore[int, int](order, utils.IntComparator, store)
+		expected := make(map[int]int)
+		random := rand.New(rand.NewSource(int64(order)))
+		for i := 0; i < 3000; i++ {
+			key := random.Intn(300)
+			if random.Intn(3) == 0 {
+				tree.Remove(key)
+				delete(expected, key)
+			} else {
+				tree.Put(key, i)
+				expected[key] = i
+			}
+			if i%50 == 0 {
+				if err := tree.Flush(); err != nil {
+					t.Fatalf("Got %v expected %v", err, nil)
+				}
+			}
+			if i%200 == 0 {
+				tree, _ = NewWithStore[int, int](order, utils.IntComparator, store)
+				if actualValue, expectedValue := store.Pages(), tree.Root.Size(); actualValue != expectedValue {
+					t.Errorf("Got %v expected %v", actualValue, expectedValue)
+				}
+				for key, value := range expected {
+					if actualValue, found := tree.Get(key); actualValue != value || !found {
+						t.Fatalf("Got %v expected %v", actualValue, value)
+					}
+				}
+			}
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got %v expected %v", err, nil)
+		}
+		if actualValue, expectedValue := tree.Size(), len(expected); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func openFileTree(t *testing.T, path string) (*Tree[int, string], *FileStore[int, string]) {
+	store, err := OpenFileStore[int, string](path, 4, IntCodec{}, StringCodec{MaxLength: 8}, 16)
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	tree, err := NewWithStore[int, string](4, utils.IntComparator, store)
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	return tree, store
+}
+
+func assertFileTree(t *testing.T, tree *Tree[int, string], size int, value string) {
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := tree.Size(), size; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it := tree.Iterator()
+	for i := 1; it.Next(); i++ {
+		if actualValue, expectedValue := it.Key(), i; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := it.Value(), fmt.Sprintf("%s%d", value, i); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestBTreeFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	tree, store := openFileTree(t, path)
+	for i := 1; i <= 200; i++ {
+		tree.Put(i, fmt.Sprintf("a%d", i))
+	}
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	for i := 1; i <= 200; i++ {
+		tree.Put(i, fmt.Sprintf("b%d", i))
+	}
+	for i := 101; i <= 200; i++ {
+		tree.Remove(i)
+	}
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	store.Close()
+
+	tree, store = openFileTree(t, path)
+	defer store.Close()
+	assertFileTree(t, tree, 100, "b")
+
+	// Pages freed by previous commits are reused
+	info, _ := os.Stat(path)
+	for i := 0; i < 100; i++ {
+		tree.Put(50, fmt.Sprintf("b%d", 50))
+		if err := tree.Flush(); err != nil {
+			t.Errorf("Got %v expected %v", err, nil)
+		}
+	}
+	if grown, _ := os.Stat(path); grown.Size() > info.Size()+int64(10*store.pageSize) {
+		t.Errorf("Got %v expected at most %v", grown.Size(), info.Size()+int64(10*store.pageSize))
+	}
+}
+
+func TestBTreeFileStoreErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	tree, store := openFileTree(t, path)
+	tree.Put(1, "too long value")
+	if err := tree.Flush(); err == nil {
+		t.Errorf("Got %v expected an error", err)
+	}
+	store.Close()
+
+	if _, err := OpenFileStore[int, string](path, 5, IntCodec{}, StringCodec{MaxLength: 8}, 16); err == nil {
+		t.Errorf("Got %v expected an error", err)
+	}
+	if _, err := OpenFileStore[int, string](path, 4, IntCodec{}, StringCodec{MaxLength: 9}, 16); err == nil {
+		t.Errorf("Got %v expected an error", err)
+	}
+	os.WriteFile(path, []byte("not a tree"), 0644)
+	if _, err := OpenFileStore[int, string](path, 4, IntCodec{}, StringCodec{MaxLength: 8}, 16); err == nil {
+		t.Errorf("Got %v expected an error", err)
+	}
+}
+
+// failingStore fails to store pages once the given number of pages has been stored
+type failingStore struct {
+	*FileStore[int, string]
+	remaining int
+}
+
+func (store *failingStore) Store(id PageID, page *Page[int, string]) error {
+	if store.remaining == 0 {
+		return fmt.Errorf("crash")
+	}
+	store.remaining--
+	return store.FileStore.Store(id, page)
+}
+
+func TestBTreeFileStoreCrash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	tree, store := openFileTree(t, path)
+	for i := 1; i <= 100; i++ {
+		tree.Put(i, fmt.Sprintf("a%d", i))
+	}
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+
+	// Modifications which have not been flushed are lost
+	for i := 1; i <= 150; i++ {
+		tree.Put(i, fmt.Sprintf("b%d", i))
+	}
+	store.Close()
+	tree, store = openFileTree(t, path)
+	assertFileTree(t, tree, 100, "a")
+
+	// Interrupted flushes keep the last commit intact
+	for _, remaining := range []int{0, 1, 5, 20} {
+		failing := &failingStore{FileStore: store, remaining: remaining}
+		tree, _ = NewWithStore[int, string](4, utils.IntComparator, failing)
+		for i := 1; i <= 150; i++ {
+			tree.Put(i, fmt.Sprintf("b%d", i))
+		}
+		if err := tree.Flush(); err == nil {
+			t.Errorf("Got %v expected an error", err)
+		}
+		store.Close()
+		tree, store = openFileTree(t, path)
+		assertFileTree(t, tree, 100, "a")
+	}
+
+	// A corrupted meta slot falls back to the previous commit
+	for i := 1; i <= 150; i++ {
+		tree.Put(i, fmt.Sprintf("c%d", i))
+	}
+	if err := tree.Flush(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	seq := store.meta.seq
+	store.Close()
+	tree, store = openFileTree(t, path)
+	assertFileTree(t, tree, 150, "c")
+	store.Close()
+
+	file, _ := os.OpenFile(path, os.O_RDWR, 0644)
+	file.WriteAt([]byte{0xff, 0xff}, int64(metaOffset+int(seq%2)*metaSize+8))
+	file.Close()
+	tree, store = openFileTree(t, path)
+	assertFileTree(t, tree, 100, "a")
+	root := store.offset(PageID(store.meta.root))
+	store.Close()
+
+	// A corrupted page is reported
+	file, _ = os.OpenFile(path, os.O_RDWR, 0644)
+	file.WriteAt([]byte{0xff, 0xff}, root+pageHeaderSize)
+	file.Close()
+	store, err := OpenFileStore[int, string](path, 4, IntCodec{}, StringCodec{MaxLength: 8}, 16)
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	defer store.Close()
+	if _, err := NewWithStore[int, string](4, utils.IntComparator, store); err == nil || !strings.Contains(err.Error(), "corrupted") {
+		t.Errorf("Got %v expected an error", err)
+	}
+}
+
+func TestBTreeCountRange(t *testing.T) {
+	tree := NewWithIntComparator[int](3)
+	for i := 0; i < 20; i += 2 {
+		tree.Put(i, i)
+	}
+	for _, test := range rangeTests {
+		actualValue := tree.CountRange(test[0].(int), test[1].(int), test[2].(bool), test[3].(bool))
+		if expectedValue := test[4].(int); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+	}
+}
+
+func TestBTreeRemoveRange(t *testing.T) {
+	for _, test := range rangeTests {
+		tree := NewWithIntComparator[int](3)
+		for i := 0; i < 20; i += 2 {
+			tree.Put(i, i)
+		}
+		actualValue := tree.RemoveRange(test[0].(int), test[1].(int), test[2].(bool), test[3].(bool))
+		if expectedValue := test[4].(int); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+		if actualValue, expectedValue := tree.Size(), 10-test[4].(int); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got %v expected %v for %v", err, nil, test)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), test[5]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+	}
+}
+
+func TestBTreeRemoveRangeRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		tree := NewWithIntComparator[int](3 + i%4)
+		size := random.Intn(300)
+		for j := 0; j < size; j++ {
+			key := random.Intn(1000)
+			tree.Put(key, key)
+		}
+		lo, hi := random.Intn(1100)-50, random.Intn(1100)-50
+		if random.Intn(2) == 0 && lo < hi {
+			hi = lo + random.Intn(10)
+		}
+		loInclusive, hiInclusive := random.Intn(2) == 0, random.Intn(2) == 0
+		var expected []int
+		removed := 0
+		for _, key := range tree.Keys() {
+			if (key > lo || loInclusive && key == lo) && (key < hi || hiInclusive && key == hi) {
+				removed++
+			} else {
+				expected = append(expected, key)
+			}
+		}
+		if actualValue := tree.CountRange(lo, hi, loInclusive, hiInclusive); actualValue != removed {
+			t.Fatalf("Got %v expected %v", actualValue, removed)
+		}
+		if actualValue := tree.RemoveRange(lo, hi, loInclusive, hiInclusive); actualValue != removed {
+			t.Fatalf("Got %v expected %v", actualValue, removed)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("Got %v expected %v", err, nil)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(expected); actualValue != expectedValue {
+			t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := tree.Size(), len(expected); actualValue != expectedValue {
+			t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+// loadingStore counts the pages loaded from the store
+type loadingStore struct {
+	*MemoryStore[int, int]
+	loads int
+}
+
+func (store *loadingStore) Load(id PageID) (*Page[int, int], error) {
+	store.loads++
+	return store.MemoryStore.Load(id)
+}
+
+func TestBTreeRemoveRangeInPlace(t *testing.T) {
+	// Removing a range does not search every key from the root
+	comparisons := 0
+	comparator := func(a, b interface{}) int {
+		comparisons++
+		return utils.IntComparator(a, b)
+	}
+	tree, other := NewWith[int, int](3, comparator), NewWith[int, int](3, comparator)
+	for i := 0; i < 10000; i++ {
+		tree.Put(i, i)
+		other.Put(i, i)
+	}
+	comparisons = 0
+	tree.RemoveRange(2000, 3000, true, false)
+	removeRange := comparisons
+	comparisons = 0
+	for i := 2000; i < 3000; i++ {
+		other.Remove(i)
+	}
+	if removeRange*4 > comparisons*3 {
+		t.Errorf("Got %v comparisons expected less than three quarters of %v", removeRange, comparisons)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(other.Keys()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// Pages outside the range are not loaded
+	store := &loadingStore{MemoryStore: NewMemoryStore[int, int]()}
+	tree, _ = NewWithStore[int, int](4, utils.IntComparator, store)
+	for i := 0; i < 10000; i++ {
+		tree.Put(i, i)
+	}
+	if err := tree.Flush(); err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	tree, _ = NewWithStore[int, int](4, utils.IntComparator, store)
+	store.loads = 0
+	if actualValue := tree.RemoveRange(5000, 5100, true, false); actualValue != 100 {
+		t.Errorf("Got %v expected %v", actualValue, 100)
+	}
+	if actualValue, expectedValue := store.loads, store.Pages()/10; actualValue > expectedValue {
+		t.Errorf("Got %v loaded pages expected at most %v", actualValue, expectedValue)
+	}
+	if err := tree.Flush(); err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	tree, _ = NewWithStore[int, int](4, utils.IntComparator, store)
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue := tree.Size(); actualValue != 9900 {
+		t.Errorf("Got %v expected %v", actualValue, 9900)
+	}
+}
+
+// rangeTests holds lo, hi, loInclusive, hiInclusive, the number of keys in range and the keys remaining after
+// removing them from the keys 0, 2, ..., 18
+var rangeTests = [][]interface{}{
+	{4, 10, true, true, 4, "[0 2 12 14 16 18]"},
+	{4, 10, false, true, 3, "[0 2 4 12 14 16 18]"},
+	{4, 10, true, false, 3, "[0 2 10 12 14 16 18]"},
+	{4, 10, false, false, 2, "[0 2 4 10 12 14 16 18]"},
+	{3, 11, false, false, 4, "[0 2 12 14 16 18]"},
+	{-5, 100, true, true, 10, "[]"},
+	{0, 18, false, false, 8, "[0 18]"},
+	{10, 4, true, true, 0, "[0 2 4 6 8 10 12 14 16 18]"},
+	{10, 10, true, true, 1, "[0 2 4 6 8 12 14 16 18]"},
+	{10, 10, false, true, 0, "[0 2 4 6 8 10 12 14 16 18]"},
+	{19, 30, true, true, 0, "[0 2 4 6 8 10 12 14 16 18]"},
+	{-10, -1, true, true, 0, "[0 2 4 6 8 10 12 14 16 18]"},
+}
+
+func TestBTreeIteratorFrom(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	for i := 1; i <= 5; i++ {
+		tree.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	// key, inclusive, whether Next() finds an element and its key, whether Prev() finds an element and its key
+	tests := [][]interface{}{
+		{30, true, true, 30, true, 20},
+		{30, false, true, 40, true, 30},
+		{25, true, true, 30, true, 20},
+		{25, false, true, 30, true, 20},
+		{50, false, false, 0, true, 50},
+		{60, true, false, 0, true, 50},
+		{5, true, true, 10, false, 0},
+		{10, true, true, 10, false, 0},
+	}
+	for _, test := range tests {
+		it := tree.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Next(), test[2].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Key() != test[3].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[3], test)
+		}
+		it = tree.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Prev(), test[4].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Key() != test[5].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[5], test)
+		}
+	}
+
+	it := tree.IteratorFrom(20, true)
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50 50 40 30 20 10]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeIteratorSeek(t *testing.T) {
+	tree := NewWithIntComparator[string](3)
+	for i := 1; i <= 5; i++ {
+		tree.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	it := tree.Iterator()
+	assertIteratorAt := func(actualFound bool, expectedFound bool, expectedKey int) {
+		t.Helper()
+		if actualFound != expectedFound {
+			t.Errorf("Got %v expected %v", actualFound, expectedFound)
+		} else if actualFound && it.Key() != expectedKey {
+			t.Errorf("Got %v expected %v", it.Key(), expectedKey)
+		}
+	}
+	assertIteratorAt(it.SeekGE(35), true, 40)
+	assertIteratorAt(it.Next(), true, 50)
+	assertIteratorAt(it.SeekGE(40), true, 40)
+	assertIteratorAt(it.Prev(), true, 30)
+	assertIteratorAt(it.SeekGE(60), false, 0)
+	assertIteratorAt(it.Prev(), true, 50)
+	assertIteratorAt(it.SeekLE(35), true, 30)
+	assertIteratorAt(it.Prev(), true, 20)
+	assertIteratorAt(it.SeekLE(30), true, 30)
+	assertIteratorAt(it.Next(), true, 40)
+	assertIteratorAt(it.SeekLE(5), false, 0)
+	assertIteratorAt(it.Next(), true, 10)
+	it.Seek(20)
+	assertIteratorAt(it.Next(), true, 20)
+	it.Seek(21)
+	assertIteratorAt(it.Prev(), true, 20)
+	it.Seek(60)
+	assertIteratorAt(it.Next(), false, 0)
+	it.Seek(60)
+	assertIteratorAt(it.Prev(), true, 50)
+	it.Seek(0)
+	assertIteratorAt(it.Prev(), false, 0)
+}
+
 func benchmarkGet(b *testing.B, tree *Tree[int, struct{}], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
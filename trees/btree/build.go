@@ -0,0 +1,122 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/containers"
+	"math"
+)
+
+// BuildFromSorted replaces the contents of the tree with the given key-value pairs in O(n).
+// Keys must be strictly ascending under the tree's comparator and have the same length as values,
+// otherwise an error is returned and the tree is left untouched.
+//
+// The tree is built bottom-up, level by level. The fill factor in (0, 1] sets the targeted share of
+// the maximum number of entries per node, e.g. 1 packs nodes fully while 0.5 leaves room for later inserts.
+// Nodes never hold fewer than the minimum number of entries required by the order.
+func (tree *Tree[K, V]) BuildFromSorted(keys []K, values []V, fillFactor float64) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("btree: got %d keys and %d values", len(keys), len(values))
+	}
+	if !(fillFactor > 0 && fillFactor <= 1) {
+		return fmt.Errorf("btree: fill factor %v is not in (0, 1]", fillFactor)
+	}
+	for i := 1; i < len(keys); i++ {
+		if tree.Comparator(keys[i-1], keys[i]) >= 0 {
+			return fmt.Errorf("btree: key %v at index %d is not greater than previous key %v", keys[i], i, keys[i-1])
+		}
+	}
+	tree.Clear()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	entries := make([]*Entry[K, V], len(keys))
+	for i := range keys {
+		entries[i] = &Entry[K, V]{Key: keys[i], Value: values[i]}
+	}
+
+	// Each pass groups the entries of a level into nodes and promotes one separator entry between
+	// adjacent nodes, which in turn become the entries of the level above.
+	var children []*Node[K, V]
+	for {
+		sizes := tree.packedNodeSizes(len(entries), fillFactor)
+		nodes := make([]*Node[K, V], len(sizes))
+		separators := make([]*Entry[K, V], 0, len(sizes)-1)
+		e, c := 0, 0
+		for i, size := range sizes {
+			node := &Node[K, V]{Entries: append([]*Entry[K, V](nil), entries[e:e+size]...)}
+			e += size
+			if children != nil {
+				node.Children = append([]*Node[K, V](nil), children[c:c+size+1]...)
+				setParent(node.Children, node)
+				c += size + 1
+			} else {
+				node.Children = []*Node[K, V]{}
+			}
+			nodes[i] = node
+			if i < len(sizes)-1 {
+				separators = append(separators, entries[e])
+				e++
+			}
+		}
+		if len(nodes) == 1 {
+			tree.Root = nodes[0]
+			break
+		}
+		entries, children = separators, nodes
+	}
+	tree.size = len(keys)
+	return nil
+}
+
+// BuildFromSortedIterator replaces the contents of the tree with the key-value pairs read from the iterator in O(n).
+// The iterator is consumed by calling Next() until it returns false.
+// See BuildFromSorted for the requirements on keys and the meaning of the fill factor.
+func (tree *Tree[K, V]) BuildFromSortedIterator(iterator containers.ForwardIteratorWithKey[K, V], fillFactor float64) error {
+	var keys []K
+	var values []V
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+		values = append(values, iterator.Value())
+	}
+	return tree.BuildFromSorted(keys, values, fillFactor)
+}
+
+// packedNodeSizes splits n entries into nodes separated by a single entry each and returns the number of
+// entries per node, i.e. the returned sizes add up to n-len(sizes)+1.
+func (tree *Tree[K, V]) packedNodeSizes(n int, fillFactor float64) []int {
+	target := int(math.Round(fillFactor * float64(tree.maxEntries())))
+	if target < tree.minEntries() {
+		target = tree.minEntries()
+	}
+	if target < 1 {
+		target = 1
+	}
+
+	// Number of nodes such that every node holds between minEntries and maxEntries entries
+	count := int(math.Round(float64(n+1) / float64(target+1)))
+	if most := (n + 1) / (tree.minEntries() + 1); count > most {
+		count = most
+	}
+	if least := (n + tree.maxEntries() + 1) / (tree.maxEntries() + 1); count < least {
+		count = least
+	}
+	if count < 1 {
+		count = 1
+	}
+
+	sizes := make([]int, count)
+	total := n - (count - 1)
+	for i := range sizes {
+		sizes[i] = total / count
+		// favor right nodes to have more entries, same as when splitting
+		if i >= count-total%count {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
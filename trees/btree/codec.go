@@ -0,0 +1,72 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Codec encodes values into a fixed number of bytes, e.g. to store keys and values in the pages of a FileStore.
+type Codec[T any] interface {
+	// Size returns the number of bytes of every encoded value.
+	Size() int
+	// Encode writes the value into the buffer of Size() bytes.
+	Encode(buffer []byte, value T) error
+	// Decode reads a value from the buffer of Size() bytes.
+	Decode(buffer []byte) (T, error)
+}
+
+// Assert Codec implementation
+//var _ Codec[int] = IntCodec{}
+//var _ Codec[string] = StringCodec{}
+
+// IntCodec encodes ints as 8 bytes in big-endian order.
+type IntCodec struct{}
+
+// Size returns the number of bytes of every encoded value.
+func (IntCodec) Size() int {
+	return 8
+}
+
+// Encode writes the value into the buffer.
+func (IntCodec) Encode(buffer []byte, value int) error {
+	binary.BigEndian.PutUint64(buffer, uint64(value))
+	return nil
+}
+
+// Decode reads a value from the buffer.
+func (IntCodec) Decode(buffer []byte) (int, error) {
+	return int(binary.BigEndian.Uint64(buffer)), nil
+}
+
+// StringCodec encodes strings of up to MaxLength bytes, prefixed by their length as 4 bytes in big-endian order.
+type StringCodec struct {
+	MaxLength int
+}
+
+// Size returns the number of bytes of every encoded value.
+func (codec StringCodec) Size() int {
+	return 4 + codec.MaxLength
+}
+
+// Encode writes the value into the buffer, it fails if the value is longer than MaxLength bytes.
+func (codec StringCodec) Encode(buffer []byte, value string) error {
+	if len(value) > codec.MaxLength {
+		return fmt.Errorf("btree: string of length %d exceeds maximum length %d", len(value), codec.MaxLength)
+	}
+	binary.BigEndian.PutUint32(buffer, uint32(len(value)))
+	copy(buffer[4:], value)
+	return nil
+}
+
+// Decode reads a value from the buffer.
+func (codec StringCodec) Decode(buffer []byte) (string, error) {
+	length := int(binary.BigEndian.Uint32(buffer))
+	if length > codec.MaxLength {
+		return "", fmt.Errorf("btree: string of length %d exceeds maximum length %d", length, codec.MaxLength)
+	}
+	return string(buffer[4 : 4+length]), nil
+}
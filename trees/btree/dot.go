@@ -0,0 +1,49 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kcswag/kcgods/internal/dot"
+	"io"
+	"strings"
+)
+
+// ToDOT writes the nodes of the tree as a Graphviz digraph to w, loading them from the store if needed.
+// Every node is drawn as a record holding all of its keys, with the edge to each child leaving from the slot
+// between the two keys that separate it.
+func (tree *Tree[K, V]) ToDOT(w io.Writer) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph BTree {\n")
+	buffer.WriteString("\tnode [shape=record];\n")
+	id := 0
+	var output func(node *Node[K, V]) int
+	output = func(node *Node[K, V]) int {
+		nodeID := id
+		id++
+		fields := make([]string, 0, 2*len(node.Entries)+1)
+		for i, entry := range node.Entries {
+			if len(node.Children) > 0 {
+				fields = append(fields, fmt.Sprintf("<c%d>", i))
+			}
+			fields = append(fields, dot.EscapeRecord(fmt.Sprintf("%v", entry.Key)))
+		}
+		if len(node.Children) > 0 {
+			fields = append(fields, fmt.Sprintf("<c%d>", len(node.Entries)))
+		}
+		buffer.WriteString(fmt.Sprintf("\tn%d [label=\"%s\"];\n", nodeID, strings.Join(fields, "|")))
+		for i, child := range node.Children {
+			buffer.WriteString(fmt.Sprintf("\tn%d:c%d -> n%d;\n", nodeID, i, output(child.load())))
+		}
+		return nodeID
+	}
+	if tree.Root != nil {
+		output(tree.Root)
+	}
+	buffer.WriteString("}\n")
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
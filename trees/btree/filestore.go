@@ -0,0 +1,430 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// File layout: a header followed by pages of a fixed size, page n starts at headerSize+(n-1)*pageSize.
+//
+// The header holds the parameters of the file and two meta slots, commits alternate between the slots,
+// so a torn write of a slot leaves the other one with the previous commit.
+// Every page starts with a checksum, its kind and the number of entries (or free page IDs).
+const (
+	fileMagic      = "KCGBTREE"
+	fileVersion    = 1
+	headerSize     = 256
+	metaOffset     = 64
+	metaSize       = 64
+	pageHeaderSize = 16
+)
+
+const (
+	leafPage byte = iota + 1
+	internalPage
+	freelistPage
+)
+
+// meta describes a commit
+type meta struct {
+	seq       uint64 // commit sequence number, the slot with the highest valid sequence number is the current one
+	root      uint64 // root page
+	size      uint64 // number of keys in the tree
+	pageCount uint64 // number of pages in the file
+	freelist  uint64 // first page of the free list
+	freeCount uint64 // number of free pages
+}
+
+func (m meta) encode() []byte {
+	buffer := make([]byte, metaSize)
+	for i, value := range []uint64{m.seq, m.root, m.size, m.pageCount, m.freelist, m.freeCount} {
+		binary.BigEndian.PutUint64(buffer[8*i:], value)
+	}
+	binary.BigEndian.PutUint32(buffer[48:], crc32.ChecksumIEEE(buffer[:48]))
+	return buffer
+}
+
+func decodeMeta(buffer []byte) (m meta, valid bool) {
+	if binary.BigEndian.Uint32(buffer[48:]) != crc32.ChecksumIEEE(buffer[:48]) {
+		return m, false
+	}
+	values := []*uint64{&m.seq, &m.root, &m.size, &m.pageCount, &m.freelist, &m.freeCount}
+	for i, value := range values {
+		*value = binary.BigEndian.Uint64(buffer[8*i:])
+	}
+	return m, m.seq > 0
+}
+
+// Assert NodeStore implementation
+//var _ NodeStore[int, int] = (*FileStore[int, int])(nil)
+
+// FileStore is a NodeStore keeping pages of a fixed size in a file, keys and values are encoded with codecs.
+//
+// Commits are atomic: pages are written copy-on-write and a commit only becomes visible once its meta data
+// has been written and synced, so after a crash the file is opened with the last complete commit.
+// Recently used pages are kept decoded in a cache.
+type FileStore[K, V any] struct {
+	file       *os.File
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+	order      int
+	pageSize   int
+	meta       meta     // last commit
+	pageCount  uint64   // number of pages in the file including the ones written since the last commit
+	free       []PageID // pages that can be allocated
+	freelist   []PageID // pages holding the free list of the last commit
+	cache      *pageCache[K, V]
+}
+
+// OpenFileStore opens the store in the file at the given path or creates the file if it does not exist or is empty.
+// The order must be the order of the tree and match the one the file was created with, as must the sizes of the codecs.
+// Up to cacheSize decoded pages are kept in memory.
+func OpenFileStore[K, V any](path string, order int, keyCodec Codec[K], valueCodec Codec[V], cacheSize int) (*FileStore[K, V], error) {
+	if order < 3 {
+		panic("Invalid order, should be at least 3")
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	store := &FileStore[K, V]{
+		file:       file,
+		keyCodec:   keyCodec,
+		valueCodec: valueCodec,
+		order:      order,
+		pageSize:   pageHeaderSize + (order-1)*(keyCodec.Size()+valueCodec.Size()) + order*8,
+		cache:      newPageCache[K, V](cacheSize),
+	}
+	info, err := file.Stat()
+	if err == nil {
+		if info.Size() == 0 {
+			err = store.create()
+		} else {
+			err = store.open()
+		}
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// create writes the header of an empty store.
+func (store *FileStore[K, V]) create() error {
+	header := make([]byte, headerSize)
+	copy(header, fileMagic)
+	for i, value := range []int{fileVersion, store.order, store.keyCodec.Size(), store.valueCodec.Size(), store.pageSize} {
+		binary.BigEndian.PutUint32(header[8+4*i:], uint32(value))
+	}
+	store.meta = meta{seq: 1}
+	copy(header[metaOffset+metaSize:], store.meta.encode())
+	if _, err := store.file.WriteAt(header, 0); err != nil {
+		return err
+	}
+	return store.file.Sync()
+}
+
+// open reads the header and the free list of the last commit.
+func (store *FileStore[K, V]) open() error {
+	header := make([]byte, headerSize)
+	if _, err := store.file.ReadAt(header, 0); err != nil {
+		return fmt.Errorf("btree: cannot read header of %s: %w", store.file.Name(), err)
+	}
+	if string(header[:8]) != fileMagic {
+		return fmt.Errorf("btree: %s is not a B-tree file", store.file.Name())
+	}
+	names := []string{"version", "order", "key size", "value size", "page size"}
+	for i, expected := range []int{fileVersion, store.order, store.keyCodec.Size(), store.valueCodec.Size(), store.pageSize} {
+		if actual := int(binary.BigEndian.Uint32(header[8+4*i:])); actual != expected {
+			return fmt.Errorf("btree: %s has %s %d, expected %d", store.file.Name(), names[i], actual, expected)
+		}
+	}
+
+	var found bool
+	for slot := 0; slot < 2; slot++ {
+		offset := metaOffset + slot*metaSize
+		if m, valid := decodeMeta(header[offset : offset+metaSize]); valid && m.seq > store.meta.seq {
+			store.meta, found = m, true
+		}
+	}
+	if !found {
+		return fmt.Errorf("btree: %s has no valid commit", store.file.Name())
+	}
+	store.pageCount = store.meta.pageCount
+
+	for id := PageID(store.meta.freelist); id != 0; {
+		buffer, err := store.read(id, freelistPage)
+		if err != nil {
+			return err
+		}
+		store.freelist = append(store.freelist, id)
+		count := int(binary.BigEndian.Uint32(buffer[8:]))
+		for i := 0; i < count; i++ {
+			store.free = append(store.free, PageID(binary.BigEndian.Uint64(buffer[pageHeaderSize+8+8*i:])))
+		}
+		id = PageID(binary.BigEndian.Uint64(buffer[pageHeaderSize:]))
+	}
+	if uint64(len(store.free)) != store.meta.freeCount {
+		return fmt.Errorf("btree: free list of %s holds %d pages, expected %d", store.file.Name(), len(store.free), store.meta.freeCount)
+	}
+	return nil
+}
+
+// Load returns the page with the given ID.
+func (store *FileStore[K, V]) Load(id PageID) (*Page[K, V], error) {
+	if page, found := store.cache.get(id); found {
+		return page, nil
+	}
+	buffer, err := store.read(id, 0)
+	if err != nil {
+		return nil, err
+	}
+	count := int(binary.BigEndian.Uint32(buffer[8:]))
+	if count > store.order-1 {
+		return nil, fmt.Errorf("btree: page %d holds %d entries, at most %d fit", id, count, store.order-1)
+	}
+	page := &Page[K, V]{Entries: make([]Entry[K, V], count)}
+	keySize, valueSize := store.keyCodec.Size(), store.valueCodec.Size()
+	offset := pageHeaderSize
+	for i := range page.Entries {
+		if page.Entries[i].Key, err = store.keyCodec.Decode(buffer[offset : offset+keySize]); err != nil {
+			return nil, err
+		}
+		offset += keySize
+		if page.Entries[i].Value, err = store.valueCodec.Decode(buffer[offset : offset+valueSize]); err != nil {
+			return nil, err
+		}
+		offset += valueSize
+	}
+	if buffer[4] == internalPage {
+		offset = pageHeaderSize + (store.order-1)*(keySize+valueSize)
+		page.Children = make([]PageID, count+1)
+		for i := range page.Children {
+			page.Children[i] = PageID(binary.BigEndian.Uint64(buffer[offset+8*i:]))
+		}
+	}
+	store.cache.put(id, page)
+	return page, nil
+}
+
+// Store writes the page with the given ID.
+func (store *FileStore[K, V]) Store(id PageID, page *Page[K, V]) error {
+	if id == 0 || uint64(id) > store.pageCount {
+		return fmt.Errorf("btree: page %d is not allocated", id)
+	}
+	if len(page.Entries) > store.order-1 {
+		return fmt.Errorf("btree: page %d holds %d entries, at most %d fit", id, len(page.Entries), store.order-1)
+	}
+	if len(page.Children) > 0 && len(page.Children) != len(page.Entries)+1 {
+		return fmt.Errorf("btree: page %d has %d children for %d entries", id, len(page.Children), len(page.Entries))
+	}
+	buffer := make([]byte, store.pageSize)
+	buffer[4] = leafPage
+	if len(page.Children) > 0 {
+		buffer[4] = internalPage
+	}
+	binary.BigEndian.PutUint32(buffer[8:], uint32(len(page.Entries)))
+	keySize, valueSize := store.keyCodec.Size(), store.valueCodec.Size()
+	offset := pageHeaderSize
+	for _, entry := range page.Entries {
+		if err := store.keyCodec.Encode(buffer[offset:offset+keySize], entry.Key); err != nil {
+			return err
+		}
+		offset += keySize
+		if err := store.valueCodec.Encode(buffer[offset:offset+valueSize], entry.Value); err != nil {
+			return err
+		}
+		offset += valueSize
+	}
+	offset = pageHeaderSize + (store.order-1)*(keySize+valueSize)
+	for i, child := range page.Children {
+		binary.BigEndian.PutUint64(buffer[offset+8*i:], uint64(child))
+	}
+	if err := store.write(id, buffer); err != nil {
+		return err
+	}
+	store.cache.put(id, page)
+	return nil
+}
+
+// Allocate reserves a page and returns its ID, free pages are reused before the file grows.
+func (store *FileStore[K, V]) Allocate() (PageID, error) {
+	if n := len(store.free); n > 0 {
+		id := store.free[n-1]
+		store.free = store.free[:n-1]
+		return id, nil
+	}
+	store.pageCount++
+	return PageID(store.pageCount), nil
+}
+
+// Root returns the root page and the size of the committed tree.
+func (store *FileStore[K, V]) Root() (PageID, int, error) {
+	return PageID(store.meta.root), int(store.meta.size), nil
+}
+
+// Commit makes the tree with the given root page and size the committed tree and then releases the freed pages.
+//
+// The free list is written first, then the file is synced before the meta data is written into the slot
+// of the older commit and synced in turn. The freed pages and the pages of the previous free list become
+// available for allocation afterwards.
+func (store *FileStore[K, V]) Commit(root PageID, size int, freed []PageID) error {
+	for _, id := range freed {
+		if id == 0 || uint64(id) > store.pageCount {
+			return fmt.Errorf("btree: page %d is not allocated", id)
+		}
+	}
+
+	// The free list is written to pages that are free already, the pages freed by this commit are still in use
+	// by the last commit until it is replaced.
+	reusable := append([]PageID(nil), store.free...)
+	pageCount := store.pageCount
+	perPage := (store.pageSize - pageHeaderSize - 8) / 8
+	var freelist []PageID
+	for len(freelist)*perPage < len(reusable)+len(freed)+len(store.freelist) {
+		if n := len(reusable); n > 0 {
+			freelist = append(freelist, reusable[n-1])
+			reusable = reusable[:n-1]
+		} else {
+			pageCount++
+			freelist = append(freelist, PageID(pageCount))
+		}
+	}
+	free := make([]PageID, 0, len(reusable)+len(freed)+len(store.freelist))
+	free = append(append(append(free, reusable...), freed...), store.freelist...)
+	for i, id := range freelist {
+		buffer := make([]byte, store.pageSize)
+		buffer[4] = freelistPage
+		ids := free[i*perPage:]
+		if len(ids) > perPage {
+			ids = ids[:perPage]
+		}
+		binary.BigEndian.PutUint32(buffer[8:], uint32(len(ids)))
+		if i+1 < len(freelist) {
+			binary.BigEndian.PutUint64(buffer[pageHeaderSize:], uint64(freelist[i+1]))
+		}
+		for j, free := range ids {
+			binary.BigEndian.PutUint64(buffer[pageHeaderSize+8+8*j:], uint64(free))
+		}
+		if err := store.write(id, buffer); err != nil {
+			return err
+		}
+	}
+	if err := store.file.Sync(); err != nil {
+		return err
+	}
+
+	m := meta{seq: store.meta.seq + 1, root: uint64(root), size: uint64(size), pageCount: pageCount, freeCount: uint64(len(free))}
+	if len(freelist) > 0 {
+		m.freelist = uint64(freelist[0])
+	}
+	if _, err := store.file.WriteAt(m.encode(), int64(metaOffset+int(m.seq%2)*metaSize)); err != nil {
+		return err
+	}
+	if err := store.file.Sync(); err != nil {
+		return err
+	}
+	store.meta = m
+	store.pageCount = pageCount
+	store.free, store.freelist = free, freelist
+	for _, id := range freed {
+		store.cache.remove(id)
+	}
+	return nil
+}
+
+// Close closes the file, changes since the last commit are discarded.
+func (store *FileStore[K, V]) Close() error {
+	return store.file.Close()
+}
+
+// read reads the page with the given ID and verifies its checksum and kind, zero accepts leaves and internal nodes.
+func (store *FileStore[K, V]) read(id PageID, kind byte) ([]byte, error) {
+	if id == 0 || uint64(id) > store.pageCount {
+		return nil, fmt.Errorf("btree: page %d is out of range", id)
+	}
+	buffer := make([]byte, store.pageSize)
+	if _, err := store.file.ReadAt(buffer, store.offset(id)); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("btree: cannot read page %d: %w", id, err)
+	}
+	if binary.BigEndian.Uint32(buffer) != crc32.ChecksumIEEE(buffer[4:]) {
+		return nil, fmt.Errorf("btree: page %d is corrupted", id)
+	}
+	if actual := buffer[4]; actual != kind && (kind != 0 || (actual != leafPage && actual != internalPage)) {
+		return nil, fmt.Errorf("btree: page %d has unexpected kind %d", id, actual)
+	}
+	return buffer, nil
+}
+
+// write computes the checksum of the page and writes it.
+func (store *FileStore[K, V]) write(id PageID, buffer []byte) error {
+	binary.BigEndian.PutUint32(buffer, crc32.ChecksumIEEE(buffer[4:]))
+	_, err := store.file.WriteAt(buffer, store.offset(id))
+	return err
+}
+
+func (store *FileStore[K, V]) offset(id PageID) int64 {
+	return headerSize + int64(id-1)*int64(store.pageSize)
+}
+
+// pageCache keeps the most recently used pages, evicting the least recently used one when full
+type pageCache[K, V any] struct {
+	capacity int
+	items    map[PageID]*list.Element
+	order    *list.List // most recently used first
+}
+
+type cacheItem[K, V any] struct {
+	id   PageID
+	page *Page[K, V]
+}
+
+func newPageCache[K, V any](capacity int) *pageCache[K, V] {
+	return &pageCache[K, V]{capacity: capacity, items: make(map[PageID]*list.Element), order: list.New()}
+}
+
+func (cache *pageCache[K, V]) get(id PageID) (*Page[K, V], bool) {
+	element, found := cache.items[id]
+	if !found {
+		return nil, false
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*cacheItem[K, V]).page, true
+}
+
+func (cache *pageCache[K, V]) put(id PageID, page *Page[K, V]) {
+	if cache.capacity <= 0 {
+		return
+	}
+	if element, found := cache.items[id]; found {
+		element.Value.(*cacheItem[K, V]).page = page
+		cache.order.MoveToFront(element)
+		return
+	}
+	cache.items[id] = cache.order.PushFront(&cacheItem[K, V]{id: id, page: page})
+	if cache.order.Len() > cache.capacity {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.items, oldest.Value.(*cacheItem[K, V]).id)
+	}
+}
+
+func (cache *pageCache[K, V]) remove(id PageID) {
+	if element, found := cache.items[id]; found {
+		cache.order.Remove(element)
+		delete(cache.items, id)
+	}
+}
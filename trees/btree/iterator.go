@@ -8,7 +8,7 @@ package btree
 //var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
 
 // Iterator holding the iterator's state
-type Iterator[K, V comparable] struct {
+type Iterator[K, V any] struct {
 	tree     *Tree[K, V]
 	node     *Node[K, V]
 	entry    *Entry[K, V]
@@ -18,7 +18,7 @@ type Iterator[K, V comparable] struct {
 type position byte
 
 const (
-	begin, between, end position = 0, 1, 2
+	begin, between, end, before position = 0, 1, 2, 3
 )
 
 // Iterator returns a stateful iterator whose elements are key/value pairs.
@@ -26,6 +26,16 @@ func (tree *Tree[K, V]) Iterator() Iterator[K, V] {
 	return Iterator[K, V]{tree: tree, node: nil, position: begin}
 }
 
+// IteratorFrom returns a stateful iterator positioned right before the first element whose key is bigger than
+// (or equal to, if inclusive) the given key, or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the element before it.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) IteratorFrom(key K, inclusive bool) Iterator[K, V] {
+	iterator, found := tree.rangeStart(key, inclusive)
+	iterator.seek(found)
+	return iterator
+}
+
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
 // If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
@@ -35,6 +45,10 @@ func (iterator *Iterator[K, V]) Next() bool {
 	if iterator.position == end {
 		goto end
 	}
+	// If right before an element, return that element
+	if iterator.position == before {
+		goto between
+	}
 	// If at beginning, get the left-most entry in the tree
 	if iterator.position == begin {
 		left := iterator.tree.Left()
@@ -50,10 +64,10 @@ func (iterator *Iterator[K, V]) Next() bool {
 		e, _ := iterator.tree.search(iterator.node, iterator.entry.Key)
 		// Try to go down to the child right of the current entry
 		if e+1 < len(iterator.node.Children) {
-			iterator.node = iterator.node.Children[e+1]
+			iterator.node = iterator.node.Children[e+1].load()
 			// Try to go down to the child left of the current node
 			for len(iterator.node.Children) > 0 {
-				iterator.node = iterator.node.Children[0]
+				iterator.node = iterator.node.Children[0].load()
 			}
 			// Return the left-most entry
 			iterator.entry = iterator.node.Entries[0]
@@ -109,10 +123,10 @@ func (iterator *Iterator[K, V]) Prev() bool {
 		e, _ := iterator.tree.search(iterator.node, iterator.entry.Key)
 		// Try to go down to the child left of the current entry
 		if e < len(iterator.node.Children) {
-			iterator.node = iterator.node.Children[e]
+			iterator.node = iterator.node.Children[e].load()
 			// Try to go down to the child right of the current node
 			for len(iterator.node.Children) > 0 {
-				iterator.node = iterator.node.Children[len(iterator.node.Children)-1]
+				iterator.node = iterator.node.Children[len(iterator.node.Children)-1].load()
 			}
 			// Return the right-most entry
 			iterator.entry = iterator.node.Entries[len(iterator.node.Entries)-1]
@@ -145,6 +159,50 @@ between:
 	return true
 }
 
+// Seek moves the iterator right before the first element whose key is equal or bigger than the given key,
+// or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the last element whose key is smaller than the given key.
+// Takes O(log n) time.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) Seek(key K) {
+	var found bool
+	*iterator, found = iterator.tree.rangeStart(key, true)
+	iterator.seek(found)
+}
+
+// SeekGE moves the iterator to the first element whose key is equal or bigger than the given key and returns true
+// if there was such an element, whose key and value can then be retrieved by Key() and Value().
+// Otherwise the iterator is moved past the last element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) SeekGE(key K) bool {
+	var found bool
+	if *iterator, found = iterator.tree.rangeStart(key, true); !found {
+		iterator.End()
+	}
+	return found
+}
+
+// SeekLE moves the iterator to the last element whose key is equal or smaller than the given key and returns true
+// if there was such an element, whose key and value can then be retrieved by Key() and Value().
+// Otherwise the iterator is moved before the first element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) SeekLE(key K) bool {
+	var found bool
+	if *iterator, found = iterator.tree.rangeEnd(key, true); !found {
+		iterator.Begin()
+	}
+	return found
+}
+
+// seek moves the iterator from the found element to right before it, or past the last element if none was found.
+func (iterator *Iterator[K, V]) seek(found bool) {
+	if !found {
+		iterator.End()
+		return
+	}
+	iterator.position = before
+}
+
 // Value returns the current element's value.
 // Does not modify the state of the iterator.
 func (iterator *Iterator[K, V]) Value() V {
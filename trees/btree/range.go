@@ -0,0 +1,112 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+// CountRange returns the number of keys between lo and hi, each bound is included if its inclusive flag is set.
+// Takes O(log n + k) time for k counted keys.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) CountRange(lo K, hi K, loInclusive bool, hiInclusive bool) int {
+	count := 0
+	for it, ok := tree.rangeStart(lo, loInclusive); ok && tree.beforeRangeEnd(it.Key(), hi, hiInclusive); ok = it.Next() {
+		count++
+	}
+	return count
+}
+
+// RemoveRange removes all keys between lo and hi, each bound is included if its inclusive flag is set,
+// and returns the number of removed keys.
+//
+// The first key is located with a single search, then the entries are removed one by one while walking the tree
+// in-order. Rebalancing only moves entries next to the removed one, so the next key is searched upwards from the
+// node the entry was removed from instead of from the root.
+// Removing k keys takes O(log n + k) amortized time for a fixed order, pages of a store-backed tree outside
+// the range are not loaded.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) RemoveRange(lo K, hi K, loInclusive bool, hiInclusive bool) int {
+	count := 0
+	it, ok := tree.rangeStart(lo, loInclusive)
+	for ok && tree.beforeRangeEnd(it.Key(), hi, hiInclusive) {
+		node, entry := it.node, it.entry
+		ok = it.Next()
+		index, _ := tree.search(node, entry.Key)
+		leaf := tree.delete(node, index)
+		tree.size--
+		count++
+		if ok {
+			it.node, _, _ = tree.searchFrom(leaf, it.entry.Key)
+		}
+	}
+	return count
+}
+
+// searchFrom searches the key starting at the node, which has to be part of the tree and whose subtree must not
+// hold keys bigger than the key's predecessor, i.e. the key is either within the subtree or to the right of it.
+// It climbs up to the first ancestor whose subtree spans the key and searches down from there.
+func (tree *Tree[K, V]) searchFrom(node *Node[K, V], key K) (*Node[K, V], int, bool) {
+	for node.Parent != nil {
+		parent := node.Parent
+		index, found := tree.search(parent, key)
+		if found {
+			return parent, index, true
+		}
+		// The separator right of the node bounds its subtree, the rightmost child is bounded further up
+		if index < len(parent.Entries) && parent.Children[index] == node {
+			break
+		}
+		node = parent
+	}
+	return tree.searchRecursively(node, key)
+}
+
+// rangeStart returns an iterator at the smallest key greater than (or equal to, if inclusive) lo,
+// second return parameter is false if there is no such key.
+func (tree *Tree[K, V]) rangeStart(lo K, inclusive bool) (Iterator[K, V], bool) {
+	iterator := Iterator[K, V]{tree: tree, position: between}
+	for node := tree.Root; node != nil; {
+		index, found := tree.search(node, lo)
+		if found {
+			if inclusive {
+				iterator.node, iterator.entry = node, node.Entries[index]
+				return iterator, true
+			}
+			index++
+		}
+		if index < len(node.Entries) {
+			iterator.node, iterator.entry = node, node.Entries[index]
+		}
+		if tree.isLeaf(node) {
+			break
+		}
+		node = node.Children[index].load()
+	}
+	return iterator, iterator.node != nil
+}
+
+// rangeEnd returns an iterator at the biggest key smaller than (or equal to, if inclusive) hi,
+// second return parameter is false if there is no such key.
+func (tree *Tree[K, V]) rangeEnd(hi K, inclusive bool) (Iterator[K, V], bool) {
+	iterator := Iterator[K, V]{tree: tree, position: between}
+	for node := tree.Root; node != nil; {
+		index, found := tree.search(node, hi)
+		if found && inclusive {
+			iterator.node, iterator.entry = node, node.Entries[index]
+			return iterator, true
+		}
+		if index > 0 {
+			iterator.node, iterator.entry = node, node.Entries[index-1]
+		}
+		if tree.isLeaf(node) {
+			break
+		}
+		node = node.Children[index].load()
+	}
+	return iterator, iterator.node != nil
+}
+
+// beforeRangeEnd returns true if the key is smaller than (or equal to, if inclusive) hi.
+func (tree *Tree[K, V]) beforeRangeEnd(key K, hi K, inclusive bool) bool {
+	compare := tree.Comparator(key, hi)
+	return compare < 0 || (compare == 0 && inclusive)
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/utils"
+	"sort"
+)
+
+// PageID identifies a page within a NodeStore, the zero value refers to no page.
+type PageID uint64
+
+// Page is the stored representation of a node, children are referenced by their page IDs.
+// Leaves have no children.
+type Page[K, V any] struct {
+	Entries  []Entry[K, V]
+	Children []PageID
+}
+
+// NodeStore holds the pages of a B-tree.
+//
+// Pages are written copy-on-write: a tree never stores into a page that is referenced by the committed tree,
+// it allocates a new page instead and hands the replaced one to the next Commit, which releases it only once the new
+// root is committed. So the committed tree stays intact and readable until then, even if committing fails.
+// Callers must not modify pages returned by Load.
+type NodeStore[K, V any] interface {
+	// Load returns the page with the given ID.
+	Load(id PageID) (*Page[K, V], error)
+	// Store writes the page with the given ID, which must have been allocated before.
+	Store(id PageID, page *Page[K, V]) error
+	// Allocate reserves a page and returns its ID.
+	Allocate() (PageID, error)
+	// Root returns the root page and the size of the committed tree, the root is zero for an empty tree.
+	Root() (root PageID, size int, err error)
+	// Commit makes the tree with the given root page and size the committed tree and then releases the freed pages,
+	// which are referenced by the previously committed tree only. Released pages can be allocated again.
+	// If committing fails, the previously committed tree and its pages are left as they were.
+	Commit(root PageID, size int, freed []PageID) error
+}
+
+// Assert NodeStore implementation
+//var _ NodeStore[int, int] = (*MemoryStore[int, int])(nil)
+
+// MemoryStore is a NodeStore keeping all pages in memory.
+type MemoryStore[K, V any] struct {
+	pages map[PageID]*Page[K, V]
+	last  PageID   // highest page ID handed out so far
+	free  []PageID // pages that can be allocated
+	root  PageID
+	size  int
+}
+
+// NewMemoryStore instantiates an empty in-memory node store.
+func NewMemoryStore[K, V any]() *MemoryStore[K, V] {
+	return &MemoryStore[K, V]{pages: make(map[PageID]*Page[K, V])}
+}
+
+// Load returns the page with the given ID.
+func (store *MemoryStore[K, V]) Load(id PageID) (*Page[K, V], error) {
+	page, found := store.pages[id]
+	if !found || page == nil {
+		return nil, fmt.Errorf("btree: page %d not found", id)
+	}
+	return page, nil
+}
+
+// Store writes a copy of the page with the given ID.
+func (store *MemoryStore[K, V]) Store(id PageID, page *Page[K, V]) error {
+	if _, found := store.pages[id]; !found {
+		return fmt.Errorf("btree: page %d is not allocated", id)
+	}
+	store.pages[id] = &Page[K, V]{
+		Entries:  append([]Entry[K, V](nil), page.Entries...),
+		Children: append([]PageID(nil), page.Children...),
+	}
+	return nil
+}
+
+// Allocate reserves a page and returns its ID.
+func (store *MemoryStore[K, V]) Allocate() (PageID, error) {
+	var id PageID
+	if n := len(store.free); n > 0 {
+		id = store.free[n-1]
+		store.free = store.free[:n-1]
+	} else {
+		store.last++
+		id = store.last
+	}
+	store.pages[id] = nil
+	return id, nil
+}
+
+// Root returns the root page and the size of the committed tree.
+func (store *MemoryStore[K, V]) Root() (PageID, int, error) {
+	return store.root, store.size, nil
+}
+
+// Commit makes the tree with the given root page and size the committed tree and then releases the freed pages.
+func (store *MemoryStore[K, V]) Commit(root PageID, size int, freed []PageID) error {
+	for _, id := range freed {
+		if _, found := store.pages[id]; !found {
+			return fmt.Errorf("btree: page %d is not allocated", id)
+		}
+	}
+	store.root, store.size = root, size
+	for _, id := range freed {
+		delete(store.pages, id)
+	}
+	store.free = append(store.free, freed...)
+	return nil
+}
+
+// Pages returns the number of allocated pages.
+func (store *MemoryStore[K, V]) Pages() int {
+	return len(store.pages)
+}
+
+// NewWithStore instantiates a B-tree with the order (maximum number of children) and a custom key comparator,
+// whose nodes are kept in the given store. The tree committed to the store, if any, is opened.
+//
+// Nodes are loaded from the store on demand and modifications are kept in memory until Flush writes them back.
+// A tree created with NewWith keeps all of its nodes in memory and does not need a store.
+// Methods of the tree panic if a page cannot be loaded from the store.
+func NewWithStore[K, V any](order int, comparator utils.Comparator, store NodeStore[K, V]) (*Tree[K, V], error) {
+	tree := NewWith[K, V](order, comparator)
+	tree.store = store
+	tree.seen = make(map[PageID][]PageID)
+	root, size, err := store.Root()
+	if err != nil {
+		return nil, err
+	}
+	tree.committed = root
+	tree.size = size
+	if root != 0 {
+		tree.Root = &Node[K, V]{page: root, stub: tree}
+		if err := tree.Root.read(); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// Flush writes all nodes modified since the last flush to the tree's store and commits them with the size of the tree.
+//
+// Modified nodes are written to newly allocated pages and the pages they replace are released by the store
+// once the new root is committed, so the previously committed tree stays intact if flushing fails or is interrupted.
+// Afterwards only the root remains in memory, all other nodes are loaded again on demand,
+// i.e. nodes and iterators obtained before flushing must not be used anymore.
+// Flush does nothing for trees without store.
+func (tree *Tree[K, V]) Flush() error {
+	if tree.store == nil {
+		return nil
+	}
+
+	// Pages that are not referenced anymore once the tree is written
+	obsolete := make(map[PageID]struct{})
+	for id, children := range tree.seen {
+		obsolete[id] = struct{}{}
+		for _, child := range children {
+			obsolete[child] = struct{}{}
+		}
+	}
+	if tree.committed != 0 {
+		obsolete[tree.committed] = struct{}{}
+		if tree.cleared {
+			if err := tree.collect(tree.committed, obsolete); err != nil {
+				return err
+			}
+		}
+	}
+
+	var root PageID
+	if tree.Root != nil {
+		var err error
+		if root, err = tree.flush(tree.Root, obsolete); err != nil {
+			return err
+		}
+		delete(obsolete, root)
+	}
+
+	freed := make([]PageID, 0, len(obsolete))
+	for id := range obsolete {
+		freed = append(freed, id)
+	}
+	sort.Slice(freed, func(i, j int) bool { return freed[i] < freed[j] })
+	if err := tree.store.Commit(root, tree.size, freed); err != nil {
+		return err
+	}
+	tree.committed = root
+	tree.cleared = false
+
+	// Release all nodes below the root
+	tree.seen = make(map[PageID][]PageID)
+	if tree.Root != nil {
+		for i, child := range tree.Root.Children {
+			tree.Root.Children[i] = &Node[K, V]{Parent: tree.Root, page: child.page, stub: tree}
+		}
+		tree.seen[root] = tree.Root.storedChildren
+	}
+	return nil
+}
+
+// flush writes the modified nodes of the subtree bottom-up and returns the page of the subtree's root.
+// Pages that are still referenced are removed from the obsolete pages.
+func (tree *Tree[K, V]) flush(node *Node[K, V], obsolete map[PageID]struct{}) (PageID, error) {
+	if node.stub != nil {
+		return node.page, nil
+	}
+	children := make([]PageID, len(node.Children))
+	for i, child := range node.Children {
+		id, err := tree.flush(child, obsolete)
+		if err != nil {
+			return 0, err
+		}
+		children[i] = id
+		delete(obsolete, id)
+	}
+	if node.page != 0 && !node.modified(children) {
+		return node.page, nil
+	}
+
+	page := &Page[K, V]{Entries: make([]Entry[K, V], len(node.Entries)), Children: children}
+	for i, entry := range node.Entries {
+		page.Entries[i] = *entry
+	}
+	id, err := tree.store.Allocate()
+	if err != nil {
+		return 0, err
+	}
+	if err := tree.store.Store(id, page); err != nil {
+		return 0, err
+	}
+	node.page = id
+	node.stored = append([]*Entry[K, V](nil), node.Entries...)
+	node.storedChildren = children
+	return id, nil
+}
+
+// collect adds the pages of the committed subtree to the given pages.
+func (tree *Tree[K, V]) collect(id PageID, pages map[PageID]struct{}) error {
+	page, err := tree.store.Load(id)
+	if err != nil {
+		return err
+	}
+	pages[id] = struct{}{}
+	for _, child := range page.Children {
+		if err := tree.collect(child, pages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// modified returns true if the node's entries or children differ from its stored page.
+// Entries are replaced rather than updated in place, so comparing their pointers suffices.
+func (node *Node[K, V]) modified(children []PageID) bool {
+	if len(node.Entries) != len(node.stored) || len(children) != len(node.storedChildren) {
+		return true
+	}
+	for i, entry := range node.Entries {
+		if entry != node.stored[i] {
+			return true
+		}
+	}
+	for i, child := range children {
+		if child != node.storedChildren[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads the node from its page if it has not been loaded yet and returns the node.
+// It panics if the page cannot be loaded.
+func (node *Node[K, V]) load() *Node[K, V] {
+	if node.stub != nil {
+		if err := node.read(); err != nil {
+			panic(err)
+		}
+	}
+	return node
+}
+
+// read loads the entries of the node from its page, its children remain to be loaded.
+func (node *Node[K, V]) read() error {
+	tree := node.stub
+	page, err := tree.store.Load(node.page)
+	if err != nil {
+		return fmt.Errorf("btree: cannot load page %d: %w", node.page, err)
+	}
+	node.Entries = make([]*Entry[K, V], len(page.Entries))
+	for i := range page.Entries {
+		entry := page.Entries[i]
+		node.Entries[i] = &entry
+	}
+	node.Children = make([]*Node[K, V], len(page.Children))
+	for i, id := range page.Children {
+		node.Children[i] = &Node[K, V]{Parent: node, page: id, stub: tree}
+	}
+	node.stored = append([]*Entry[K, V](nil), node.Entries...)
+	node.storedChildren = append([]PageID(nil), page.Children...)
+	node.stub = nil
+	tree.seen[node.page] = node.storedChildren
+	return nil
+}
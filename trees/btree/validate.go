@@ -0,0 +1,104 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import "fmt"
+
+// Validate checks the invariants of the tree and returns an error describing the first violation found, or nil.
+//
+// It checks that keys are ordered under the comparator within and across nodes, that parent pointers are consistent,
+// that every node holds between the minimum and maximum number of entries for the tree's order,
+// that internal nodes have one more child than entries, that all leaves appear at the same depth
+// and that the cached size matches the number of entries.
+func (tree *Tree[K, V]) Validate() error {
+	if tree.Root == nil {
+		if tree.size != 0 {
+			return fmt.Errorf("btree: size is %d but tree is empty", tree.size)
+		}
+		return nil
+	}
+	if tree.Root.Parent != nil {
+		return fmt.Errorf("btree: root %v has parent", tree.Root.keys())
+	}
+	count, err := tree.validate(tree.Root, nil, nil, 1, tree.Height())
+	if err != nil {
+		return err
+	}
+	if count != tree.size {
+		return fmt.Errorf("btree: size is %d but tree holds %d entries", tree.size, count)
+	}
+	return nil
+}
+
+// validate checks the subtree whose keys must lie strictly between the lower and upper entries (if any)
+// and returns its number of entries.
+func (tree *Tree[K, V]) validate(node *Node[K, V], lower *Entry[K, V], upper *Entry[K, V], depth int, height int) (int, error) {
+	if len(node.Entries) > tree.maxEntries() {
+		return 0, fmt.Errorf("btree: node %v holds %d entries, at most %d allowed", node.keys(), len(node.Entries), tree.maxEntries())
+	}
+	if node != tree.Root && len(node.Entries) < tree.minEntries() {
+		return 0, fmt.Errorf("btree: node %v holds %d entries, at least %d required", node.keys(), len(node.Entries), tree.minEntries())
+	}
+	if len(node.Entries) == 0 {
+		return 0, fmt.Errorf("btree: node at depth %d holds no entries", depth)
+	}
+	for i, entry := range node.Entries {
+		if entry == nil {
+			return 0, fmt.Errorf("btree: node at depth %d has nil entry at index %d", depth, i)
+		}
+		if i > 0 && tree.Comparator(node.Entries[i-1].Key, entry.Key) >= 0 {
+			return 0, fmt.Errorf("btree: node %v has entry %v not greater than previous entry %v", node.keys(), entry, node.Entries[i-1])
+		}
+	}
+	if lower != nil && tree.Comparator(node.Entries[0].Key, lower.Key) <= 0 {
+		return 0, fmt.Errorf("btree: node %v has entry %v not greater than separator %v", node.keys(), node.Entries[0], lower)
+	}
+	if last := node.Entries[len(node.Entries)-1]; upper != nil && tree.Comparator(last.Key, upper.Key) >= 0 {
+		return 0, fmt.Errorf("btree: node %v has entry %v not smaller than separator %v", node.keys(), last, upper)
+	}
+
+	count := len(node.Entries)
+	if tree.isLeaf(node) {
+		if depth != height {
+			return 0, fmt.Errorf("btree: leaf %v is at depth %d, expected %d", node.keys(), depth, height)
+		}
+		return count, nil
+	}
+	if len(node.Children) != len(node.Entries)+1 {
+		return 0, fmt.Errorf("btree: node %v has %d children for %d entries", node.keys(), len(node.Children), len(node.Entries))
+	}
+	for i, child := range node.Children {
+		if child == nil {
+			return 0, fmt.Errorf("btree: node %v has nil child at index %d", node.keys(), i)
+		}
+		if child.Parent != node {
+			return 0, fmt.Errorf("btree: child %v of node %v has a different parent", child.keys(), node.keys())
+		}
+		childLower, childUpper := lower, upper
+		if i > 0 {
+			childLower = node.Entries[i-1]
+		}
+		if i < len(node.Entries) {
+			childUpper = node.Entries[i]
+		}
+		childCount, err := tree.validate(child.load(), childLower, childUpper, depth+1, height)
+		if err != nil {
+			return 0, err
+		}
+		count += childCount
+	}
+	return count, nil
+}
+
+// keys returns the keys of the node's entries for error reporting.
+func (node *Node[K, V]) keys() []K {
+	keys := make([]K, 0, len(node.Entries))
+	for _, entry := range node.Entries {
+		if entry != nil {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package daryheap implements a d-ary heap backed by a slice.
+//
+// A d-ary heap generalizes the binary heap to nodes with d children. The tree is shallower, so pushing and
+// decreasing keys take fewer steps, while popping compares more children per level. With d=4 the children of
+// a node usually share a cache line, which makes it faster than a binary heap for large heaps of small elements.
+//
+// Comparator defines this heap as either min or max heap.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/D-ary_heap
+package daryheap
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/trees"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+)
+
+// Assert Heap implementation
+//var _ trees.Heap[int] = (*Heap[int])(nil)
+
+// Heap holds elements in a slice, the children of the element at index i are at the indices d*i+1 to d*i+d.
+type Heap[E any] struct {
+	elements   []E
+	arity      int
+	Comparator utils.Comparator
+}
+
+// NewWith instantiates a new empty heap with the arity (number of children per node) and the custom comparator.
+func NewWith[E any](arity int, comparator utils.Comparator) *Heap[E] {
+	if arity < 2 {
+		panic("Invalid arity, should be at least 2")
+	}
+	return &Heap[E]{arity: arity, Comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a new empty heap with the arity and the IntComparator, i.e. elements are of type int.
+func NewWithIntComparator(arity int) *Heap[int] {
+	return NewWith[int](arity, utils.IntComparator)
+}
+
+// NewWithStringComparator instantiates a new empty heap with the arity and the StringComparator, i.e. elements are of type string.
+func NewWithStringComparator(arity int) *Heap[string] {
+	return NewWith[string](arity, utils.StringComparator)
+}
+
+// Push adds the values onto the heap and bubbles them up accordingly.
+// Many values are added in O(n) by rebuilding the heap instead of bubbling up each value.
+func (heap *Heap[E]) Push(values ...E) {
+	if len(values) == 1 {
+		heap.elements = append(heap.elements, values[0])
+		heap.bubbleUp(len(heap.elements) - 1)
+		return
+	}
+	heap.elements = append(heap.elements, values...)
+	if len(values) > 0 {
+		heap.heapify()
+	}
+}
+
+// Pop removes top element on heap and returns it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *Heap[E]) Pop() (value E, ok bool) {
+	if len(heap.elements) == 0 {
+		return value, false
+	}
+	value = heap.elements[0]
+	lastIndex := len(heap.elements) - 1
+	heap.elements[0] = heap.elements[lastIndex]
+	var zero E
+	heap.elements[lastIndex] = zero
+	heap.elements = heap.elements[:lastIndex]
+	if lastIndex > 0 {
+		heap.bubbleDown(0)
+	}
+	return value, true
+}
+
+// Peek returns top element on the heap without removing it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *Heap[E]) Peek() (value E, ok bool) {
+	if len(heap.elements) == 0 {
+		return value, false
+	}
+	return heap.elements[0], true
+}
+
+// Meld moves all elements of the other heap into the heap in O(n+m) and leaves the other heap empty.
+// The other heap must order its elements the same way.
+func (heap *Heap[E]) Meld(other trees.Heap[E]) {
+	if other == trees.Heap[E](heap) {
+		return
+	}
+	var values []E
+	if heap2, ok := other.(*Heap[E]); ok {
+		values = heap2.elements
+	} else {
+		values = other.Values()
+	}
+	if len(values) > 0 {
+		heap.Push(values...)
+		other.Clear()
+	}
+}
+
+// Arity returns the number of children per node.
+func (heap *Heap[E]) Arity() int {
+	return heap.arity
+}
+
+// Empty returns true if heap does not contain any elements.
+func (heap *Heap[E]) Empty() bool {
+	return len(heap.elements) == 0
+}
+
+// Size returns number of elements within the heap.
+func (heap *Heap[E]) Size() int {
+	return len(heap.elements)
+}
+
+// Clear removes all elements from the heap.
+func (heap *Heap[E]) Clear() {
+	heap.elements = nil
+}
+
+// Values returns all elements in the heap in the order of the underlying slice, i.e. level by level.
+func (heap *Heap[E]) Values() []E {
+	return append([]E(nil), heap.elements...)
+}
+
+// String returns a string representation of container
+func (heap *Heap[E]) String() string {
+	str := "DaryHeap\n"
+	values := []string{}
+	for _, value := range heap.elements {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// heapify restores the heap order of all elements in O(n).
+// Reference: https://en.wikipedia.org/wiki/Binary_heap#Building_a_heap
+func (heap *Heap[E]) heapify() {
+	for index := (len(heap.elements) - 2) / heap.arity; index >= 0; index-- {
+		heap.bubbleDown(index)
+	}
+}
+
+// Performs the "bubble down" operation. This is to place the element that is at the index
+// of the heap in its correct place so that the heap maintains the min/max-heap order property.
+func (heap *Heap[E]) bubbleDown(index int) {
+	size := len(heap.elements)
+	value := heap.elements[index]
+	for {
+		firstChild := heap.arity*index + 1
+		if firstChild >= size {
+			break
+		}
+		smallest := firstChild
+		lastChild := firstChild + heap.arity
+		if lastChild > size {
+			lastChild = size
+		}
+		for child := firstChild + 1; child < lastChild; child++ {
+			if heap.Comparator(heap.elements[child], heap.elements[smallest]) < 0 {
+				smallest = child
+			}
+		}
+		if heap.Comparator(value, heap.elements[smallest]) <= 0 {
+			break
+		}
+		heap.elements[index] = heap.elements[smallest]
+		index = smallest
+	}
+	heap.elements[index] = value
+}
+
+// Performs the "bubble up" operation. This is to place the element that is at the index
+// of the heap in its correct place so that the heap maintains the min/max-heap order property.
+func (heap *Heap[E]) bubbleUp(index int) {
+	value := heap.elements[index]
+	for index > 0 {
+		parent := (index - 1) / heap.arity
+		if heap.Comparator(heap.elements[parent], value) <= 0 {
+			break
+		}
+		heap.elements[index] = heap.elements[parent]
+		index = parent
+	}
+	heap.elements[index] = value
+}
+
+// Check that the index is within bounds of the heap
+func (heap *Heap[E]) withinRange(index int) bool {
+	return index >= 0 && index < len(heap.elements)
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package daryheap
+
+import (
+	"encoding/json"
+	"github.com/kcswag/kcgods/trees/binaryheap"
+	"github.com/kcswag/kcgods/utils"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func assertValidHeap[E any](t *testing.T, heap *Heap[E]) {
+	for index := 1; index < len(heap.elements); index++ {
+		parent := (index - 1) / heap.arity
+		if heap.Comparator(heap.elements[parent], heap.elements[index]) > 0 {
+			t.Fatalf("Element %v at index %v is ordered before its parent %v", heap.elements[index], index, heap.elements[parent])
+		}
+	}
+}
+
+func TestDaryHeapPush(t *testing.T) {
+	heap := NewWithIntComparator(4)
+
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Arity(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	if actualValue := heap.Values(); actualValue[0] != 1 || actualValue[1] != 3 || actualValue[2] != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,3,2]")
+	}
+	if actualValue := heap.Empty(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestDaryHeapPushBulk(t *testing.T) {
+	heap := NewWithIntComparator(3)
+
+	heap.Push(15, 20, 3, 1, 2)
+	heap.Push()
+	assertValidHeap(t, heap)
+
+	for _, expectedValue := range []int{1, 2, 3, 15, 20} {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if _, ok := heap.Pop(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := heap.Peek(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+}
+
+func TestDaryHeapInvalidArity(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for arity 1")
+		}
+	}()
+	NewWithIntComparator(1)
+}
+
+func TestDaryHeapRandom(t *testing.T) {
+	for arity := 2; arity <= 8; arity++ {
+		heap := NewWithIntComparator(arity)
+		for i := 0; i < 1000; i++ {
+			heap.Push(rand.Intn(100))
+		}
+		heap.Push(rand.Perm(100)...)
+		assertValidHeap(t, heap)
+
+		prev, _ := heap.Pop()
+		for !heap.Empty() {
+			curr, _ := heap.Pop()
+			if prev > curr {
+				t.Fatalf("Heap property invalidated. prev: %v current: %v", prev, curr)
+			}
+			prev = curr
+		}
+	}
+}
+
+func TestDaryHeapMeld(t *testing.T) {
+	heap := NewWithIntComparator(4)
+	heap.Push(5, 1, 9)
+	other := NewWithIntComparator(2)
+	other.Push(4, 8, 0)
+
+	heap.Meld(other)
+	heap.Meld(heap)
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Size(); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	assertValidHeap(t, heap)
+
+	binary := binaryheap.NewWithIntComparator()
+	binary.Push(3, 7)
+	heap.Meld(binary)
+	if actualValue := binary.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	for _, expectedValue := range []int{0, 1, 3, 4, 5, 7, 8, 9} {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestDaryHeapIterator(t *testing.T) {
+	heap := NewWithIntComparator(3)
+	heap.Push(5, 4, 3, 2, 1)
+
+	it := heap.Iterator()
+	count := 0
+	for it.Next() {
+		if actualValue, expectedValue := it.Value(), heap.elements[it.Index()]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count++
+	}
+	if actualValue := count; actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := it.Prev(); actualValue != true || it.Index() != 4 {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := it.First(); actualValue != true || it.Value() != 1 {
+		t.Errorf("Got %v expected %v", it.Value(), 1)
+	}
+	if actualValue := it.Last(); actualValue != true || it.Index() != 4 {
+		t.Errorf("Got %v expected %v", it.Index(), 4)
+	}
+	it.Begin()
+	if actualValue := it.NextTo(func(index int, value int) bool { return value > 3 }); actualValue != true || it.Value() <= 3 {
+		t.Errorf("Got %v expected %v", it.Value(), "value > 3")
+	}
+	it.End()
+	if actualValue := it.PrevTo(func(index int, value int) bool { return value == 1 }); actualValue != true || it.Index() != 0 {
+		t.Errorf("Got %v expected %v", it.Index(), 0)
+	}
+	if actualValue := it.Prev(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestDaryHeapSerialization(t *testing.T) {
+	heap := NewWithStringComparator(4)
+	heap.Push("c", "b", "a")
+
+	var err error
+	assert := func() {
+		if actualValue := heap.Size(); actualValue != 3 {
+			t.Errorf("Got %v expected %v", actualValue, 3)
+		}
+		if actualValue, ok := heap.Peek(); actualValue != "a" || !ok {
+			t.Errorf("Got %v expected %v", actualValue, "a")
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	bytes, err := heap.ToJSON()
+	assert()
+
+	err = heap.FromJSON(bytes)
+	assert()
+
+	_, err = json.Marshal([]interface{}{"a", "b", "c", heap})
+	assert()
+
+	err = json.Unmarshal([]byte(`["c","a","b"]`), &heap)
+	assert()
+	assertValidHeap(t, heap)
+}
+
+func TestDaryHeapString(t *testing.T) {
+	heap := NewWith[int](4, func(a, b interface{}) int {
+		return -utils.IntComparator(a, b)
+	})
+	heap.Push(1, 2)
+	if actualValue := heap.String(); !strings.HasPrefix(actualValue, "DaryHeap") || !strings.HasSuffix(actualValue, "2, 1") {
+		t.Errorf("Got %v expected %v", actualValue, "DaryHeap\n2, 1")
+	}
+}
+
+func benchmarkPush(b *testing.B, heap *Heap[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Push(n)
+		}
+	}
+}
+
+func benchmarkPop(b *testing.B, heap *Heap[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Pop()
+		}
+	}
+}
+
+func BenchmarkDaryHeapPop100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	heap := NewWithIntComparator(4)
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
+
+func BenchmarkDaryHeapPop10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithIntComparator(4)
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
+
+func BenchmarkDaryHeapPush100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	heap := NewWithIntComparator(4)
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkDaryHeapPush10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithIntComparator(4)
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fenwicktree implements a Fenwick tree (binary indexed tree) over a fixed number of numeric elements.
+//
+// A Fenwick tree keeps partial sums of its elements in an array, so that both adding to an element and
+// computing the sum of a prefix take O(log n) time, using less memory than a segment tree.
+// If no element is negative, LowerBound finds the shortest prefix whose sum reaches a target in O(log n),
+// e.g. to sample an index with probability proportional to its weight.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Fenwick_tree
+package fenwicktree
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/lists/arraylist"
+	"strings"
+)
+
+// Assert Tree implementation
+//var _ trees.Tree[int] = (*Tree[int])(nil)
+
+// Number is the constraint for the elements of a tree.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Tree holds the partial sums of the elements.
+type Tree[E Number] struct {
+	sums []E // sums[i-1] is the sum of the elements in (i-i&-i, i]
+}
+
+// New instantiates a Fenwick tree holding the values in O(n).
+func New[E Number](values ...E) *Tree[E] {
+	tree := &Tree[E]{}
+	tree.build(values)
+	return tree
+}
+
+// NewFromList instantiates a Fenwick tree holding the values of the list in O(n).
+func NewFromList[E Number](list *arraylist.List[E]) *Tree[E] {
+	return New(list.Values()...)
+}
+
+// Add adds the delta to the element at the index in O(log n).
+// Does not do anything if the index is out of bounds.
+func (tree *Tree[E]) Add(index int, delta E) {
+	if !tree.withinRange(index) {
+		return
+	}
+	for i := index + 1; i <= len(tree.sums); i += i & -i {
+		tree.sums[i-1] += delta
+	}
+}
+
+// Get returns the element at the index in O(log n).
+// Second return parameter is true if index is within bounds of the tree.
+func (tree *Tree[E]) Get(index int) (value E, found bool) {
+	if !tree.withinRange(index) {
+		return 0, false
+	}
+	// The partial sum at the index covers the element and the partial sums that end right before it
+	value = tree.sums[index]
+	for i, parent := index, (index+1)-(index+1)&-(index+1); i > parent; i -= i & -i {
+		value -= tree.sums[i-1]
+	}
+	return value, true
+}
+
+// Set replaces the element at the index with the value in O(log n).
+// Does not do anything if the index is out of bounds.
+func (tree *Tree[E]) Set(index int, value E) {
+	if current, found := tree.Get(index); found {
+		tree.Add(index, value-current)
+	}
+}
+
+// PrefixSum returns the sum of the elements in the range [0, to) in O(log n).
+// Second return parameter is false if the range is not within bounds of the tree.
+func (tree *Tree[E]) PrefixSum(to int) (sum E, ok bool) {
+	if to < 0 || to > len(tree.sums) {
+		return 0, false
+	}
+	for i := to; i > 0; i -= i & -i {
+		sum += tree.sums[i-1]
+	}
+	return sum, true
+}
+
+// Sum returns the sum of the elements in the range [from, to) in O(log n).
+// Second return parameter is false if the range is not within bounds of the tree.
+func (tree *Tree[E]) Sum(from, to int) (sum E, ok bool) {
+	if from < 0 || to > len(tree.sums) || from > to {
+		return 0, false
+	}
+	// Only the partial sums below the common ancestor of both prefixes are needed
+	for to > from {
+		sum += tree.sums[to-1]
+		to -= to & -to
+	}
+	for from > to {
+		sum -= tree.sums[from-1]
+		from -= from & -from
+	}
+	return sum, true
+}
+
+// LowerBound returns the smallest index such that the sum of the elements in [0, index] is at least the target
+// in O(log n), or the size of the tree if there is none.
+// The result is only meaningful if no element is negative, i.e. if the prefix sums are ascending.
+func (tree *Tree[E]) LowerBound(target E) int {
+	if target <= 0 {
+		return 0
+	}
+	index := 0
+	var sum E
+	for step := highestPowerOfTwo(len(tree.sums)); step > 0; step >>= 1 {
+		if next := index + step; next <= len(tree.sums) && sum+tree.sums[next-1] < target {
+			index = next
+			sum += tree.sums[next-1]
+		}
+	}
+	return index
+}
+
+// Empty returns true if tree does not contain any elements.
+func (tree *Tree[E]) Empty() bool {
+	return len(tree.sums) == 0
+}
+
+// Size returns the number of elements within the tree.
+func (tree *Tree[E]) Size() int {
+	return len(tree.sums)
+}
+
+// Clear removes all elements from the tree.
+func (tree *Tree[E]) Clear() {
+	tree.sums = nil
+}
+
+// Values returns all elements in the tree in order of their indices in O(n).
+func (tree *Tree[E]) Values() []E {
+	values := append([]E(nil), tree.sums...)
+	for i := len(values); i > 0; i-- {
+		if parent := i + i&-i; parent <= len(values) {
+			values[parent-1] -= tree.sums[i-1]
+		}
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (tree *Tree[E]) String() string {
+	str := "FenwickTree\n"
+	values := []string{}
+	for _, value := range tree.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// build replaces the elements of the tree with the values in O(n).
+func (tree *Tree[E]) build(values []E) {
+	tree.sums = append([]E(nil), values...)
+	for i := 1; i <= len(tree.sums); i++ {
+		if parent := i + i&-i; parent <= len(tree.sums) {
+			tree.sums[parent-1] += tree.sums[i-1]
+		}
+	}
+}
+
+// Check that the index is within bounds of the tree
+func (tree *Tree[E]) withinRange(index int) bool {
+	return index >= 0 && index < len(tree.sums)
+}
+
+// highestPowerOfTwo returns the largest power of two that is not greater than n, or 0 if n is 0.
+func highestPowerOfTwo(n int) int {
+	power := 0
+	for next := 1; next <= n; next <<= 1 {
+		power = next
+	}
+	return power
+}
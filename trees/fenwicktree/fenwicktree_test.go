@@ -0,0 +1,212 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fenwicktree
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kcswag/kcgods/lists/arraylist"
+	"math/rand"
+	"testing"
+)
+
+func TestFenwickTreeSum(t *testing.T) {
+	tree := New(5, 3, 8, 1, 4, 7)
+
+	if actualValue, expectedValue := tree.Size(), 6; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := tree.Empty(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	tests := [][]interface{}{
+		{0, 6, 28, true},
+		{0, 1, 5, true},
+		{1, 4, 12, true},
+		{2, 5, 13, true},
+		{5, 6, 7, true},
+		{3, 3, 0, true},
+		{6, 6, 0, true},
+		{-1, 2, 0, false},
+		{0, 7, 0, false},
+		{4, 3, 0, false},
+	}
+	for _, test := range tests {
+		actualValue, ok := tree.Sum(test[0].(int), test[1].(int))
+		if actualValue != test[2] || ok != test[3] {
+			t.Errorf("Got %v %v expected %v %v for [%v, %v)", actualValue, ok, test[2], test[3], test[0], test[1])
+		}
+	}
+
+	prefixes := []int{0, 5, 8, 16, 17, 21, 28}
+	for to, expectedValue := range prefixes {
+		if actualValue, ok := tree.PrefixSum(to); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v for prefix [0, %v)", actualValue, expectedValue, to)
+		}
+	}
+	if _, ok := tree.PrefixSum(7); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+}
+
+func TestFenwickTreeAdd(t *testing.T) {
+	tree := NewFromList(arraylist.New(5, 3, 8, 1, 4, 7))
+
+	tree.Add(2, -3)
+	tree.Add(5, 1)
+	tree.Add(6, 100)
+	tree.Set(0, 0)
+	tree.Set(-1, 100)
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[0 3 5 1 4 8]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, ok := tree.Sum(0, 6); actualValue != 21 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 21)
+	}
+	for index, expectedValue := range []int{0, 3, 5, 1, 4, 8} {
+		if actualValue, found := tree.Get(index); actualValue != expectedValue || !found {
+			t.Errorf("Got %v expected %v for index %v", actualValue, expectedValue, index)
+		}
+	}
+	if actualValue, found := tree.Get(6); actualValue != 0 || found {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestFenwickTreeLowerBound(t *testing.T) {
+	tree := New(2, 0, 3, 1, 0, 4)
+
+	tests := [][]interface{}{
+		{-1, 0},
+		{0, 0},
+		{1, 0},
+		{2, 0},
+		{3, 2},
+		{5, 2},
+		{6, 3},
+		{7, 5},
+		{10, 5},
+		{11, 6},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := tree.LowerBound(test[0].(int)), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test[0])
+		}
+	}
+	if actualValue, expectedValue := New[uint]().LowerBound(1), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	weights := New(0.5, 0.25, 0.25)
+	if actualValue, expectedValue := weights.LowerBound(0.6), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestFenwickTreeRandom(t *testing.T) {
+	for _, size := range []int{1, 2, 3, 17, 64, 100} {
+		values := make([]int, size)
+		for i := range values {
+			values[i] = rand.Intn(10)
+		}
+		tree := New(values...)
+
+		for i := 0; i < 1000; i++ {
+			index, delta := rand.Intn(size), rand.Intn(10)
+			values[index] += delta
+			tree.Add(index, delta)
+
+			from := rand.Intn(size + 1)
+			to := from + rand.Intn(size-from+1)
+			expectedValue := 0
+			for _, value := range values[from:to] {
+				expectedValue += value
+			}
+			if actualValue, _ := tree.Sum(from, to); actualValue != expectedValue {
+				t.Fatalf("Got %v expected %v for [%v, %v)", actualValue, expectedValue, from, to)
+			}
+
+			target := rand.Intn(expectedValue + 10)
+			expectedIndex, prefix := 0, 0
+			for ; expectedIndex < size && prefix+values[expectedIndex] < target; expectedIndex++ {
+				prefix += values[expectedIndex]
+			}
+			if actualValue := tree.LowerBound(target); actualValue != expectedIndex {
+				t.Fatalf("Got %v expected %v for lower bound of %v", actualValue, expectedIndex, target)
+			}
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Values()), fmt.Sprint(values); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestFenwickTreeClear(t *testing.T) {
+	tree := New(1, 2, 3)
+	tree.Clear()
+
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, ok := tree.PrefixSum(0); actualValue != 0 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := tree.LowerBound(1); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue, expectedValue := tree.String(), "FenwickTree\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestFenwickTreeSerialization(t *testing.T) {
+	tree := New(1, 2, 3, 4)
+	tree.Add(1, 1)
+
+	serialized, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(serialized), "[1,3,3,4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	deserialized := New[float64]()
+	err = json.Unmarshal([]byte(`[0.5,1.5,2]`), deserialized)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, _ := deserialized.Sum(1, 3); actualValue != 3.5 {
+		t.Errorf("Got %v expected %v", actualValue, 3.5)
+	}
+	if actualValue, expectedValue := deserialized.String(), "FenwickTree\n0.5, 1.5, 2"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func BenchmarkFenwickTreeSum100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := New(make([]int, size)...)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Sum(n/2, n+1)
+		}
+	}
+}
+
+func BenchmarkFenwickTreeAdd100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := New(make([]int, size)...)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Add(n, 1)
+		}
+	}
+}
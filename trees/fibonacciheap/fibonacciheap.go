@@ -0,0 +1,356 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fibonacciheap implements a Fibonacci heap.
+//
+// A Fibonacci heap is a collection of heap-ordered trees whose roots are kept in a circular list.
+// Pushing, melding and decreasing the key of an element take O(1) amortized time, popping takes O(log n) amortized
+// time, which makes it asymptotically the best heap for workloads dominated by decrease-key, e.g. Dijkstra's
+// algorithm on dense graphs.
+//
+// Comparator defines this heap as either min or max heap.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/Fibonacci_heap
+package fibonacciheap
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/internal/owner"
+	"github.com/kcswag/kcgods/trees"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+)
+
+// Assert Heap implementation
+//var _ trees.Heap[int] = (*Heap[int])(nil)
+
+// Heap holds elements in a list of heap-ordered trees.
+type Heap[E any] struct {
+	min        *Node[E] // top element, its tree is part of the root list
+	size       int
+	owner      *owner.Owner // owner of the nodes, replaced on Clear
+	Comparator utils.Comparator
+}
+
+// Node is a single element within the heap, it is returned by Insert to Update or Remove the element later.
+type Node[E any] struct {
+	Value  E
+	parent *Node[E]
+	child  *Node[E]     // any child, the children form a circular list
+	left   *Node[E]     // previous sibling in the circular list, nil once the node has been removed
+	right  *Node[E]     // next sibling in the circular list
+	degree int          // number of children
+	marked bool         // whether the node has lost a child since it became a child itself
+	owner  *owner.Owner // owner of the heap the node belongs to, nil once the node has been removed
+}
+
+// NewWith instantiates a new empty heap with the custom comparator.
+func NewWith[E any](comparator utils.Comparator) *Heap[E] {
+	return &Heap[E]{Comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a new empty heap with the IntComparator, i.e. elements are of type int.
+func NewWithIntComparator() *Heap[int] {
+	return &Heap[int]{Comparator: utils.IntComparator}
+}
+
+// NewWithStringComparator instantiates a new empty heap with the StringComparator, i.e. elements are of type string.
+func NewWithStringComparator() *Heap[string] {
+	return &Heap[string]{Comparator: utils.StringComparator}
+}
+
+// Push adds the values onto the heap in O(1) each.
+func (heap *Heap[E]) Push(values ...E) {
+	for _, value := range values {
+		heap.Insert(value)
+	}
+}
+
+// Insert adds the value onto the heap in O(1) and returns its node.
+func (heap *Heap[E]) Insert(value E) *Node[E] {
+	node := &Node[E]{Value: value}
+	heap.insert(node)
+	return node
+}
+
+// Pop removes top element on heap and returns it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *Heap[E]) Pop() (value E, ok bool) {
+	node := heap.min
+	if node == nil {
+		return value, false
+	}
+	// Children become roots
+	if child := node.child; child != nil {
+		for c := child; ; {
+			c.parent = nil
+			if c = c.right; c == child {
+				break
+			}
+		}
+		splice(node, child)
+	}
+	if node.right == node {
+		heap.min = nil
+	} else {
+		heap.min = node.right
+		unlink(node)
+		heap.consolidate()
+	}
+	heap.size--
+	node.left, node.right, node.child = nil, nil, nil
+	node.degree, node.owner = 0, nil
+	return node.Value, true
+}
+
+// Peek returns top element on the heap without removing it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *Heap[E]) Peek() (value E, ok bool) {
+	if heap.min == nil {
+		return value, false
+	}
+	return heap.min.Value, true
+}
+
+// Meld moves all elements of the other heap into the heap and leaves the other heap empty.
+// Melding another Fibonacci heap takes O(1) and keeps its nodes valid, other heaps are pushed element by element.
+// The other heap must order its elements the same way.
+func (heap *Heap[E]) Meld(other trees.Heap[E]) {
+	if other == trees.Heap[E](heap) {
+		return
+	}
+	if heap2, ok := other.(*Heap[E]); ok {
+		if heap2.min == nil {
+			return
+		}
+		if heap.min == nil {
+			heap.min = heap2.min
+		} else {
+			splice(heap.min, heap2.min)
+			if heap.Comparator(heap2.min.Value, heap.min.Value) < 0 {
+				heap.min = heap2.min
+			}
+		}
+		heap.size += heap2.size
+		heap2.owner.Forward(heap.own())
+		heap2.min, heap2.size, heap2.owner = nil, 0, nil
+		return
+	}
+	values := other.Values()
+	other.Clear()
+	heap.Push(values...)
+}
+
+// Update replaces the value of the node and restores the heap order, i.e. it decreases or increases the key
+// of the element. Decreasing a key takes O(1) amortized, increasing it takes O(log n) amortized.
+// Returns false if the node has already been removed from the heap.
+// The node must have been inserted into this heap or into a heap melded into it.
+func (heap *Heap[E]) Update(node *Node[E], value E) bool {
+	if !heap.contains(node) {
+		return false
+	}
+	if heap.Comparator(value, node.Value) <= 0 {
+		node.Value = value
+		if parent := node.parent; parent != nil && heap.Comparator(node.Value, parent.Value) < 0 {
+			heap.cut(node)
+			heap.cascadingCut(parent)
+		}
+		if heap.Comparator(node.Value, heap.min.Value) < 0 {
+			heap.min = node
+		}
+		return true
+	}
+	heap.remove(node)
+	node.Value = value
+	heap.insert(node)
+	return true
+}
+
+// Remove removes the node from the heap in O(log n) amortized.
+// Returns false if the node has already been removed from the heap.
+// The node must have been inserted into this heap or into a heap melded into it.
+func (heap *Heap[E]) Remove(node *Node[E]) bool {
+	if !heap.contains(node) {
+		return false
+	}
+	heap.remove(node)
+	return true
+}
+
+// Empty returns true if heap does not contain any elements.
+func (heap *Heap[E]) Empty() bool {
+	return heap.size == 0
+}
+
+// Size returns number of elements within the heap.
+func (heap *Heap[E]) Size() int {
+	return heap.size
+}
+
+// Clear removes all elements from the heap.
+// Nodes of the removed elements no longer belong to the heap, i.e. they cannot be updated or removed.
+func (heap *Heap[E]) Clear() {
+	heap.min = nil
+	heap.size = 0
+	heap.owner = nil
+}
+
+// Values returns all elements in the heap in pre-order, i.e. every element comes before its children.
+func (heap *Heap[E]) Values() []E {
+	values := make([]E, 0, heap.size)
+	for it := heap.Iterator(); it.Next(); {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (heap *Heap[E]) String() string {
+	str := "FibonacciHeap\n"
+	values := []string{}
+	for it := heap.Iterator(); it.Next(); {
+		values = append(values, fmt.Sprintf("%v", it.Value()))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// insert adds the detached node to the root list.
+func (heap *Heap[E]) insert(node *Node[E]) {
+	node.left, node.right = node, node
+	node.owner = heap.own()
+	if heap.min == nil {
+		heap.min = node
+	} else {
+		splice(heap.min, node)
+		if heap.Comparator(node.Value, heap.min.Value) < 0 {
+			heap.min = node
+		}
+	}
+	heap.size++
+}
+
+// remove cuts the node from its parent, makes it the top element and pops it.
+func (heap *Heap[E]) remove(node *Node[E]) {
+	if parent := node.parent; parent != nil {
+		heap.cut(node)
+		heap.cascadingCut(parent)
+	}
+	heap.min = node
+	heap.Pop()
+}
+
+// consolidate links roots of the same degree until all roots have distinct degrees and determines the new top element.
+func (heap *Heap[E]) consolidate() {
+	var roots []*Node[E]
+	for root := heap.min; ; {
+		roots = append(roots, root)
+		if root = root.right; root == heap.min {
+			break
+		}
+	}
+
+	var byDegree []*Node[E]
+	for _, root := range roots {
+		root.left, root.right = root, root
+		for root.degree < len(byDegree) && byDegree[root.degree] != nil {
+			other := byDegree[root.degree]
+			byDegree[root.degree] = nil
+			if heap.Comparator(other.Value, root.Value) < 0 {
+				root, other = other, root
+			}
+			heap.link(other, root)
+		}
+		for root.degree >= len(byDegree) {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[root.degree] = root
+	}
+
+	heap.min = nil
+	for _, root := range byDegree {
+		if root == nil {
+			continue
+		}
+		if heap.min == nil {
+			heap.min = root
+		} else {
+			splice(heap.min, root)
+			if heap.Comparator(root.Value, heap.min.Value) < 0 {
+				heap.min = root
+			}
+		}
+	}
+}
+
+// link makes the root a child of the parent, which must be a root too.
+func (heap *Heap[E]) link(root, parent *Node[E]) {
+	root.left, root.right = root, root
+	root.parent = parent
+	root.marked = false
+	if parent.child == nil {
+		parent.child = root
+	} else {
+		splice(parent.child, root)
+	}
+	parent.degree++
+}
+
+// cut moves the node from its parent's children to the root list.
+func (heap *Heap[E]) cut(node *Node[E]) {
+	parent := node.parent
+	if node.right == node {
+		parent.child = nil
+	} else {
+		if parent.child == node {
+			parent.child = node.right
+		}
+		unlink(node)
+	}
+	parent.degree--
+	node.parent = nil
+	node.marked = false
+	node.left, node.right = node, node
+	splice(heap.min, node)
+}
+
+// cascadingCut cuts the node from its parent if it has already lost a child before, and continues with the parent.
+func (heap *Heap[E]) cascadingCut(node *Node[E]) {
+	for parent := node.parent; parent != nil; node, parent = parent, parent.parent {
+		if !node.marked {
+			node.marked = true
+			return
+		}
+		heap.cut(node)
+	}
+}
+
+// contains returns true if the node belongs to the heap, i.e. it was inserted into the heap or into a heap melded
+// into it and has been neither removed nor cleared since.
+func (heap *Heap[E]) contains(node *Node[E]) bool {
+	return node != nil && heap.owner != nil && node.owner.Resolve() == heap.owner
+}
+
+// own returns the owner of the nodes of the heap.
+func (heap *Heap[E]) own() *owner.Owner {
+	if heap.owner == nil {
+		heap.owner = &owner.Owner{}
+	}
+	return heap.owner
+}
+
+// splice joins the circular lists of both nodes.
+func splice[E any](a, b *Node[E]) {
+	aRight, bLeft := a.right, b.left
+	a.right, b.left = b, a
+	bLeft.right, aRight.left = aRight, bLeft
+}
+
+// unlink removes the node from its circular list.
+func unlink[E any](node *Node[E]) {
+	node.left.right = node.right
+	node.right.left = node.left
+}
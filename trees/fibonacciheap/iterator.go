@@ -0,0 +1,106 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fibonacciheap
+
+// Assert Iterator implementation
+//var _ containers.IteratorWithIndex = (*Iterator)(nil)
+
+// Iterator holding the iterator's state
+type Iterator[E any] struct {
+	heap  *Heap[E]
+	node  *Node[E]
+	stack []*Node[E] // nodes that are still to be visited, the next one last
+	index int
+}
+
+// Iterator returns a stateful iterator whose values can be fetched by an index.
+// Elements are iterated in pre-order starting at the top element, i.e. every element comes before its children.
+func (heap *Heap[E]) Iterator() Iterator[E] {
+	return Iterator[E]{heap: heap, index: -1}
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's index and value can be retrieved by Index() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator[E]) Next() bool {
+	switch {
+	case iterator.index < 0:
+		iterator.push(iterator.heap.min)
+	case iterator.node == nil:
+		return false
+	default:
+		iterator.push(iterator.node.child)
+	}
+	if n := len(iterator.stack); n > 0 {
+		iterator.node = iterator.stack[n-1]
+		iterator.stack = iterator.stack[:n-1]
+	} else {
+		iterator.node = nil
+	}
+	iterator.index++
+	return iterator.node != nil
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[E]) Value() E {
+	return iterator.node.Value
+}
+
+// Node returns the current element's node, e.g. to Update or Remove it after iterating.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[E]) Node() *Node[E] {
+	return iterator.node
+}
+
+// Index returns the current element's index.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[E]) Index() int {
+	return iterator.index
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator[E]) Begin() {
+	iterator.node = nil
+	iterator.stack = iterator.stack[:0]
+	iterator.index = -1
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's index and value can be retrieved by Index() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[E]) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// NextTo moves the iterator to the next element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If NextTo() returns true, then next element's index and value can be retrieved by Index() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[E]) NextTo(f func(index int, value E) bool) bool {
+	for iterator.Next() {
+		index, value := iterator.Index(), iterator.Value()
+		if f(index, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// push adds the nodes of the circular list starting at the node to the stack, so that they are visited in order.
+func (iterator *Iterator[E]) push(first *Node[E]) {
+	if first == nil {
+		return
+	}
+	for node := first.left; ; node = node.left {
+		iterator.stack = append(iterator.stack, node)
+		if node == first {
+			break
+		}
+	}
+}
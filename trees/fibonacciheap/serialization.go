@@ -0,0 +1,39 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fibonacciheap
+
+import (
+	"encoding/json"
+)
+
+// Assert Serialization implementation
+//var _ containers.JSONSerializer = (*Heap)(nil)
+//var _ containers.JSONDeserializer = (*Heap)(nil)
+
+// ToJSON outputs the JSON representation of the heap.
+func (heap *Heap[E]) ToJSON() ([]byte, error) {
+	return json.Marshal(heap.Values())
+}
+
+// FromJSON populates the heap from the input JSON representation.
+func (heap *Heap[E]) FromJSON(data []byte) error {
+	var elements []E
+	err := json.Unmarshal(data, &elements)
+	if err == nil {
+		heap.Clear()
+		heap.Push(elements...)
+	}
+	return err
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (heap *Heap[E]) UnmarshalJSON(bytes []byte) error {
+	return heap.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (heap *Heap[E]) MarshalJSON() ([]byte, error) {
+	return heap.ToJSON()
+}
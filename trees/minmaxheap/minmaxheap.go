@@ -0,0 +1,314 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package minmaxheap implements a min-max heap backed by a slice, i.e. a double-ended priority queue.
+//
+// A min-max heap is a complete binary tree whose levels alternate between min and max levels: every element on a
+// min level is ordered before all of its descendants, every element on a max level after all of its descendants.
+// Hence the root is the smallest and one of its children the largest element, both can be peeked in O(1) and
+// popped in O(log n).
+//
+// A bounded heap holds at most a given number of elements and drops the largest one when it is full,
+// e.g. to keep the best n items of a stream.
+//
+// Comparator defines the order of the elements, "min" is the first and "max" the last element in that order.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/Min-max_heap
+package minmaxheap
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/trees"
+	"github.com/kcswag/kcgods/utils"
+	"math/bits"
+	"strings"
+)
+
+// Assert Heap implementation
+//var _ trees.Heap[int] = (*Heap[int])(nil)
+
+// Heap holds elements in a slice, the children of the element at index i are at the indices 2i+1 and 2i+2.
+type Heap[E any] struct {
+	elements   []E
+	capacity   int // maximum number of elements, 0 if unbounded
+	Comparator utils.Comparator
+}
+
+// NewWith instantiates a new empty heap with the custom comparator.
+func NewWith[E any](comparator utils.Comparator) *Heap[E] {
+	return &Heap[E]{Comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a new empty heap with the IntComparator, i.e. elements are of type int.
+func NewWithIntComparator() *Heap[int] {
+	return NewWith[int](utils.IntComparator)
+}
+
+// NewWithStringComparator instantiates a new empty heap with the StringComparator, i.e. elements are of type string.
+func NewWithStringComparator() *Heap[string] {
+	return NewWith[string](utils.StringComparator)
+}
+
+// NewBoundedWith instantiates a new empty heap that holds at most capacity elements with the custom comparator.
+// When the heap is full, pushing an element drops the largest element, which may be the pushed element itself.
+func NewBoundedWith[E any](capacity int, comparator utils.Comparator) *Heap[E] {
+	if capacity < 1 {
+		panic("Invalid capacity, should be at least 1")
+	}
+	return &Heap[E]{capacity: capacity, Comparator: comparator}
+}
+
+// Push adds the values onto the heap and bubbles them up accordingly.
+// Many values are added to an unbounded heap in O(n) by rebuilding the heap instead of bubbling up each value.
+// A bounded heap drops its largest elements once it is full.
+func (heap *Heap[E]) Push(values ...E) {
+	if heap.capacity == 0 && len(values) > 1 {
+		heap.elements = append(heap.elements, values...)
+		heap.heapify()
+		return
+	}
+	for _, value := range values {
+		heap.Offer(value)
+	}
+}
+
+// Offer adds the value onto the heap in O(log n) and returns the element that was dropped to make room for it.
+// Second return parameter is true, if the heap is bounded and was full, so that either its largest element or
+// the value itself, if no element is larger, was dropped.
+func (heap *Heap[E]) Offer(value E) (dropped E, ok bool) {
+	if heap.Full() {
+		index := heap.maxIndex()
+		if heap.Comparator(value, heap.elements[index]) >= 0 {
+			return value, true
+		}
+		dropped, ok = heap.elements[index], true
+		heap.removeAt(index)
+	}
+	heap.elements = append(heap.elements, value)
+	heap.bubbleUp(len(heap.elements) - 1)
+	return dropped, ok
+}
+
+// Pop removes the smallest element on heap and returns it, or nil if heap is empty, same as PopMin.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *Heap[E]) Pop() (value E, ok bool) {
+	return heap.PopMin()
+}
+
+// Peek returns the smallest element on the heap without removing it, or nil if heap is empty, same as PeekMin.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *Heap[E]) Peek() (value E, ok bool) {
+	return heap.PeekMin()
+}
+
+// PopMin removes the smallest element on heap in O(log n) and returns it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *Heap[E]) PopMin() (value E, ok bool) {
+	if len(heap.elements) == 0 {
+		return value, false
+	}
+	value = heap.elements[0]
+	heap.removeAt(0)
+	return value, true
+}
+
+// PopMax removes the largest element on heap in O(log n) and returns it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *Heap[E]) PopMax() (value E, ok bool) {
+	if len(heap.elements) == 0 {
+		return value, false
+	}
+	index := heap.maxIndex()
+	value = heap.elements[index]
+	heap.removeAt(index)
+	return value, true
+}
+
+// PeekMin returns the smallest element on the heap in O(1) without removing it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *Heap[E]) PeekMin() (value E, ok bool) {
+	if len(heap.elements) == 0 {
+		return value, false
+	}
+	return heap.elements[0], true
+}
+
+// PeekMax returns the largest element on the heap in O(1) without removing it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *Heap[E]) PeekMax() (value E, ok bool) {
+	if len(heap.elements) == 0 {
+		return value, false
+	}
+	return heap.elements[heap.maxIndex()], true
+}
+
+// Meld moves all elements of the other heap into the heap in O(n+m) and leaves the other heap empty.
+// A bounded heap keeps only the smallest elements that fit.
+// The other heap must order its elements the same way.
+func (heap *Heap[E]) Meld(other trees.Heap[E]) {
+	if other == trees.Heap[E](heap) {
+		return
+	}
+	var values []E
+	if heap2, ok := other.(*Heap[E]); ok {
+		values = heap2.elements
+	} else {
+		values = other.Values()
+	}
+	if len(values) > 0 {
+		heap.Push(values...)
+		other.Clear()
+	}
+}
+
+// Capacity returns the maximum number of elements of a bounded heap, or 0 if the heap is unbounded.
+func (heap *Heap[E]) Capacity() int {
+	return heap.capacity
+}
+
+// Full returns true if the heap is bounded and holds as many elements as its capacity.
+func (heap *Heap[E]) Full() bool {
+	return heap.capacity > 0 && len(heap.elements) >= heap.capacity
+}
+
+// Empty returns true if heap does not contain any elements.
+func (heap *Heap[E]) Empty() bool {
+	return len(heap.elements) == 0
+}
+
+// Size returns number of elements within the heap.
+func (heap *Heap[E]) Size() int {
+	return len(heap.elements)
+}
+
+// Clear removes all elements from the heap.
+func (heap *Heap[E]) Clear() {
+	heap.elements = nil
+}
+
+// Values returns all elements in the heap in the order of the underlying slice, i.e. level by level.
+func (heap *Heap[E]) Values() []E {
+	return append([]E(nil), heap.elements...)
+}
+
+// String returns a string representation of container
+func (heap *Heap[E]) String() string {
+	str := "MinMaxHeap\n"
+	values := []string{}
+	for _, value := range heap.elements {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// maxIndex returns the index of the largest element, which is the root or one of its children.
+func (heap *Heap[E]) maxIndex() int {
+	switch len(heap.elements) {
+	case 0, 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if heap.Comparator(heap.elements[2], heap.elements[1]) > 0 {
+		return 2
+	}
+	return 1
+}
+
+// removeAt replaces the element at the index, which must be the root or one of its children, with the last element.
+func (heap *Heap[E]) removeAt(index int) {
+	lastIndex := len(heap.elements) - 1
+	heap.elements[index] = heap.elements[lastIndex]
+	var zero E
+	heap.elements[lastIndex] = zero
+	heap.elements = heap.elements[:lastIndex]
+	if index < lastIndex {
+		heap.bubbleDown(index)
+	}
+}
+
+// heapify restores the heap order of all elements in O(n).
+func (heap *Heap[E]) heapify() {
+	for index := len(heap.elements)/2 - 1; index >= 0; index-- {
+		heap.bubbleDown(index)
+	}
+}
+
+// order returns 1 for indexes on min levels and -1 for indexes on max levels,
+// so that multiplying a comparison with it compares in the order of the level.
+func order(index int) int {
+	if bits.Len(uint(index+1))%2 == 1 {
+		return 1
+	}
+	return -1
+}
+
+// Performs the "bubble down" operation. This is to place the element that is at the index
+// of the heap in its correct place so that the heap maintains the min-max order property.
+// On a min level the element trickles down to the smallest of its children and grandchildren, on a max level
+// to the largest one.
+func (heap *Heap[E]) bubbleDown(index int) {
+	size := len(heap.elements)
+	sign := order(index)
+	for {
+		firstChild := 2*index + 1
+		if firstChild >= size {
+			return
+		}
+		next := firstChild
+		if firstChild+1 < size && sign*heap.Comparator(heap.elements[firstChild+1], heap.elements[next]) < 0 {
+			next = firstChild + 1
+		}
+		for grandchild := 2*firstChild + 1; grandchild <= 2*firstChild+4 && grandchild < size; grandchild++ {
+			if sign*heap.Comparator(heap.elements[grandchild], heap.elements[next]) < 0 {
+				next = grandchild
+			}
+		}
+		if sign*heap.Comparator(heap.elements[next], heap.elements[index]) >= 0 {
+			return
+		}
+		heap.swap(next, index)
+		if next <= firstChild+1 {
+			return
+		}
+		if parent := (next - 1) / 2; sign*heap.Comparator(heap.elements[next], heap.elements[parent]) > 0 {
+			heap.swap(next, parent)
+		}
+		index = next
+	}
+}
+
+// Performs the "bubble up" operation. This is to place the element that is at the index
+// of the heap in its correct place so that the heap maintains the min-max order property.
+// The element moves to the other kind of level if its parent is out of order and then up by grandparents.
+func (heap *Heap[E]) bubbleUp(index int) {
+	if index == 0 {
+		return
+	}
+	sign := order(index)
+	if parent := (index - 1) / 2; sign*heap.Comparator(heap.elements[index], heap.elements[parent]) > 0 {
+		heap.swap(index, parent)
+		index, sign = parent, -sign
+	}
+	for index > 2 {
+		grandparent := ((index-1)/2 - 1) / 2
+		if sign*heap.Comparator(heap.elements[index], heap.elements[grandparent]) >= 0 {
+			return
+		}
+		heap.swap(index, grandparent)
+		index = grandparent
+	}
+}
+
+func (heap *Heap[E]) swap(i, j int) {
+	heap.elements[i], heap.elements[j] = heap.elements[j], heap.elements[i]
+}
+
+// Check that the index is within bounds of the heap
+func (heap *Heap[E]) withinRange(index int) bool {
+	return index >= 0 && index < len(heap.elements)
+}
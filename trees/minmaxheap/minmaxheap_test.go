@@ -0,0 +1,354 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package minmaxheap
+
+import (
+	"encoding/json"
+	"github.com/kcswag/kcgods/trees/binaryheap"
+	"github.com/kcswag/kcgods/utils"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func assertValidHeap[E any](t *testing.T, heap *Heap[E]) {
+	for index := 1; index < len(heap.elements); index++ {
+		parent := (index - 1) / 2
+		if order(parent)*heap.Comparator(heap.elements[parent], heap.elements[index]) > 0 {
+			t.Fatalf("Element %v at index %v is out of order with its parent %v", heap.elements[index], index, heap.elements[parent])
+		}
+		if parent == 0 {
+			continue
+		}
+		grandparent := (parent - 1) / 2
+		if order(grandparent)*heap.Comparator(heap.elements[grandparent], heap.elements[index]) > 0 {
+			t.Fatalf("Element %v at index %v is out of order with its grandparent %v", heap.elements[index], index, heap.elements[grandparent])
+		}
+	}
+	if heap.capacity > 0 && len(heap.elements) > heap.capacity {
+		t.Fatalf("Got %v elements expected at most %v", len(heap.elements), heap.capacity)
+	}
+}
+
+func TestMinMaxHeapPush(t *testing.T) {
+	heap := NewWithIntComparator()
+
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if _, ok := heap.PeekMax(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	if actualValue := heap.Values(); actualValue[0] != 1 || actualValue[1] != 3 || actualValue[2] != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,3,2]")
+	}
+	if actualValue := heap.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.PeekMin(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.PeekMax(); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue := heap.Capacity(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := heap.Full(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	assertValidHeap(t, heap)
+}
+
+func TestMinMaxHeapPop(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(15, 20, 3, 1, 2, 7, 11)
+	assertValidHeap(t, heap)
+
+	tests := []struct {
+		pop           func() (int, bool)
+		expectedValue int
+	}{
+		{heap.PopMax, 20},
+		{heap.PopMin, 1},
+		{heap.PopMax, 15},
+		{heap.Pop, 2},
+		{heap.PopMax, 11},
+		{heap.PopMax, 7},
+		{heap.PopMin, 3},
+	}
+	for _, test := range tests {
+		if actualValue, ok := test.pop(); actualValue != test.expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, test.expectedValue)
+		}
+		assertValidHeap(t, heap)
+	}
+	if _, ok := heap.PopMin(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := heap.PopMax(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+}
+
+func TestMinMaxHeapRandom(t *testing.T) {
+	heap := NewWithIntComparator()
+	var values []int
+	for i := 0; i < 10000; i++ {
+		switch r := rand.Intn(10); {
+		case r < 5 || len(values) == 0:
+			value := rand.Intn(1000)
+			heap.Push(value)
+			values = append(values, value)
+			sort.Ints(values)
+		case r < 7:
+			if actualValue, _ := heap.PopMin(); actualValue != values[0] {
+				t.Fatalf("Got %v expected %v", actualValue, values[0])
+			}
+			values = values[1:]
+		default:
+			if actualValue, _ := heap.PopMax(); actualValue != values[len(values)-1] {
+				t.Fatalf("Got %v expected %v", actualValue, values[len(values)-1])
+			}
+			values = values[:len(values)-1]
+		}
+		if i%100 == 0 {
+			assertValidHeap(t, heap)
+		}
+	}
+
+	heap.Push(rand.Perm(1000)...)
+	assertValidHeap(t, heap)
+	prev, _ := heap.PopMax()
+	for !heap.Empty() {
+		curr, _ := heap.PopMax()
+		if prev < curr {
+			t.Fatalf("Heap property invalidated. prev: %v current: %v", prev, curr)
+		}
+		prev = curr
+	}
+}
+
+func TestMinMaxHeapBounded(t *testing.T) {
+	heap := NewBoundedWith[int](3, utils.IntComparator)
+
+	if actualValue := heap.Capacity(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	heap.Push(5, 1, 9)
+	if actualValue := heap.Full(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	tests := [][]interface{}{
+		{4, 9, true},
+		{7, 7, true},
+		{0, 5, true},
+	}
+	for _, test := range tests {
+		if actualValue, ok := heap.Offer(test[0].(int)); actualValue != test[1] || ok != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+		assertValidHeap(t, heap)
+	}
+	if actualValue, ok := heap.PeekMax(); actualValue != 4 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+
+	heap.PopMin()
+	if _, ok := heap.Offer(2); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	heap.Push(rand.Perm(100)...)
+	assertValidHeap(t, heap)
+	for _, expectedValue := range []int{0, 1, 1} {
+		if actualValue, _ := heap.PopMin(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMinMaxHeapInvalidCapacity(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for capacity 0")
+		}
+	}()
+	NewBoundedWith[int](0, utils.IntComparator)
+}
+
+func TestMinMaxHeapMeld(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(5, 1, 9)
+	other := NewWithIntComparator()
+	other.Push(4, 8, 0)
+
+	heap.Meld(other)
+	heap.Meld(heap)
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Size(); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	assertValidHeap(t, heap)
+
+	binary := binaryheap.NewWithIntComparator()
+	binary.Push(3, 7)
+	bounded := NewBoundedWith[int](4, utils.IntComparator)
+	bounded.Meld(binary)
+	bounded.Meld(heap)
+	if actualValue := binary.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	assertValidHeap(t, bounded)
+	for _, expectedValue := range []int{4, 3, 1, 0} {
+		if actualValue, _ := bounded.PopMax(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMinMaxHeapIterator(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(5, 4, 3, 2, 1)
+
+	it := heap.Iterator()
+	count := 0
+	for it.Next() {
+		if actualValue, expectedValue := it.Value(), heap.elements[it.Index()]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count++
+	}
+	if actualValue := count; actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := it.First(); actualValue != true || it.Value() != 1 {
+		t.Errorf("Got %v expected %v", it.Value(), 1)
+	}
+	if actualValue := it.Last(); actualValue != true || it.Index() != 4 {
+		t.Errorf("Got %v expected %v", it.Index(), 4)
+	}
+	if actualValue := it.PrevTo(func(index int, value int) bool { return value == 5 }); actualValue != true || it.Index() > 2 {
+		t.Errorf("Got %v expected %v", it.Index(), "1 or 2")
+	}
+}
+
+func TestMinMaxHeapSerialization(t *testing.T) {
+	heap := NewWithStringComparator()
+	heap.Push("c", "b", "a")
+
+	var err error
+	assert := func() {
+		if actualValue := heap.Size(); actualValue != 3 {
+			t.Errorf("Got %v expected %v", actualValue, 3)
+		}
+		if actualValue, ok := heap.PeekMin(); actualValue != "a" || !ok {
+			t.Errorf("Got %v expected %v", actualValue, "a")
+		}
+		if actualValue, ok := heap.PeekMax(); actualValue != "c" || !ok {
+			t.Errorf("Got %v expected %v", actualValue, "c")
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	bytes, err := heap.ToJSON()
+	assert()
+
+	err = heap.FromJSON(bytes)
+	assert()
+
+	_, err = json.Marshal([]interface{}{"a", "b", "c", heap})
+	assert()
+
+	err = json.Unmarshal([]byte(`["c","a","b"]`), &heap)
+	assert()
+	assertValidHeap(t, heap)
+}
+
+func TestMinMaxHeapString(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(2, 1)
+	if actualValue := heap.String(); !strings.HasPrefix(actualValue, "MinMaxHeap") || !strings.HasSuffix(actualValue, "1, 2") {
+		t.Errorf("Got %v expected %v", actualValue, "MinMaxHeap\n1, 2")
+	}
+}
+
+func benchmarkPush(b *testing.B, heap *Heap[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Push(n)
+		}
+	}
+}
+
+func benchmarkPopMax(b *testing.B, heap *Heap[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.PopMax()
+		}
+	}
+}
+
+func BenchmarkMinMaxHeapPopMax100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPopMax(b, heap, size)
+}
+
+func BenchmarkMinMaxHeapPopMax10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPopMax(b, heap, size)
+}
+
+func BenchmarkMinMaxHeapPush100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkMinMaxHeapPush10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkMinMaxHeapBoundedPush10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewBoundedWith[int](100, utils.IntComparator)
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
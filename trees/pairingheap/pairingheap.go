@@ -0,0 +1,283 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pairingheap implements a pairing heap.
+//
+// A pairing heap is a heap-ordered multi-way tree. Pushing, melding and decreasing the key of an element take O(1)
+// time, popping takes O(log n) amortized time with the two-pass pairing of the root's children.
+// It is simple and fast in practice for workloads dominated by decrease-key or meld.
+//
+// Comparator defines this heap as either min or max heap.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/Pairing_heap
+package pairingheap
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/internal/owner"
+	"github.com/kcswag/kcgods/trees"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+)
+
+// Assert Heap implementation
+//var _ trees.Heap[int] = (*Heap[int])(nil)
+
+// Heap holds elements in a heap-ordered tree.
+type Heap[E any] struct {
+	root       *Node[E]
+	size       int
+	owner      *owner.Owner // owner of the nodes, replaced on Clear
+	Comparator utils.Comparator
+}
+
+// Node is a single element within the heap, it is returned by Insert to Update or Remove the element later.
+type Node[E any] struct {
+	Value   E
+	child   *Node[E]     // leftmost child
+	sibling *Node[E]     // next sibling to the right
+	prev    *Node[E]     // previous sibling, or parent of the leftmost child
+	owner   *owner.Owner // owner of the heap the node belongs to, nil once the node has been removed
+}
+
+// NewWith instantiates a new empty heap with the custom comparator.
+func NewWith[E any](comparator utils.Comparator) *Heap[E] {
+	return &Heap[E]{Comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a new empty heap with the IntComparator, i.e. elements are of type int.
+func NewWithIntComparator() *Heap[int] {
+	return &Heap[int]{Comparator: utils.IntComparator}
+}
+
+// NewWithStringComparator instantiates a new empty heap with the StringComparator, i.e. elements are of type string.
+func NewWithStringComparator() *Heap[string] {
+	return &Heap[string]{Comparator: utils.StringComparator}
+}
+
+// Push adds the values onto the heap in O(1) each.
+func (heap *Heap[E]) Push(values ...E) {
+	for _, value := range values {
+		heap.Insert(value)
+	}
+}
+
+// Insert adds the value onto the heap in O(1) and returns its node.
+func (heap *Heap[E]) Insert(value E) *Node[E] {
+	node := &Node[E]{Value: value, owner: heap.own()}
+	heap.root = heap.link(heap.root, node)
+	heap.size++
+	return node
+}
+
+// Pop removes top element on heap and returns it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *Heap[E]) Pop() (value E, ok bool) {
+	if heap.root == nil {
+		return value, false
+	}
+	root := heap.root
+	heap.root = heap.pair(root.child)
+	heap.size--
+	root.child, root.owner = nil, nil
+	return root.Value, true
+}
+
+// Peek returns top element on the heap without removing it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *Heap[E]) Peek() (value E, ok bool) {
+	if heap.root == nil {
+		return value, false
+	}
+	return heap.root.Value, true
+}
+
+// Meld moves all elements of the other heap into the heap and leaves the other heap empty.
+// Melding another pairing heap takes O(1) and keeps its nodes valid, other heaps are pushed element by element.
+// The other heap must order its elements the same way.
+func (heap *Heap[E]) Meld(other trees.Heap[E]) {
+	if other == trees.Heap[E](heap) {
+		return
+	}
+	if heap2, ok := other.(*Heap[E]); ok {
+		heap.root = heap.link(heap.root, heap2.root)
+		heap.size += heap2.size
+		if heap2.owner != nil {
+			heap2.owner.Forward(heap.own())
+		}
+		heap2.root, heap2.size, heap2.owner = nil, 0, nil
+		return
+	}
+	values := other.Values()
+	other.Clear()
+	heap.Push(values...)
+}
+
+// Update replaces the value of the node and restores the heap order, i.e. it decreases or increases the key
+// of the element. Decreasing a key takes O(1), increasing it takes O(log n) amortized.
+// Returns false if the node has already been removed from the heap.
+// The node must have been inserted into this heap or into a heap melded into it.
+func (heap *Heap[E]) Update(node *Node[E], value E) bool {
+	if !heap.contains(node) {
+		return false
+	}
+	if heap.Comparator(value, node.Value) <= 0 {
+		node.Value = value
+		if node != heap.root {
+			heap.cut(node)
+			heap.root = heap.link(heap.root, node)
+		}
+		return true
+	}
+	heap.remove(node)
+	node.Value, node.owner = value, heap.own()
+	heap.root = heap.link(heap.root, node)
+	heap.size++
+	return true
+}
+
+// Remove removes the node from the heap in O(log n) amortized.
+// Returns false if the node has already been removed from the heap.
+// The node must have been inserted into this heap or into a heap melded into it.
+func (heap *Heap[E]) Remove(node *Node[E]) bool {
+	if !heap.contains(node) {
+		return false
+	}
+	heap.remove(node)
+	return true
+}
+
+// Empty returns true if heap does not contain any elements.
+func (heap *Heap[E]) Empty() bool {
+	return heap.size == 0
+}
+
+// Size returns number of elements within the heap.
+func (heap *Heap[E]) Size() int {
+	return heap.size
+}
+
+// Clear removes all elements from the heap.
+// Nodes of the removed elements no longer belong to the heap, i.e. they cannot be updated or removed.
+func (heap *Heap[E]) Clear() {
+	heap.root = nil
+	heap.size = 0
+	heap.owner = nil
+}
+
+// Values returns all elements in the heap in pre-order, i.e. every element comes before its children.
+func (heap *Heap[E]) Values() []E {
+	values := make([]E, 0, heap.size)
+	for it := heap.Iterator(); it.Next(); {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (heap *Heap[E]) String() string {
+	str := "PairingHeap\n"
+	values := []string{}
+	for it := heap.Iterator(); it.Next(); {
+		values = append(values, fmt.Sprintf("%v", it.Value()))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// contains returns true if the node belongs to the heap, i.e. it was inserted into the heap or into a heap melded
+// into it and has been neither removed nor cleared since.
+func (heap *Heap[E]) contains(node *Node[E]) bool {
+	return node != nil && heap.owner != nil && node.owner.Resolve() == heap.owner
+}
+
+// own returns the owner of the nodes of the heap.
+func (heap *Heap[E]) own() *owner.Owner {
+	if heap.owner == nil {
+		heap.owner = &owner.Owner{}
+	}
+	return heap.owner
+}
+
+// remove detaches the node and melds its children back into the heap.
+func (heap *Heap[E]) remove(node *Node[E]) {
+	if node == heap.root {
+		heap.Pop()
+		return
+	}
+	heap.cut(node)
+	heap.root = heap.link(heap.root, heap.pair(node.child))
+	node.child, node.owner = nil, nil
+	heap.size--
+}
+
+// cut detaches the subtree of the node, which must not be the root, from its parent.
+func (heap *Heap[E]) cut(node *Node[E]) {
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+	node.prev, node.sibling = nil, nil
+}
+
+// link makes the root that is ordered after the other one the leftmost child of the other one and returns
+// the new root. Both nodes must be roots, either may be nil.
+func (heap *Heap[E]) link(a, b *Node[E]) *Node[E] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if heap.Comparator(b.Value, a.Value) < 0 {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// pair melds the siblings starting at the node into a single tree with the two-pass pairing and returns its root.
+func (heap *Heap[E]) pair(first *Node[E]) *Node[E] {
+	if first == nil {
+		return nil
+	}
+	// First pass: link pairs from left to right, collecting the resulting trees in reverse order
+	var pairs *Node[E]
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		tree := heap.link(a, b)
+		tree.sibling = pairs
+		pairs = tree
+	}
+	// Second pass: link the trees from right to left
+	root := pairs
+	pairs = pairs.sibling
+	root.sibling = nil
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = heap.link(root, pairs)
+		pairs = next
+	}
+	root.prev = nil
+	return root
+}
@@ -0,0 +1,405 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pairingheap
+
+import (
+	"encoding/json"
+	"github.com/kcswag/kcgods/trees/binaryheap"
+	"github.com/kcswag/kcgods/utils"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func assertValidHeap[E any](t *testing.T, heap *Heap[E]) {
+	count := 0
+	var validate func(node *Node[E])
+	validate = func(node *Node[E]) {
+		count++
+		prev := node
+		for child := node.child; child != nil; child = child.sibling {
+			if child.prev != prev {
+				t.Fatalf("Node %v has a wrong previous node", child.Value)
+			}
+			if heap.Comparator(node.Value, child.Value) > 0 {
+				t.Fatalf("Node %v is ordered before its parent %v", child.Value, node.Value)
+			}
+			validate(child)
+			prev = child
+		}
+	}
+	if heap.root != nil {
+		if heap.root.prev != nil || heap.root.sibling != nil {
+			t.Fatalf("Root %v has a previous or next sibling", heap.root.Value)
+		}
+		validate(heap.root)
+	}
+	if actualValue, expectedValue := count, heap.Size(); actualValue != expectedValue {
+		t.Fatalf("Got %v nodes expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestPairingHeapPush(t *testing.T) {
+	heap := NewWithIntComparator()
+
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	if actualValue := heap.Values(); actualValue[0] != 1 || actualValue[1] != 2 || actualValue[2] != 3 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2,3]")
+	}
+	if actualValue := heap.Empty(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	assertValidHeap(t, heap)
+}
+
+func TestPairingHeapPop(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(15, 20, 3, 1, 2)
+
+	for _, expectedValue := range []int{1, 2, 3, 15, 20} {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		assertValidHeap(t, heap)
+	}
+	if _, ok := heap.Pop(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := heap.Peek(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+}
+
+func TestPairingHeapRandom(t *testing.T) {
+	heap := NewWithIntComparator()
+	for i := 0; i < 10000; i++ {
+		heap.Push(rand.Intn(100))
+	}
+
+	prev, _ := heap.Pop()
+	assertValidHeap(t, heap)
+	for !heap.Empty() {
+		curr, _ := heap.Pop()
+		if prev > curr {
+			t.Fatalf("Heap property invalidated. prev: %v current: %v", prev, curr)
+		}
+		prev = curr
+	}
+}
+
+func TestPairingHeapUpdate(t *testing.T) {
+	heap := NewWithIntComparator()
+	nodes := make([]*Node[int], 10)
+	for i := range nodes {
+		nodes[i] = heap.Insert(i * 10)
+	}
+	heap.Pop()
+	assertValidHeap(t, heap)
+
+	if actualValue := heap.Update(nodes[5], -1); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, _ := heap.Peek(); actualValue != -1 {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+	heap.Update(nodes[5], 100)
+	heap.Update(nodes[9], 5)
+	heap.Update(nodes[1], 1)
+	assertValidHeap(t, heap)
+
+	if actualValue := heap.Update(nodes[0], 0); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Remove(nodes[0]); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Remove(nodes[3]); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Remove(nodes[3]); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	assertValidHeap(t, heap)
+
+	for _, expectedValue := range []int{1, 5, 20, 40, 60, 70, 80, 100} {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestPairingHeapUpdateRandom(t *testing.T) {
+	heap := NewWithIntComparator()
+	values := make(map[*Node[int]]int)
+	for i := 0; i < 5000; i++ {
+		switch r := rand.Intn(10); {
+		case r < 4 || len(values) == 0:
+			value := rand.Intn(1000)
+			values[heap.Insert(value)] = value
+		case r < 6:
+			for node := range values {
+				value := rand.Intn(1000)
+				heap.Update(node, value)
+				values[node] = value
+				break
+			}
+		case r < 8:
+			for node := range values {
+				heap.Remove(node)
+				delete(values, node)
+				break
+			}
+		default:
+			expectedValue := -1
+			for _, value := range values {
+				if expectedValue < 0 || value < expectedValue {
+					expectedValue = value
+				}
+			}
+			node := heap.root
+			if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+				t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+			}
+			delete(values, node)
+		}
+		if i%100 == 0 {
+			assertValidHeap(t, heap)
+		}
+	}
+	assertValidHeap(t, heap)
+	if actualValue, expectedValue := heap.Size(), len(values); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestPairingHeapMeld(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(5, 1, 9)
+	other := NewWithIntComparator()
+	node := other.Insert(8)
+	other.Push(4, 0)
+
+	heap.Meld(other)
+	heap.Meld(heap)
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Size(); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	assertValidHeap(t, heap)
+
+	heap.Update(node, -1)
+	binary := binaryheap.NewWithIntComparator()
+	binary.Push(3, 7)
+	heap.Meld(binary)
+	if actualValue := binary.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	assertValidHeap(t, heap)
+	for _, expectedValue := range []int{-1, 0, 1, 3, 4, 5, 7, 9} {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestPairingHeapStaleNodes(t *testing.T) {
+	heap := NewWithIntComparator()
+	node := heap.Insert(1)
+	heap.Push(5, 3)
+	heap.Clear()
+	if actualValue := heap.Remove(node); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Update(node, 0); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := len(heap.Values()); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+
+	// Nodes of a cleared heap stay stale after new elements have been inserted
+	heap.Push(7)
+	if actualValue := heap.Update(node, 0); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, _ := heap.Peek(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+
+	// Nodes of another heap do not belong to the heap until it is melded
+	other := NewWithIntComparator()
+	otherNode := other.Insert(4)
+	if actualValue := heap.Remove(otherNode); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	heap.Meld(other)
+	if actualValue := other.Remove(otherNode); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Update(otherNode, 2); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	popped := heap.Insert(1)
+	heap.Pop()
+	if actualValue := heap.Remove(popped); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	assertValidHeap(t, heap)
+	if actualValue, expectedValue := heap.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestPairingHeapIterator(t *testing.T) {
+	heap := NewWithIntComparator()
+	it := heap.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty heap")
+	}
+
+	heap.Push(5, 4, 3, 2, 1, 6, 7)
+	heap.Pop()
+	seen := map[int]bool{}
+	index := 0
+	for it = heap.Iterator(); it.Next(); index++ {
+		if actualValue := it.Index(); actualValue != index {
+			t.Errorf("Got %v expected %v", actualValue, index)
+		}
+		if it.Node().Value != it.Value() {
+			t.Errorf("Got %v expected %v", it.Node().Value, it.Value())
+		}
+		seen[it.Value()] = true
+	}
+	if actualValue := len(seen); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	if actualValue := it.Next(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := it.First(); actualValue != true || it.Value() != 2 {
+		t.Errorf("Got %v expected %v", it.Value(), 2)
+	}
+	if actualValue := it.NextTo(func(index int, value int) bool { return value == 7 }); actualValue != true || it.Value() != 7 {
+		t.Errorf("Got %v expected %v", it.Value(), 7)
+	}
+	if actualValue := it.NextTo(func(index int, value int) bool { return value == 2 }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestPairingHeapSerialization(t *testing.T) {
+	heap := NewWithStringComparator()
+	heap.Push("c", "b", "a")
+
+	var err error
+	assert := func() {
+		if actualValue := heap.Size(); actualValue != 3 {
+			t.Errorf("Got %v expected %v", actualValue, 3)
+		}
+		if actualValue, ok := heap.Peek(); actualValue != "a" || !ok {
+			t.Errorf("Got %v expected %v", actualValue, "a")
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	bytes, err := heap.ToJSON()
+	assert()
+
+	err = heap.FromJSON(bytes)
+	assert()
+
+	_, err = json.Marshal([]interface{}{"a", "b", "c", heap})
+	assert()
+
+	err = json.Unmarshal([]byte(`["c","a","b"]`), &heap)
+	assert()
+	assertValidHeap(t, heap)
+}
+
+func TestPairingHeapString(t *testing.T) {
+	heap := NewWith[int](func(a, b interface{}) int {
+		return -utils.IntComparator(a, b)
+	})
+	heap.Push(1, 2)
+	if actualValue := heap.String(); !strings.HasPrefix(actualValue, "PairingHeap") || !strings.HasSuffix(actualValue, "2, 1") {
+		t.Errorf("Got %v expected %v", actualValue, "PairingHeap\n2, 1")
+	}
+}
+
+func benchmarkPush(b *testing.B, heap *Heap[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Push(n)
+		}
+	}
+}
+
+func benchmarkPop(b *testing.B, heap *Heap[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Pop()
+		}
+	}
+}
+
+func BenchmarkPairingHeapPop100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
+
+func BenchmarkPairingHeapPop10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
+
+func BenchmarkPairingHeapPush100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkPairingHeapPush10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package radixtree
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kcswag/kcgods/internal/dot"
+	"io"
+)
+
+// ToDOT writes the tree as a Graphviz digraph to w, so that the shared prefixes of the keys can be seen.
+// Edges are labelled with their part of the key, nodes that hold a key are drawn as double circles labelled with
+// their value.
+func (tree *Tree[V]) ToDOT(w io.Writer) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph RadixTree {\n")
+	buffer.WriteString("\tnode [shape=circle, label=\"\"];\n")
+	id := 0
+	var output func(n *node[V]) int
+	output = func(n *node[V]) int {
+		nodeID := id
+		id++
+		if n.leaf {
+			buffer.WriteString(fmt.Sprintf("\tn%d [shape=doublecircle, label=\"%s\"];\n", nodeID, dot.Escape(fmt.Sprintf("%v", n.value))))
+		} else {
+			buffer.WriteString(fmt.Sprintf("\tn%d;\n", nodeID))
+		}
+		for _, child := range n.children {
+			label := dot.Escape(child.key[len(n.key):])
+			buffer.WriteString(fmt.Sprintf("\tn%d -> n%d [label=\"%s\"];\n", nodeID, output(child), label))
+		}
+		return nodeID
+	}
+	output(tree.root)
+	buffer.WriteString("}\n")
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package radixtree
+
+// Assert Iterator implementation
+//var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
+
+// Iterator holding the iterator's state
+type Iterator[V any] struct {
+	tree     *Tree[V]
+	node     *node[V]
+	position position
+}
+
+type position byte
+
+const (
+	begin, between, end position = 0, 1, 2
+)
+
+// Iterator returns a stateful iterator whose elements are key/value pairs in lexicographic order of the keys.
+func (tree *Tree[V]) Iterator() Iterator[V] {
+	return Iterator[V]{tree: tree, node: nil, position: begin}
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator[V]) Next() bool {
+	switch iterator.position {
+	case end:
+		return false
+	case begin:
+		iterator.node = iterator.tree.root
+		if !iterator.node.leaf {
+			iterator.node = iterator.node.next()
+		}
+	case between:
+		iterator.node = iterator.node.next()
+	}
+	if iterator.node == nil {
+		iterator.position = end
+		return false
+	}
+	iterator.position = between
+	return true
+}
+
+// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
+// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[V]) Prev() bool {
+	switch iterator.position {
+	case begin:
+		return false
+	case end:
+		iterator.node = iterator.tree.root.last()
+		if !iterator.node.leaf {
+			iterator.node = nil
+		}
+	case between:
+		iterator.node = iterator.node.prev()
+	}
+	if iterator.node == nil {
+		iterator.position = begin
+		return false
+	}
+	iterator.position = between
+	return true
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[V]) Value() V {
+	return iterator.node.value
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[V]) Key() string {
+	return iterator.node.key
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator[V]) Begin() {
+	iterator.node = nil
+	iterator.position = begin
+}
+
+// End moves the iterator past the last element (one-past-the-end).
+// Call Prev() to fetch the last element if any.
+func (iterator *Iterator[V]) End() {
+	iterator.node = nil
+	iterator.position = end
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator
+func (iterator *Iterator[V]) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// Last moves the iterator to the last element and returns true if there was a last element in the container.
+// If Last() returns true, then last element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[V]) Last() bool {
+	iterator.End()
+	return iterator.Prev()
+}
+
+// NextTo moves the iterator to the next element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If NextTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[V]) NextTo(f func(key string, value V) bool) bool {
+	for iterator.Next() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrevTo moves the iterator to the previous element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If PrevTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[V]) PrevTo(f func(key string, value V) bool) bool {
+	for iterator.Prev() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the node holding the next key in lexicographic order or nil if there is none.
+// Keys are ordered like a pre-order traversal, since every key is smaller than the keys below it.
+func (n *node[V]) next() *node[V] {
+	if len(n.children) > 0 {
+		return n.children[0].first()
+	}
+	for parent := n.parent; parent != nil; n, parent = parent, parent.parent {
+		if index, _ := parent.child(n.key[len(parent.key)]); index+1 < len(parent.children) {
+			return parent.children[index+1].first()
+		}
+	}
+	return nil
+}
+
+// prev returns the node holding the previous key in lexicographic order or nil if there is none.
+func (n *node[V]) prev() *node[V] {
+	for parent := n.parent; parent != nil; n, parent = parent, parent.parent {
+		if index, _ := parent.child(n.key[len(parent.key)]); index > 0 {
+			return parent.children[index-1].last()
+		}
+		if parent.leaf {
+			return parent
+		}
+	}
+	return nil
+}
+
+// first returns the node holding the smallest key of the subtree.
+func (n *node[V]) first() *node[V] {
+	for !n.leaf && len(n.children) > 0 {
+		n = n.children[0]
+	}
+	return n
+}
+
+// last returns the node holding the biggest key of the subtree, i.e. its right-most descendant.
+func (n *node[V]) last() *node[V] {
+	for len(n.children) > 0 {
+		n = n.children[len(n.children)-1]
+	}
+	return n
+}
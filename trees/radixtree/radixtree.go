@@ -0,0 +1,322 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package radixtree implements a radix tree (compressed trie) mapping string keys to values.
+//
+// Every edge is labelled with a string and every node without a value has at least two children, so the tree holds
+// at most 2n nodes for n keys and a lookup takes O(k) time for a key of length k, independently of the number of keys.
+// Keys that share a prefix share the path from the root, which allows efficient prefix queries.
+//
+// Keys are compared byte by byte, so arbitrary binary keys can be stored by converting them with string(bytes).
+// Elements are iterated in lexicographic order of their keys.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Radix_tree
+package radixtree
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Assert Map implementation
+//var _ maps.Map = (*Tree)(nil)
+
+// Tree holds elements of the radix tree
+type Tree[V any] struct {
+	root *node[V] // root node, its key is always the empty string
+	size int      // number of keys in the tree
+}
+
+// node is a single vertex within the tree
+type node[V any] struct {
+	key      string     // full key from the root, the edge label is the part after the parent's key
+	value    V          // value, only meaningful if leaf is set
+	leaf     bool       // whether the key is stored in the tree
+	parent   *node[V]   // parent node, nil for the root
+	children []*node[V] // children sorted by the first byte of their edge label
+}
+
+// New instantiates an empty radix tree.
+func New[V any]() *Tree[V] {
+	return &Tree[V]{root: &node[V]{}}
+}
+
+// Put inserts key-value pair into the tree.
+// If key already exists, then its value is updated with the new value.
+func (tree *Tree[V]) Put(key string, value V) {
+	n := tree.root
+	for len(n.key) < len(key) {
+		index, child := n.child(key[len(n.key)])
+		if child == nil {
+			n.insertChild(index, &node[V]{key: key, value: value, leaf: true})
+			tree.size++
+			return
+		}
+		common := len(n.key) + commonPrefixLength(key[len(n.key):], child.key[len(n.key):])
+		if common == len(child.key) {
+			n = child
+			continue
+		}
+		// Split the edge to the child at the end of the common prefix
+		middle := &node[V]{key: key[:common], parent: n, children: []*node[V]{child}}
+		n.children[index] = middle
+		child.parent = middle
+		n = middle
+	}
+	if !n.leaf {
+		tree.size++
+	}
+	n.value = value
+	n.leaf = true
+}
+
+// Get searches the node in the tree by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+func (tree *Tree[V]) Get(key string) (value V, found bool) {
+	if n := tree.lookup(key); n != nil && n.leaf {
+		return n.value, true
+	}
+	return *new(V), false
+}
+
+// Remove removes the node from the tree by key.
+func (tree *Tree[V]) Remove(key string) {
+	n := tree.lookup(key)
+	if n == nil || !n.leaf {
+		return
+	}
+	n.value = *new(V)
+	n.leaf = false
+	tree.size--
+	tree.compact(n)
+}
+
+// LongestPrefix finds the longest key in the tree that is a prefix of the given key.
+// Third return parameter is true if such a key was found, otherwise false.
+func (tree *Tree[V]) LongestPrefix(key string) (foundKey string, foundValue V, found bool) {
+	var longest *node[V]
+	for n := tree.root; n != nil; {
+		if n.leaf {
+			longest = n
+		}
+		if len(n.key) == len(key) {
+			break
+		}
+		_, child := n.child(key[len(n.key)])
+		if child == nil || !strings.HasPrefix(key, child.key) {
+			break
+		}
+		n = child
+	}
+	if longest == nil {
+		return "", *new(V), false
+	}
+	return longest.key, longest.value, true
+}
+
+// WalkPrefix calls the given function for every key that starts with the given prefix in lexicographic order,
+// passing the key and its value. Walking stops early when the function returns false.
+func (tree *Tree[V]) WalkPrefix(prefix string, f func(key string, value V) bool) {
+	if n := tree.prefixNode(prefix); n != nil {
+		n.walk(f)
+	}
+}
+
+// DeletePrefix removes all keys that start with the given prefix and returns the number of removed keys.
+func (tree *Tree[V]) DeletePrefix(prefix string) int {
+	n := tree.prefixNode(prefix)
+	if n == nil {
+		return 0
+	}
+	if n == tree.root {
+		count := tree.size
+		tree.Clear()
+		return count
+	}
+	count := n.count()
+	parent := n.parent
+	index, _ := parent.child(n.key[len(parent.key)])
+	parent.removeChild(index)
+	tree.size -= count
+	tree.compact(parent)
+	return count
+}
+
+// Empty returns true if tree does not contain any nodes
+func (tree *Tree[V]) Empty() bool {
+	return tree.size == 0
+}
+
+// Size returns number of keys in the tree.
+func (tree *Tree[V]) Size() int {
+	return tree.size
+}
+
+// Keys returns all keys in lexicographic order.
+func (tree *Tree[V]) Keys() []string {
+	keys := make([]string, 0, tree.size)
+	tree.root.walk(func(key string, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in lexicographic order based on the key.
+func (tree *Tree[V]) Values() []V {
+	values := make([]V, 0, tree.size)
+	tree.root.walk(func(key string, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Clear removes all nodes from the tree.
+func (tree *Tree[V]) Clear() {
+	tree.root = &node[V]{}
+	tree.size = 0
+}
+
+// String returns a string representation of container, one edge label per line indented by depth.
+func (tree *Tree[V]) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("RadixTree\n")
+	if !tree.Empty() {
+		output(&buffer, tree.root, 0)
+	}
+	return buffer.String()
+}
+
+func output[V any](buffer *bytes.Buffer, n *node[V], level int) {
+	for _, child := range n.children {
+		buffer.WriteString(strings.Repeat("    ", level))
+		buffer.WriteString(child.key[len(n.key):])
+		if child.leaf {
+			buffer.WriteString(fmt.Sprintf(": %v", child.value))
+		}
+		buffer.WriteString("\n")
+		output(buffer, child, level+1)
+	}
+}
+
+// lookup returns the node whose key equals the given key or nil if there is none.
+// The returned node may not hold a value.
+func (tree *Tree[V]) lookup(key string) *node[V] {
+	n := tree.root
+	for len(n.key) < len(key) {
+		_, child := n.child(key[len(n.key)])
+		if child == nil || !strings.HasPrefix(key, child.key) {
+			return nil
+		}
+		n = child
+	}
+	return n
+}
+
+// prefixNode returns the topmost node whose key starts with the given prefix, i.e. the root of the subtree that
+// holds exactly the keys with that prefix, or nil if no key starts with the prefix.
+func (tree *Tree[V]) prefixNode(prefix string) *node[V] {
+	n := tree.root
+	for len(n.key) < len(prefix) {
+		_, child := n.child(prefix[len(n.key)])
+		if child == nil {
+			return nil
+		}
+		if strings.HasPrefix(child.key, prefix) {
+			return child
+		}
+		if !strings.HasPrefix(prefix, child.key) {
+			return nil
+		}
+		n = child
+	}
+	if len(n.children) == 0 && !n.leaf {
+		return nil
+	}
+	return n
+}
+
+// compact restores the invariants after the node lost its value or a child:
+// nodes without value and children are removed, nodes without value and a single child are merged into that child.
+func (tree *Tree[V]) compact(n *node[V]) {
+	for n != tree.root && !n.leaf {
+		parent := n.parent
+		index, _ := parent.child(n.key[len(parent.key)])
+		switch len(n.children) {
+		case 0:
+			parent.removeChild(index)
+			n = parent
+			continue
+		case 1:
+			child := n.children[0]
+			child.parent = parent
+			parent.children[index] = child
+		}
+		return
+	}
+}
+
+// child returns the child whose edge label starts with the given byte and its index within the children,
+// or nil and the index where such a child would have to be inserted.
+func (n *node[V]) child(label byte) (int, *node[V]) {
+	offset := len(n.key)
+	index := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].key[offset] >= label
+	})
+	if index < len(n.children) && n.children[index].key[offset] == label {
+		return index, n.children[index]
+	}
+	return index, nil
+}
+
+func (n *node[V]) insertChild(index int, child *node[V]) {
+	child.parent = n
+	n.children = append(n.children, nil)
+	copy(n.children[index+1:], n.children[index:])
+	n.children[index] = child
+}
+
+func (n *node[V]) removeChild(index int) {
+	copy(n.children[index:], n.children[index+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// walk visits all keys of the subtree in lexicographic order and returns false if walking was stopped.
+func (n *node[V]) walk(f func(key string, value V) bool) bool {
+	if n.leaf && !f(n.key, n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.walk(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// count returns the number of keys within the subtree.
+func (n *node[V]) count() int {
+	count := 0
+	if n.leaf {
+		count++
+	}
+	for _, child := range n.children {
+		count += child.count()
+	}
+	return count
+}
+
+func commonPrefixLength(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,536 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package radixtree
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kcswag/kcgods/maps"
+	"github.com/kcswag/kcgods/maps/treemap"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRadixTreePut(t *testing.T) {
+	tree := New[int]()
+	tree.Put("romane", 1)
+	tree.Put("romanus", 2)
+	tree.Put("romulus", 3)
+	tree.Put("rubens", 4)
+	tree.Put("ruber", 5)
+	tree.Put("rubicon", 6)
+	tree.Put("rubicundus", 7)
+	tree.Put("rom", 8)
+	tree.Put("rom", 9) // overwrite
+
+	if actualValue := tree.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[rom romane romanus romulus rubens ruber rubicon rubicundus]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[9 1 2 3 4 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	assertValidTree(t, tree)
+
+	// key,expectedValue,expectedFound
+	tests1 := [][]interface{}{
+		{"rom", 9, true},
+		{"romane", 1, true},
+		{"rubicundus", 7, true},
+		{"r", 0, false},
+		{"ro", 0, false},
+		{"roman", 0, false},
+		{"romanes", 0, false},
+		{"x", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests1 {
+		actualValue, actualFound := tree.Get(test[0].(string))
+		if actualValue != test[1] || actualFound != test[2] {
+			t.Errorf("Got %v (%v) expected %v (%v) for %q", actualValue, actualFound, test[1], test[2], test[0])
+		}
+	}
+}
+
+func TestRadixTreeEmptyKey(t *testing.T) {
+	tree := New[int]()
+	tree.Put("", 1)
+	tree.Put("a", 2)
+	if actualValue, found := tree.Get(""); actualValue != 1 || !found {
+		t.Errorf("Got %v (%v) expected %v (%v)", actualValue, found, 1, true)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%q", tree.Keys()), `["" "a"]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Remove("")
+	if actualValue, expectedValue := fmt.Sprintf("%q", tree.Keys()), `["a"]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	assertValidTree(t, tree)
+}
+
+func TestRadixTreeRemove(t *testing.T) {
+	tree := New[int]()
+	tree.Put("test", 1)
+	tree.Put("team", 2)
+	tree.Put("toast", 3)
+	tree.Put("te", 4)
+
+	tree.Remove("t")   // not a key
+	tree.Remove("tea") // not a key
+	tree.Remove("x")
+	if actualValue := tree.Size(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+
+	tree.Remove("te")
+	assertValidTree(t, tree)
+	if actualValue, expectedValue := tree.String(), "RadixTree\nt\n    e\n        am: 2\n        st: 1\n    oast: 3\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Remove("toast")
+	assertValidTree(t, tree)
+	if actualValue, expectedValue := tree.String(), "RadixTree\nte\n    am: 2\n    st: 1\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Remove("team")
+	assertValidTree(t, tree)
+	if actualValue, expectedValue := tree.String(), "RadixTree\ntest: 1\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Remove("test")
+	if actualValue := tree.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := len(tree.root.children); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestRadixTreeLongestPrefix(t *testing.T) {
+	tree := New[string]()
+	if _, _, found := tree.LongestPrefix("anything"); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+	tree.Put("/", "root")
+	tree.Put("/api", "api")
+	tree.Put("/api/v1", "v1")
+	tree.Put("/apix", "apix")
+
+	// key,expectedKey,expectedValue
+	tests1 := [][]string{
+		{"/", "/", "root"},
+		{"/ap", "/", "root"},
+		{"/api", "/api", "api"},
+		{"/api/", "/api", "api"},
+		{"/api/v1/users", "/api/v1", "v1"},
+		{"/api/v2", "/api", "api"},
+		{"/apix/y", "/apix", "apix"},
+		{"/other", "/", "root"},
+	}
+	for _, test := range tests1 {
+		actualKey, actualValue, found := tree.LongestPrefix(test[0])
+		if actualKey != test[1] || actualValue != test[2] || !found {
+			t.Errorf("Got %v->%v (%v) expected %v->%v for %q", actualKey, actualValue, found, test[1], test[2], test[0])
+		}
+	}
+	if _, _, found := tree.LongestPrefix("api"); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+}
+
+func TestRadixTreeWalkPrefix(t *testing.T) {
+	tree := New[int]()
+	for i, key := range []string{"car", "card", "care", "cart", "cat", "dog"} {
+		tree.Put(key, i)
+	}
+
+	// prefix,expectedKeys
+	tests1 := [][]string{
+		{"car", "[car card care cart]"},
+		{"ca", "[car card care cart cat]"},
+		{"cart", "[cart]"},
+		{"carts", "[]"},
+		{"cb", "[]"},
+		{"", "[car card care cart cat dog]"},
+		{"d", "[dog]"},
+		{"x", "[]"},
+	}
+	for _, test := range tests1 {
+		keys := []string{}
+		tree.WalkPrefix(test[0], func(key string, value int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if actualValue := fmt.Sprint(keys); actualValue != test[1] {
+			t.Errorf("Got %v expected %v for %q", actualValue, test[1], test[0])
+		}
+	}
+
+	count := 0
+	tree.WalkPrefix("car", func(key string, value int) bool {
+		count++
+		return count < 2
+	})
+	if actualValue, expectedValue := count, 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeDeletePrefix(t *testing.T) {
+	tree := New[int]()
+	for i, key := range []string{"car", "card", "care", "cart", "cat", "dog"} {
+		tree.Put(key, i)
+	}
+
+	// prefix,expectedCount,expectedKeys
+	tests1 := [][]interface{}{
+		{"x", 0, "[car card care cart cat dog]"},
+		{"cars", 0, "[car card care cart cat dog]"},
+		{"card", 1, "[car care cart cat dog]"},
+		{"car", 3, "[cat dog]"},
+		{"ca", 1, "[dog]"},
+		{"", 1, "[]"},
+		{"", 0, "[]"},
+	}
+	for _, test := range tests1 {
+		if actualValue := tree.DeletePrefix(test[0].(string)); actualValue != test[1] {
+			t.Errorf("Got %v expected %v for %q", actualValue, test[1], test[0])
+		}
+		if actualValue := fmt.Sprint(tree.Keys()); actualValue != test[2] {
+			t.Errorf("Got %v expected %v for %q", actualValue, test[2], test[0])
+		}
+		if actualValue, expectedValue := tree.Size(), len(tree.Keys()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		assertValidTree(t, tree)
+	}
+}
+
+func TestRadixTreeRandom(t *testing.T) {
+	tree := New[int]()
+	reference := make(map[string]int)
+	random := rand.New(rand.NewSource(1))
+	randomKey := func() string {
+		key := make([]byte, random.Intn(6))
+		for i := range key {
+			key[i] = "abc"[random.Intn(3)]
+		}
+		return string(key)
+	}
+	for i := 0; i < 5000; i++ {
+		key := randomKey()
+		switch random.Intn(10) {
+		case 0:
+			prefix := key[:len(key)/2]
+			expected := 0
+			for k := range reference {
+				if strings.HasPrefix(k, prefix) {
+					delete(reference, k)
+					expected++
+				}
+			}
+			if actualValue := tree.DeletePrefix(prefix); actualValue != expected {
+				t.Fatalf("Got %v expected %v", actualValue, expected)
+			}
+		case 1, 2, 3:
+			tree.Remove(key)
+			delete(reference, key)
+		default:
+			tree.Put(key, i)
+			reference[key] = i
+		}
+		if i%100 == 0 {
+			assertValidTree(t, tree)
+		}
+	}
+	assertValidTree(t, tree)
+
+	keys := make([]string, 0, len(reference))
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if actualValue, expectedValue := fmt.Sprintf("%q", tree.Keys()), fmt.Sprintf("%q", keys); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it := tree.Iterator()
+	it.End()
+	for index := len(keys) - 1; index >= 0; index-- {
+		if !it.Prev() || it.Key() != keys[index] || it.Value() != reference[keys[index]] {
+			t.Errorf("Got %v expected %v", it.Key(), keys[index])
+		}
+	}
+	if it.Prev() {
+		t.Errorf("Should not go before first element")
+	}
+}
+
+func TestRadixTreeIteratorOnEmpty(t *testing.T) {
+	tree := New[int]()
+	it := tree.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+	if it.First() || it.Last() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+}
+
+func TestRadixTreeIterator(t *testing.T) {
+	tree := New[int]()
+	tree.Put("b", 2)
+	tree.Put("", 0)
+	tree.Put("ab", 1)
+	tree.Put("a", 4)
+	tree.Put("abc", 3)
+
+	keys := []string{}
+	for it := tree.Iterator(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%q", keys), `["" "a" "ab" "abc" "b"]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys = keys[:0]
+	it := tree.Iterator()
+	for it.End(); it.Prev(); {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%q", keys), `["b" "abc" "ab" "a" ""]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if !it.First() || it.Key() != "" || it.Value() != 0 {
+		t.Errorf("Got %v->%v expected %v->%v", it.Key(), it.Value(), "", 0)
+	}
+	if !it.Last() || it.Key() != "b" || it.Value() != 2 {
+		t.Errorf("Got %v->%v expected %v->%v", it.Key(), it.Value(), "b", 2)
+	}
+	if !it.PrevTo(func(key string, value int) bool { return value == 1 }) || it.Key() != "ab" {
+		t.Errorf("Got %v expected %v", it.Key(), "ab")
+	}
+	if !it.NextTo(func(key string, value int) bool { return len(key) == 1 }) || it.Key() != "b" {
+		t.Errorf("Got %v expected %v", it.Key(), "b")
+	}
+	if it.NextTo(func(key string, value int) bool { return true }) {
+		t.Errorf("Shouldn't iterate past the last element")
+	}
+}
+
+func TestRadixTreeImplementsMap(t *testing.T) {
+	var m maps.Map[string, int] = New[int]()
+	m.Put("a", 1)
+	if actualValue, found := m.Get("a"); actualValue != 1 || !found {
+		t.Errorf("Got %v (%v) expected %v (%v)", actualValue, found, 1, true)
+	}
+}
+
+func TestRadixTreeSerialization(t *testing.T) {
+	tree := New[string]()
+	tree.Put("c", "3")
+	tree.Put("a", "1")
+	tree.Put("ab", "2")
+
+	serialized, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(serialized), `{"a":"1","ab":"2","c":"3"}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// Same format as treemap in both directions
+	m := treemap.NewWithStringComparator[string]()
+	if err := m.FromJSON(serialized); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys(), m.Values()), "[a ab c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	fromMap, _ := m.ToJSON()
+	deserialized := New[string]()
+	if err := deserialized.FromJSON(fromMap); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(deserialized.Keys(), deserialized.Values()), "[a ab c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	_, err = json.Marshal([]interface{}{"a", "b", "c", tree})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	err = json.Unmarshal([]byte(`{"x":"1","y":"2"}`), &tree)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[x y]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeToDOT(t *testing.T) {
+	tree := New[int]()
+	tree.Put("te", 1)
+	tree.Put("test", 2)
+	tree.Put("toast", 3)
+	var builder strings.Builder
+	if err := tree.ToDOT(&builder); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `digraph RadixTree {
+	node [shape=circle, label=""];
+	n0;
+	n1;
+	n2 [shape=doublecircle, label="1"];
+	n3 [shape=doublecircle, label="2"];
+	n2 -> n3 [label="st"];
+	n1 -> n2 [label="e"];
+	n4 [shape=doublecircle, label="3"];
+	n1 -> n4 [label="oast"];
+	n0 -> n1 [label="t"];
+}
+`
+	if actualValue := builder.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+}
+
+func TestRadixTreeToStructureJSON(t *testing.T) {
+	tree := New[int]()
+	tree.Put("te", 1)
+	tree.Put("test", 2)
+	tree.Put("toast", 3)
+	structure, err := tree.ToStructureJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `{"label":"","key":"","children":[{"label":"t","key":"t","children":[{"label":"e","key":"te","value":1,"children":[{"label":"st","key":"test","value":2}]},{"label":"oast","key":"toast","value":3}]}]}`
+	if actualValue := string(structure); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	structure, _ = New[int]().ToStructureJSON()
+	if actualValue, expectedValue := string(structure), "{\"label\":\"\",\"key\":\"\"}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeString(t *testing.T) {
+	tree := New[int]()
+	tree.Put("a", 1)
+	if !strings.HasPrefix(tree.String(), "RadixTree") {
+		t.Errorf("String should start with container name")
+	}
+}
+
+// assertValidTree checks that every node stores the full key, children are sorted by the first byte of their edge
+// label, parents are linked, nodes without value have at least two children (except the root) and the size is right.
+func assertValidTree[V comparable](t *testing.T, tree *Tree[V]) {
+	t.Helper()
+	count := 0
+	var check func(n *node[V])
+	check = func(n *node[V]) {
+		if n.leaf {
+			count++
+		}
+		if n != tree.root && !n.leaf && len(n.children) < 2 {
+			t.Fatalf("Node %q without value has %d children", n.key, len(n.children))
+		}
+		for i, child := range n.children {
+			if child.parent != n {
+				t.Fatalf("Node %q has a different parent than %q", child.key, n.key)
+			}
+			if len(child.key) <= len(n.key) || !strings.HasPrefix(child.key, n.key) {
+				t.Fatalf("Node %q does not extend its parent %q", child.key, n.key)
+			}
+			if i > 0 && n.children[i-1].key[len(n.key)] >= child.key[len(n.key)] {
+				t.Fatalf("Children %q and %q of %q are not sorted", n.children[i-1].key, child.key, n.key)
+			}
+			check(child)
+		}
+	}
+	check(tree.root)
+	if actualValue, expectedValue := tree.Size(), count; actualValue != expectedValue {
+		t.Fatalf("Got size %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkGet(b *testing.B, tree *Tree[struct{}], keys []string) {
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			tree.Get(key)
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, tree *Tree[struct{}], keys []string) {
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			tree.Put(key, struct{}{})
+		}
+	}
+}
+
+func benchmarkKeys(size int) []string {
+	keys := make([]string, size)
+	for n := range keys {
+		keys[n] = fmt.Sprintf("/api/v%d/users/%d", n%3, n)
+	}
+	return keys
+}
+
+func BenchmarkRadixTreeGet1000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(1000)
+	tree := New[struct{}]()
+	for _, key := range keys {
+		tree.Put(key, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, keys)
+}
+
+func BenchmarkRadixTreeGet100000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(100000)
+	tree := New[struct{}]()
+	for _, key := range keys {
+		tree.Put(key, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, keys)
+}
+
+func BenchmarkRadixTreePut1000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(1000)
+	tree := New[struct{}]()
+	b.StartTimer()
+	benchmarkPut(b, tree, keys)
+}
+
+func BenchmarkRadixTreePut100000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(100000)
+	tree := New[struct{}]()
+	b.StartTimer()
+	benchmarkPut(b, tree, keys)
+}
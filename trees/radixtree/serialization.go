@@ -0,0 +1,74 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package radixtree
+
+import (
+	"encoding/json"
+)
+
+// Assert Serialization implementation
+//var _ containers.JSONSerializer = (*Tree)(nil)
+//var _ containers.JSONDeserializer = (*Tree)(nil)
+
+// ToJSON outputs the JSON representation of the tree, i.e. an object of the keys and their values.
+func (tree *Tree[V]) ToJSON() ([]byte, error) {
+	elements := make(map[string]V)
+	it := tree.Iterator()
+	for it.Next() {
+		elements[it.Key()] = it.Value()
+	}
+	return json.Marshal(&elements)
+}
+
+// FromJSON populates the tree from the input JSON representation.
+func (tree *Tree[V]) FromJSON(data []byte) error {
+	elements := make(map[string]V)
+	err := json.Unmarshal(data, &elements)
+	if err == nil {
+		tree.Clear()
+		for key, value := range elements {
+			tree.Put(key, value)
+		}
+	}
+	return err
+}
+
+// structureNode is the JSON representation of a node that keeps the shape of the tree
+type structureNode[V any] struct {
+	Label    string              `json:"label"`
+	Key      string              `json:"key"`
+	Value    *V                  `json:"value,omitempty"`
+	Children []*structureNode[V] `json:"children,omitempty"`
+}
+
+// ToStructureJSON outputs the JSON representation of the shape of the tree for debugging.
+// Every node is an object with the label of the edge leading to it, its full key, its value if the key is stored
+// in the tree and its children in order. The root always has an empty label and key.
+// Unlike ToJSON, the output is not meant to be read back with FromJSON.
+func (tree *Tree[V]) ToStructureJSON() ([]byte, error) {
+	var structure func(n *node[V], parentKey string) *structureNode[V]
+	structure = func(n *node[V], parentKey string) *structureNode[V] {
+		result := &structureNode[V]{Label: n.key[len(parentKey):], Key: n.key}
+		if n.leaf {
+			value := n.value
+			result.Value = &value
+		}
+		for _, child := range n.children {
+			result.Children = append(result.Children, structure(child, n.key))
+		}
+		return result
+	}
+	return json.Marshal(structure(tree.root, ""))
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (tree *Tree[V]) UnmarshalJSON(bytes []byte) error {
+	return tree.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (tree *Tree[V]) MarshalJSON() ([]byte, error) {
+	return tree.ToJSON()
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redblacktree
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/containers"
+	"math/bits"
+)
+
+// BuildFromSorted replaces the contents of the tree with the given key-value pairs in O(n).
+// Keys must be strictly ascending under the tree's comparator and have the same length as values,
+// otherwise an error is returned and the tree is left untouched.
+//
+// The resulting tree is perfectly balanced: every level except the last one is full,
+// and the nodes of the last level are colored red.
+func (tree *Tree[K, V]) BuildFromSorted(keys []K, values []V) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("redblacktree: got %d keys and %d values", len(keys), len(values))
+	}
+	for i := 1; i < len(keys); i++ {
+		if tree.Comparator(keys[i-1], keys[i]) >= 0 {
+			return fmt.Errorf("redblacktree: key %v at index %d is not greater than previous key %v", keys[i], i, keys[i-1])
+		}
+	}
+	tree.Clear()
+	if len(keys) == 0 {
+		return nil
+	}
+	redDepth := bits.Len(uint(len(keys))) - 1
+	tree.Root = buildSorted(keys, values, nil, 0, redDepth)
+	tree.Root.color = black
+	tree.size = len(keys)
+	return nil
+}
+
+// BuildFromSortedIterator replaces the contents of the tree with the key-value pairs read from the iterator in O(n).
+// The iterator is consumed by calling Next() until it returns false.
+// Keys must be strictly ascending under the tree's comparator, otherwise an error is returned and the tree is left untouched.
+func (tree *Tree[K, V]) BuildFromSortedIterator(iterator containers.ForwardIteratorWithKey[K, V]) error {
+	var keys []K
+	var values []V
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+		values = append(values, iterator.Value())
+	}
+	return tree.BuildFromSorted(keys, values)
+}
+
+func buildSorted[K, V any](keys []K, values []V, parent *Node[K, V], depth int, redDepth int) *Node[K, V] {
+	if len(keys) == 0 {
+		return nil
+	}
+	middle := len(keys) / 2
+	node := &Node[K, V]{Key: keys[middle], Value: values[middle], color: black, Parent: parent}
+	if depth == redDepth {
+		node.color = red
+	}
+	node.Left = buildSorted(keys[:middle], values[:middle], node, depth+1, redDepth)
+	node.Right = buildSorted(keys[middle+1:], values[middle+1:], node, depth+1, redDepth)
+	return node
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redblacktree
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kcswag/kcgods/internal/dot"
+	"io"
+)
+
+// ToDOT writes the tree with the colors of its nodes as a Graphviz digraph to w.
+// Nodes are labelled with their key and filled with their color. Where a node has a single child, the missing
+// one is drawn as a black point, which keeps left and right children apart.
+func (tree *Tree[K, V]) ToDOT(w io.Writer) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph RedBlackTree {\n")
+	buffer.WriteString("\tnode [shape=circle, style=filled, fontcolor=white];\n")
+	id := 0
+	var output func(node *Node[K, V]) int
+	output = func(node *Node[K, V]) int {
+		nodeID := id
+		id++
+		fillColor := "black"
+		if node.color == red {
+			fillColor = "red"
+		}
+		buffer.WriteString(fmt.Sprintf("\tn%d [label=\"%s\", fillcolor=%s];\n", nodeID, dot.Escape(fmt.Sprintf("%v", node.Key)), fillColor))
+		if node.Left == nil && node.Right == nil {
+			return nodeID
+		}
+		for _, child := range []*Node[K, V]{node.Left, node.Right} {
+			if child == nil {
+				buffer.WriteString(fmt.Sprintf("\tn%d [shape=point, fillcolor=black];\n", id))
+				buffer.WriteString(fmt.Sprintf("\tn%d -> n%d;\n", nodeID, id))
+				id++
+				continue
+			}
+			buffer.WriteString(fmt.Sprintf("\tn%d -> n%d;\n", nodeID, output(child)))
+		}
+		return nodeID
+	}
+	if tree.Root != nil {
+		output(tree.Root)
+	}
+	buffer.WriteString("}\n")
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
@@ -8,7 +8,7 @@ package redblacktree
 //var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
 
 // Iterator holding the iterator's state
-type Iterator[K comparable, V any] struct {
+type Iterator[K, V any] struct {
 	tree     *Tree[K, V]
 	node     *Node[K, V]
 	position position
@@ -17,7 +17,7 @@ type Iterator[K comparable, V any] struct {
 type position byte
 
 const (
-	begin, between, end position = 0, 1, 2
+	begin, between, end, before position = 0, 1, 2, 3
 )
 
 // Iterator returns a stateful iterator whose elements are key/value pairs.
@@ -30,6 +30,16 @@ func (tree *Tree[K, V]) IteratorAt(node *Node[K, V]) Iterator[K, V] {
 	return Iterator[K, V]{tree: tree, node: node, position: between}
 }
 
+// IteratorFrom returns a stateful iterator positioned right before the first element whose key is bigger than
+// (or equal to, if inclusive) the given key, or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the element before it.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) IteratorFrom(key K, inclusive bool) Iterator[K, V] {
+	iterator := tree.Iterator()
+	iterator.seek(tree.rangeStart(key, inclusive))
+	return iterator
+}
+
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
 // If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
@@ -38,6 +48,9 @@ func (iterator *Iterator[K, V]) Next() bool {
 	if iterator.position == end {
 		goto end
 	}
+	if iterator.position == before {
+		goto between
+	}
 	if iterator.position == begin {
 		left := iterator.tree.Left()
 		if left == nil {
@@ -111,6 +124,52 @@ between:
 	return true
 }
 
+// Seek moves the iterator right before the first element whose key is equal or bigger than the given key,
+// or past the last element if there is none.
+// Call Next() to fetch that element, or Prev() to fetch the last element whose key is smaller than the given key.
+// Takes O(log n) time, moving on from there takes amortized constant time per element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) Seek(key K) {
+	iterator.seek(iterator.tree.rangeStart(key, true))
+}
+
+// SeekGE moves the iterator to the first element whose key is equal or bigger than the given key and returns true
+// if there was such an element, whose key and value can then be retrieved by Key() and Value().
+// Otherwise the iterator is moved past the last element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) SeekGE(key K) bool {
+	node, found := iterator.tree.Ceiling(key)
+	if !found {
+		iterator.End()
+		return false
+	}
+	iterator.node, iterator.position = node, between
+	return true
+}
+
+// SeekLE moves the iterator to the last element whose key is equal or smaller than the given key and returns true
+// if there was such an element, whose key and value can then be retrieved by Key() and Value().
+// Otherwise the iterator is moved before the first element.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (iterator *Iterator[K, V]) SeekLE(key K) bool {
+	node, found := iterator.tree.Floor(key)
+	if !found {
+		iterator.Begin()
+		return false
+	}
+	iterator.node, iterator.position = node, between
+	return true
+}
+
+// seek moves the iterator right before the given node or past the last element if the node is nil.
+func (iterator *Iterator[K, V]) seek(node *Node[K, V]) {
+	if node == nil {
+		iterator.End()
+		return
+	}
+	iterator.node, iterator.position = node, before
+}
+
 // Value returns the current element's value.
 // Does not modify the state of the iterator.
 func (iterator *Iterator[K, V]) Value() V {
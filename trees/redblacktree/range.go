@@ -0,0 +1,103 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redblacktree
+
+import "math/bits"
+
+// CountRange returns the number of keys between lo and hi, each bound is included if its inclusive flag is set.
+// Takes O(log n + k) time for k counted keys.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) CountRange(lo K, hi K, loInclusive bool, hiInclusive bool) int {
+	count := 0
+	for node := tree.rangeStart(lo, loInclusive); tree.beforeRangeEnd(node, hi, hiInclusive); node = node.next() {
+		count++
+	}
+	return count
+}
+
+// RemoveRange removes all keys between lo and hi, each bound is included if its inclusive flag is set,
+// and returns the number of removed keys.
+//
+// The keys are located with a single search. If they make up a large share of the tree, the remaining keys are
+// bulk-loaded into a new balanced tree in O(n), otherwise the nodes are removed one by one while walking the tree
+// in-order, without searching them again. Removing k keys takes O(log n + min(k log n, n)) time.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[K, V]) RemoveRange(lo K, hi K, loInclusive bool, hiInclusive bool) int {
+	start := tree.rangeStart(lo, loInclusive)
+	count := 0
+	for node := start; tree.beforeRangeEnd(node, hi, hiInclusive); node = node.next() {
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+
+	if count*bits.Len(uint(tree.size)) >= tree.size {
+		keys := make([]K, 0, tree.size-count)
+		values := make([]V, 0, tree.size-count)
+		for node := tree.Left(); node != nil; node = node.next() {
+			if node == start {
+				for i := 0; i < count; i++ {
+					node = node.next()
+				}
+				if node == nil {
+					break
+				}
+			}
+			keys = append(keys, node.Key)
+			values = append(values, node.Value)
+		}
+		tree.BuildFromSorted(keys, values)
+		return count
+	}
+
+	for node, i := start, 0; i < count; i++ {
+		// Removing a node at most moves its predecessor's key into it, so its successor stays in place
+		next := node.next()
+		tree.RemoveNode(node)
+		node = next
+	}
+	return count
+}
+
+// rangeStart returns the node with the smallest key greater than (or equal to, if inclusive) lo, or nil if there is none.
+func (tree *Tree[K, V]) rangeStart(lo K, inclusive bool) *Node[K, V] {
+	var start *Node[K, V]
+	node := tree.Root
+	for node != nil {
+		compare := tree.Comparator(lo, node.Key)
+		if compare < 0 || (compare == 0 && inclusive) {
+			start = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return start
+}
+
+// beforeRangeEnd returns true if the node's key is smaller than (or equal to, if inclusive) hi.
+func (tree *Tree[K, V]) beforeRangeEnd(node *Node[K, V], hi K, inclusive bool) bool {
+	if node == nil {
+		return false
+	}
+	compare := tree.Comparator(node.Key, hi)
+	return compare < 0 || (compare == 0 && inclusive)
+}
+
+// next returns the node with the next key in-order or nil if there is none.
+func (node *Node[K, V]) next() *Node[K, V] {
+	if node.Right != nil {
+		node = node.Right
+		for node.Left != nil {
+			node = node.Left
+		}
+		return node
+	}
+	for node.Parent != nil && node == node.Parent.Right {
+		node = node.Parent
+	}
+	return node.Parent
+}
@@ -26,14 +26,14 @@ const (
 )
 
 // Tree holds elements of the red-black tree
-type Tree[K comparable, V any] struct {
+type Tree[K, V any] struct {
 	Root       *Node[K, V]
 	size       int
 	Comparator utils.Comparator
 }
 
 // Node is a single element within the tree
-type Node[K comparable, V any] struct {
+type Node[K, V any] struct {
 	Key    K
 	Value  V
 	color  color
@@ -43,7 +43,7 @@ type Node[K comparable, V any] struct {
 }
 
 // NewWith instantiates a red-black tree with the custom comparator.
-func NewWith[K comparable, V any](comparator utils.Comparator) *Tree[K, V] {
+func NewWith[K, V any](comparator utils.Comparator) *Tree[K, V] {
 	return &Tree[K, V]{Comparator: comparator}
 }
 
@@ -298,7 +298,7 @@ func (node *Node[K, V]) String() string {
 	return fmt.Sprintf("%v", node.Key)
 }
 
-func output[K comparable, V any](node *Node[K, V], prefix string, isTail bool, str *string) {
+func output[K, V any](node *Node[K, V], prefix string, isTail bool, str *string) {
 	if node.Right != nil {
 		newPrefix := prefix
 		if isTail {
@@ -543,7 +543,7 @@ func (tree *Tree[K, V]) deleteCase6(node *Node[K, V]) {
 	}
 }
 
-func nodeColor[K comparable, V any](node *Node[K, V]) color {
+func nodeColor[K, V any](node *Node[K, V]) color {
 	if node == nil {
 		return black
 	}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/kcswag/kcgods/containers"
 	"github.com/kcswag/kcgods/utils"
+	"math/rand"
 	"strings"
 	"testing"
 )
@@ -699,6 +700,55 @@ func TestRedBlackTreeSerialization(t *testing.T) {
 	}
 }
 
+func TestRedBlackTreeToDOT(t *testing.T) {
+	tree := NewWithIntComparator[string]()
+	tree.Put(1, "1")
+	tree.Put(2, "2")
+	tree.Put(3, "3")
+	tree.Put(4, "4")
+	var builder strings.Builder
+	if err := tree.ToDOT(&builder); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `digraph RedBlackTree {
+	node [shape=circle, style=filled, fontcolor=white];
+	n0 [label="2", fillcolor=black];
+	n1 [label="1", fillcolor=black];
+	n0 -> n1;
+	n2 [label="3", fillcolor=black];
+	n3 [shape=point, fillcolor=black];
+	n2 -> n3;
+	n4 [label="4", fillcolor=red];
+	n2 -> n4;
+	n0 -> n2;
+}
+`
+	if actualValue := builder.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+}
+
+func TestRedBlackTreeToStructureJSON(t *testing.T) {
+	tree := NewWithIntComparator[string]()
+	tree.Put(1, "1")
+	tree.Put(2, "2")
+	tree.Put(3, "3")
+	tree.Put(4, "4")
+	structure, err := tree.ToStructureJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `{"key":2,"value":"2","color":"black","left":{"key":1,"value":"1","color":"black"},"right":{"key":3,"value":"3","color":"black","right":{"key":4,"value":"4","color":"red"}}}`
+	if actualValue := string(structure); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	structure, _ = NewWithIntComparator[string]().ToStructureJSON()
+	if actualValue, expectedValue := string(structure), "null"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestRedBlackTreeString(t *testing.T) {
 	c := NewWithStringComparator[int]()
 	c.Put("a", 1)
@@ -707,6 +757,292 @@ func TestRedBlackTreeString(t *testing.T) {
 	}
 }
 
+func TestRedBlackTreeBuildFromSorted(t *testing.T) {
+	for n := 0; n < 300; n++ {
+		keys := make([]int, n)
+		values := make([]int, n)
+		for i := range keys {
+			keys[i], values[i] = i*2, i
+		}
+		tree := NewWithIntComparator[int]()
+		tree.Put(-1, -1)
+		if err := tree.BuildFromSorted(keys, values); err != nil {
+			t.Fatalf("Got error %v", err)
+		}
+		if actualValue, expectedValue := tree.Size(), n; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(keys); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v for %d keys", err, n)
+		}
+		tree.Put(n*2+1, 0)
+		tree.Remove(0)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v for %d keys", err, n)
+		}
+	}
+}
+
+func TestRedBlackTreeBuildFromSortedInvalid(t *testing.T) {
+	tree := NewWithIntComparator[string]()
+	tree.Put(1, "a")
+	if err := tree.BuildFromSorted([]int{1, 3, 2}, []string{"a", "b", "c"}); err == nil {
+		t.Errorf("Expected error for unsorted keys")
+	}
+	if err := tree.BuildFromSorted([]int{1, 2, 2}, []string{"a", "b", "c"}); err == nil {
+		t.Errorf("Expected error for duplicate keys")
+	}
+	if err := tree.BuildFromSorted([]int{1, 2}, []string{"a"}); err == nil {
+		t.Errorf("Expected error for mismatched lengths")
+	}
+	if actualValue, expectedValue := tree.Size(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRedBlackTreeBuildFromSortedIterator(t *testing.T) {
+	source := NewWithStringComparator[int]()
+	source.Put("c", 3)
+	source.Put("a", 1)
+	source.Put("b", 2)
+	tree := NewWithStringComparator[int]()
+	it := source.Iterator()
+	if err := tree.BuildFromSortedIterator(&it); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Values()), "[a b c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRedBlackTreeValidate(t *testing.T) {
+	tree := NewWithIntComparator[int]()
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		tree.Put((i*37)%100, i)
+	}
+	for i := 0; i < 50; i++ {
+		tree.Remove((i * 13) % 100)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	tests := []struct {
+		corrupt  func(tree *Tree[int, int])
+		expected string
+	}{
+		{func(tree *Tree[int, int]) { tree.Root.Left.Key = 1000 }, "is not smaller than its ancestor"},
+		{func(tree *Tree[int, int]) { tree.Root.Right.Key = -1 }, "is not greater than its ancestor"},
+		{func(tree *Tree[int, int]) { tree.Root.Left.Parent = nil }, "has parent"},
+		{func(tree *Tree[int, int]) { tree.Root.color = red }, "root 1 is red"},
+		{func(tree *Tree[int, int]) { tree.Root.Right.Left.color = red }, "red node 3 has red child 2"},
+		{func(tree *Tree[int, int]) { tree.Root.Left.color = red }, "node 1 has black height 1 on the left and 2 on the right"},
+		{func(tree *Tree[int, int]) { tree.size++ }, "size is 8 but tree holds 7 nodes"},
+	}
+	for _, test := range tests {
+		tree := NewWithIntComparator[int]()
+		for i := 0; i < 7; i++ {
+			tree.Put(i, i)
+		}
+		// RedBlackTree
+		// │           ┌── 6 (red)
+		// │       ┌── 5
+		// │       │   └── 4 (red)
+		// │   ┌── 3 (red)
+		// │   │   └── 2
+		// └── 1
+		//     └── 0
+		test.corrupt(tree)
+		if err := tree.Validate(); err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Got %v expected %v", err, test.expected)
+		}
+	}
+}
+
+func TestRedBlackTreeCountRange(t *testing.T) {
+	tree := NewWithIntComparator[int]()
+	for i := 0; i < 20; i += 2 {
+		tree.Put(i, i)
+	}
+	for _, test := range rangeTests {
+		actualValue := tree.CountRange(test[0].(int), test[1].(int), test[2].(bool), test[3].(bool))
+		if expectedValue := test[4].(int); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+	}
+}
+
+func TestRedBlackTreeRemoveRange(t *testing.T) {
+	for _, test := range rangeTests {
+		tree := NewWithIntComparator[int]()
+		for i := 0; i < 20; i += 2 {
+			tree.Put(i, i)
+		}
+		actualValue := tree.RemoveRange(test[0].(int), test[1].(int), test[2].(bool), test[3].(bool))
+		if expectedValue := test[4].(int); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+		if actualValue, expectedValue := tree.Size(), 10-test[4].(int); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got %v expected %v for %v", err, nil, test)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), test[5]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+	}
+}
+
+func TestRedBlackTreeRemoveRangeRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		tree := NewWithIntComparator[int]()
+		size := random.Intn(300)
+		for j := 0; j < size; j++ {
+			key := random.Intn(1000)
+			tree.Put(key, key)
+		}
+		lo, hi := random.Intn(1100)-50, random.Intn(1100)-50
+		if random.Intn(2) == 0 && lo < hi {
+			hi = lo + random.Intn(10)
+		}
+		loInclusive, hiInclusive := random.Intn(2) == 0, random.Intn(2) == 0
+		var expected []int
+		removed := 0
+		for _, key := range tree.Keys() {
+			if (key > lo || loInclusive && key == lo) && (key < hi || hiInclusive && key == hi) {
+				removed++
+			} else {
+				expected = append(expected, key)
+			}
+		}
+		if actualValue := tree.CountRange(lo, hi, loInclusive, hiInclusive); actualValue != removed {
+			t.Fatalf("Got %v expected %v", actualValue, removed)
+		}
+		if actualValue := tree.RemoveRange(lo, hi, loInclusive, hiInclusive); actualValue != removed {
+			t.Fatalf("Got %v expected %v", actualValue, removed)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("Got %v expected %v", err, nil)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(expected); actualValue != expectedValue {
+			t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := tree.Size(), len(expected); actualValue != expectedValue {
+			t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+// rangeTests holds lo, hi, loInclusive, hiInclusive, the number of keys in range and the keys remaining after
+// removing them from the keys 0, 2, ..., 18
+var rangeTests = [][]interface{}{
+	{4, 10, true, true, 4, "[0 2 12 14 16 18]"},
+	{4, 10, false, true, 3, "[0 2 4 12 14 16 18]"},
+	{4, 10, true, false, 3, "[0 2 10 12 14 16 18]"},
+	{4, 10, false, false, 2, "[0 2 4 10 12 14 16 18]"},
+	{3, 11, false, false, 4, "[0 2 12 14 16 18]"},
+	{-5, 100, true, true, 10, "[]"},
+	{0, 18, false, false, 8, "[0 18]"},
+	{10, 4, true, true, 0, "[0 2 4 6 8 10 12 14 16 18]"},
+	{10, 10, true, true, 1, "[0 2 4 6 8 12 14 16 18]"},
+	{10, 10, false, true, 0, "[0 2 4 6 8 10 12 14 16 18]"},
+	{19, 30, true, true, 0, "[0 2 4 6 8 10 12 14 16 18]"},
+	{-10, -1, true, true, 0, "[0 2 4 6 8 10 12 14 16 18]"},
+}
+
+func TestRedBlackTreeIteratorFrom(t *testing.T) {
+	tree := NewWithIntComparator[string]()
+	for i := 1; i <= 5; i++ {
+		tree.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	// key, inclusive, whether Next() finds an element and its key, whether Prev() finds an element and its key
+	tests := [][]interface{}{
+		{30, true, true, 30, true, 20},
+		{30, false, true, 40, true, 30},
+		{25, true, true, 30, true, 20},
+		{25, false, true, 30, true, 20},
+		{50, false, false, 0, true, 50},
+		{60, true, false, 0, true, 50},
+		{5, true, true, 10, false, 0},
+		{10, true, true, 10, false, 0},
+	}
+	for _, test := range tests {
+		it := tree.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Next(), test[2].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Key() != test[3].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[3], test)
+		}
+		it = tree.IteratorFrom(test[0].(int), test[1].(bool))
+		if actualValue, expectedValue := it.Prev(), test[4].(bool); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		} else if actualValue && it.Key() != test[5].(int) {
+			t.Errorf("Got %v expected %v for %v", it.Key(), test[5], test)
+		}
+	}
+
+	it := tree.IteratorFrom(20, true)
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30 40 50 50 40 30 20 10]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRedBlackTreeIteratorSeek(t *testing.T) {
+	tree := NewWithIntComparator[string]()
+	for i := 1; i <= 5; i++ {
+		tree.Put(i*10, fmt.Sprintf("%d", i))
+	}
+	it := tree.Iterator()
+	assertIteratorAt := func(actualFound bool, expectedFound bool, expectedKey int) {
+		t.Helper()
+		if actualFound != expectedFound {
+			t.Errorf("Got %v expected %v", actualFound, expectedFound)
+		} else if actualFound && it.Key() != expectedKey {
+			t.Errorf("Got %v expected %v", it.Key(), expectedKey)
+		}
+	}
+	assertIteratorAt(it.SeekGE(35), true, 40)
+	assertIteratorAt(it.Next(), true, 50)
+	assertIteratorAt(it.SeekGE(40), true, 40)
+	assertIteratorAt(it.Prev(), true, 30)
+	assertIteratorAt(it.SeekGE(60), false, 0)
+	assertIteratorAt(it.Prev(), true, 50)
+	assertIteratorAt(it.SeekLE(35), true, 30)
+	assertIteratorAt(it.Prev(), true, 20)
+	assertIteratorAt(it.SeekLE(30), true, 30)
+	assertIteratorAt(it.Next(), true, 40)
+	assertIteratorAt(it.SeekLE(5), false, 0)
+	assertIteratorAt(it.Next(), true, 10)
+	it.Seek(20)
+	assertIteratorAt(it.Next(), true, 20)
+	it.Seek(21)
+	assertIteratorAt(it.Prev(), true, 20)
+	it.Seek(60)
+	assertIteratorAt(it.Next(), false, 0)
+	it.Seek(60)
+	assertIteratorAt(it.Prev(), true, 50)
+	it.Seek(0)
+	assertIteratorAt(it.Prev(), false, 0)
+}
+
 func benchmarkGet(b *testing.B, tree *Tree[int, struct{}], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
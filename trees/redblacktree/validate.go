@@ -0,0 +1,72 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redblacktree
+
+import "fmt"
+
+// Validate checks the invariants of the tree and returns an error describing the first violation found, or nil.
+//
+// It checks that keys are ordered under the comparator, that parent pointers are consistent,
+// that the root is black, that no red node has a red child, that every path from a node to its
+// leaves contains the same number of black nodes and that the cached size matches the number of nodes.
+// Useful to detect corruption, e.g. after a node's Key has been changed through the exported fields.
+func (tree *Tree[K, V]) Validate() error {
+	if tree.Root != nil {
+		if tree.Root.Parent != nil {
+			return fmt.Errorf("redblacktree: root %v has parent %v", tree.Root, tree.Root.Parent)
+		}
+		if tree.Root.color != black {
+			return fmt.Errorf("redblacktree: root %v is red", tree.Root)
+		}
+	}
+	_, count, err := tree.validate(tree.Root, nil, nil)
+	if err != nil {
+		return err
+	}
+	if count != tree.size {
+		return fmt.Errorf("redblacktree: size is %d but tree holds %d nodes", tree.size, count)
+	}
+	return nil
+}
+
+// validate checks the subtree whose keys must lie strictly between the keys of the lower and upper nodes (if any)
+// and returns its black height and number of nodes.
+func (tree *Tree[K, V]) validate(node *Node[K, V], lower *Node[K, V], upper *Node[K, V]) (blackHeight int, count int, err error) {
+	if node == nil {
+		return 1, 0, nil
+	}
+	if lower != nil && tree.Comparator(node.Key, lower.Key) <= 0 {
+		return 0, 0, fmt.Errorf("redblacktree: node %v is not greater than its ancestor %v", node, lower)
+	}
+	if upper != nil && tree.Comparator(node.Key, upper.Key) >= 0 {
+		return 0, 0, fmt.Errorf("redblacktree: node %v is not smaller than its ancestor %v", node, upper)
+	}
+	for _, child := range []*Node[K, V]{node.Left, node.Right} {
+		if child == nil {
+			continue
+		}
+		if child.Parent != node {
+			return 0, 0, fmt.Errorf("redblacktree: node %v has parent %v, expected %v", child, child.Parent, node)
+		}
+		if node.color == red && child.color == red {
+			return 0, 0, fmt.Errorf("redblacktree: red node %v has red child %v", node, child)
+		}
+	}
+	leftHeight, leftCount, err := tree.validate(node.Left, lower, node)
+	if err != nil {
+		return 0, 0, err
+	}
+	rightHeight, rightCount, err := tree.validate(node.Right, node, upper)
+	if err != nil {
+		return 0, 0, err
+	}
+	if leftHeight != rightHeight {
+		return 0, 0, fmt.Errorf("redblacktree: node %v has black height %d on the left and %d on the right", node, leftHeight, rightHeight)
+	}
+	if node.color == black {
+		leftHeight++
+	}
+	return leftHeight, leftCount + rightCount + 1, nil
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package segmenttree implements a segment tree over a fixed number of elements.
+//
+// A segment tree aggregates ranges of elements with an associative combine function,
+// e.g. addition for range sums or minimum for range minima, so that both replacing an element
+// and querying the aggregate of a range take O(log n) time.
+// Trees created with NewLazy additionally update whole ranges in O(log n) by deferring updates to the children
+// of a node until they are visited.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Segment_tree
+package segmenttree
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/lists/arraylist"
+	"strings"
+)
+
+// Assert Tree implementation
+//var _ trees.Tree[int] = (*Tree[int])(nil)
+
+// Tree holds the elements and the aggregates of their ranges.
+type Tree[E any] struct {
+	size     int
+	nodes    []E    // aggregates of the ranges, node i has the children 2i+1 and 2i+2
+	pending  []E    // range updates not yet applied to the children of a node
+	deferred []bool // whether a node has a pending range update
+	combine  func(a, b E) E
+	identity E
+	apply    func(aggregate E, update E, length int) E
+	compose  func(previous E, next E) E
+}
+
+// New instantiates a segment tree holding the values.
+//
+// The combine function must be associative and the identity must be its neutral element,
+// i.e. combine(identity, x) == combine(x, identity) == x, e.g. addition with 0 or minimum with the largest value.
+func New[E any](combine func(a, b E) E, identity E, values ...E) *Tree[E] {
+	tree := &Tree[E]{combine: combine, identity: identity}
+	tree.build(values)
+	return tree
+}
+
+// NewFromList instantiates a segment tree holding the values of the list.
+func NewFromList[E any](combine func(a, b E) E, identity E, list *arraylist.List[E]) *Tree[E] {
+	return New(combine, identity, list.Values()...)
+}
+
+// NewLazy instantiates a segment tree holding the values, which also supports range updates.
+//
+// The apply function returns the aggregate of a range of length elements after the update has been applied to each
+// of them, e.g. aggregate+update*length for adding to range sums or aggregate+update for adding to range minima.
+// The compose function merges two successive updates into one, e.g. previous+next for additions
+// or next for assignments.
+func NewLazy[E any](combine func(a, b E) E, identity E, apply func(aggregate E, update E, length int) E, compose func(previous E, next E) E, values ...E) *Tree[E] {
+	tree := &Tree[E]{combine: combine, identity: identity, apply: apply, compose: compose}
+	tree.build(values)
+	return tree
+}
+
+// NewLazyFromList instantiates a segment tree holding the values of the list, which also supports range updates.
+// See NewLazy for the meaning of the functions.
+func NewLazyFromList[E any](combine func(a, b E) E, identity E, apply func(aggregate E, update E, length int) E, compose func(previous E, next E) E, list *arraylist.List[E]) *Tree[E] {
+	return NewLazy(combine, identity, apply, compose, list.Values()...)
+}
+
+// Get returns the element at the index.
+// Second return parameter is true if index is within bounds of the tree.
+func (tree *Tree[E]) Get(index int) (value E, found bool) {
+	if !tree.withinRange(index) {
+		return tree.identity, false
+	}
+	node, from, to := 0, 0, tree.size
+	for to-from > 1 {
+		tree.push(node, to-from)
+		mid := from + (to-from)/2
+		if index < mid {
+			node, to = 2*node+1, mid
+		} else {
+			node, from = 2*node+2, mid
+		}
+	}
+	return tree.nodes[node], true
+}
+
+// Set replaces the element at the index with the value in O(log n).
+// Does not do anything if the index is out of bounds.
+func (tree *Tree[E]) Set(index int, value E) {
+	if !tree.withinRange(index) {
+		return
+	}
+	tree.set(0, 0, tree.size, index, value)
+}
+
+// Query returns the aggregate of the elements in the range [from, to) in O(log n), i.e. the elements
+// combined from left to right. The aggregate of an empty range is the identity.
+// Second return parameter is false if the range is not within bounds of the tree.
+func (tree *Tree[E]) Query(from, to int) (aggregate E, ok bool) {
+	if from < 0 || to > tree.size || from > to {
+		return tree.identity, false
+	}
+	if from == to {
+		return tree.identity, true
+	}
+	return tree.query(0, 0, tree.size, from, to), true
+}
+
+// Update applies the update to all elements in the range [from, to) in O(log n).
+// Does not do anything if the range is not within bounds of the tree.
+// It panics if the tree was not created by NewLazy or NewLazyFromList.
+func (tree *Tree[E]) Update(from, to int, update E) {
+	if tree.apply == nil {
+		panic("segmenttree: range updates require a tree created by NewLazy")
+	}
+	if from < 0 || to > tree.size || from >= to {
+		return
+	}
+	tree.update(0, 0, tree.size, from, to, update)
+}
+
+// Empty returns true if tree does not contain any elements.
+func (tree *Tree[E]) Empty() bool {
+	return tree.size == 0
+}
+
+// Size returns the number of elements within the tree.
+func (tree *Tree[E]) Size() int {
+	return tree.size
+}
+
+// Clear removes all elements from the tree.
+func (tree *Tree[E]) Clear() {
+	tree.build(nil)
+}
+
+// Values returns all elements in the tree in order of their indices.
+func (tree *Tree[E]) Values() []E {
+	values := make([]E, 0, tree.size)
+	var collect func(node, from, to int)
+	collect = func(node, from, to int) {
+		if to-from == 1 {
+			values = append(values, tree.nodes[node])
+			return
+		}
+		tree.push(node, to-from)
+		mid := from + (to-from)/2
+		collect(2*node+1, from, mid)
+		collect(2*node+2, mid, to)
+	}
+	if tree.size > 0 {
+		collect(0, 0, tree.size)
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (tree *Tree[E]) String() string {
+	str := "SegmentTree\n"
+	values := []string{}
+	for _, value := range tree.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// build replaces the elements of the tree with the values.
+func (tree *Tree[E]) build(values []E) {
+	tree.size = len(values)
+	capacity := 0
+	if tree.size > 0 {
+		capacity = 2*nextPowerOfTwo(tree.size) - 1
+	}
+	tree.nodes = make([]E, capacity)
+	if tree.apply != nil {
+		tree.pending = make([]E, capacity)
+		tree.deferred = make([]bool, capacity)
+	}
+	var build func(node, from, to int)
+	build = func(node, from, to int) {
+		if to-from == 1 {
+			tree.nodes[node] = values[from]
+			return
+		}
+		mid := from + (to-from)/2
+		build(2*node+1, from, mid)
+		build(2*node+2, mid, to)
+		tree.nodes[node] = tree.combine(tree.nodes[2*node+1], tree.nodes[2*node+2])
+	}
+	if tree.size > 0 {
+		build(0, 0, tree.size)
+	}
+}
+
+func (tree *Tree[E]) set(node, from, to, index int, value E) {
+	if to-from == 1 {
+		tree.nodes[node] = value
+		return
+	}
+	tree.push(node, to-from)
+	mid := from + (to-from)/2
+	if index < mid {
+		tree.set(2*node+1, from, mid, index, value)
+	} else {
+		tree.set(2*node+2, mid, to, index, value)
+	}
+	tree.nodes[node] = tree.combine(tree.nodes[2*node+1], tree.nodes[2*node+2])
+}
+
+// query returns the aggregate of the intersection of the node's range [from, to) with the range [lo, hi),
+// which must not be empty.
+func (tree *Tree[E]) query(node, from, to, lo, hi int) E {
+	if lo <= from && to <= hi {
+		return tree.nodes[node]
+	}
+	tree.push(node, to-from)
+	mid := from + (to-from)/2
+	if hi <= mid {
+		return tree.query(2*node+1, from, mid, lo, hi)
+	}
+	if lo >= mid {
+		return tree.query(2*node+2, mid, to, lo, hi)
+	}
+	return tree.combine(tree.query(2*node+1, from, mid, lo, hi), tree.query(2*node+2, mid, to, lo, hi))
+}
+
+func (tree *Tree[E]) update(node, from, to, lo, hi int, update E) {
+	if hi <= from || to <= lo {
+		return
+	}
+	if lo <= from && to <= hi {
+		tree.schedule(node, to-from, update)
+		return
+	}
+	tree.push(node, to-from)
+	mid := from + (to-from)/2
+	tree.update(2*node+1, from, mid, lo, hi, update)
+	tree.update(2*node+2, mid, to, lo, hi, update)
+	tree.nodes[node] = tree.combine(tree.nodes[2*node+1], tree.nodes[2*node+2])
+}
+
+// schedule applies the update to the aggregate of the node covering length elements
+// and keeps it pending for the node's children.
+func (tree *Tree[E]) schedule(node, length int, update E) {
+	tree.nodes[node] = tree.apply(tree.nodes[node], update, length)
+	if length == 1 {
+		return
+	}
+	if tree.deferred[node] {
+		tree.pending[node] = tree.compose(tree.pending[node], update)
+	} else {
+		tree.pending[node] = update
+		tree.deferred[node] = true
+	}
+}
+
+// push hands the pending update of the node covering length elements down to its children.
+func (tree *Tree[E]) push(node, length int) {
+	if tree.apply == nil || !tree.deferred[node] {
+		return
+	}
+	mid := length / 2
+	tree.schedule(2*node+1, mid, tree.pending[node])
+	tree.schedule(2*node+2, length-mid, tree.pending[node])
+	tree.pending[node] = tree.identity
+	tree.deferred[node] = false
+}
+
+// Check that the index is within bounds of the tree
+func (tree *Tree[E]) withinRange(index int) bool {
+	return index >= 0 && index < tree.size
+}
+
+// nextPowerOfTwo returns the smallest power of two that is not less than n.
+func nextPowerOfTwo(n int) int {
+	power := 1
+	for power < n {
+		power <<= 1
+	}
+	return power
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package segmenttree
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kcswag/kcgods/lists/arraylist"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func sum(a, b int) int {
+	return a + b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func addToSum(aggregate, update, length int) int {
+	return aggregate + update*length
+}
+
+func addToMin(aggregate, update, length int) int {
+	return aggregate + update
+}
+
+func TestSegmentTreeQuery(t *testing.T) {
+	tree := New(sum, 0, 5, 3, 8, 1, 4, 7)
+
+	if actualValue, expectedValue := tree.Size(), 6; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := tree.Empty(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	tests := [][]interface{}{
+		{0, 6, 28, true},
+		{0, 1, 5, true},
+		{1, 4, 12, true},
+		{2, 5, 13, true},
+		{5, 6, 7, true},
+		{3, 3, 0, true},
+		{6, 6, 0, true},
+		{-1, 2, 0, false},
+		{0, 7, 0, false},
+		{4, 3, 0, false},
+	}
+	for _, test := range tests {
+		actualValue, ok := tree.Query(test[0].(int), test[1].(int))
+		if actualValue != test[2] || ok != test[3] {
+			t.Errorf("Got %v %v expected %v %v for [%v, %v)", actualValue, ok, test[2], test[3], test[0], test[1])
+		}
+	}
+}
+
+func TestSegmentTreeSet(t *testing.T) {
+	tree := New(min, math.MaxInt, 5, 3, 8, 1, 4, 7)
+
+	tree.Set(3, 9)
+	tree.Set(6, 0)
+	tree.Set(-1, 0)
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[5 3 8 9 4 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, ok := tree.Query(2, 6); actualValue != 4 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue, ok := tree.Get(3); actualValue != 9 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+	if actualValue, ok := tree.Get(6); actualValue != math.MaxInt || ok {
+		t.Errorf("Got %v expected %v", actualValue, math.MaxInt)
+	}
+	if actualValue, ok := tree.Query(0, 0); actualValue != math.MaxInt || !ok {
+		t.Errorf("Got %v expected %v", actualValue, math.MaxInt)
+	}
+}
+
+func TestSegmentTreeCombineOrder(t *testing.T) {
+	concat := func(a, b string) string {
+		return a + b
+	}
+	tree := New(concat, "", "a", "b", "c", "d", "e")
+
+	if actualValue, _ := tree.Query(0, 5); actualValue != "abcde" {
+		t.Errorf("Got %v expected %v", actualValue, "abcde")
+	}
+	if actualValue, _ := tree.Query(1, 4); actualValue != "bcd" {
+		t.Errorf("Got %v expected %v", actualValue, "bcd")
+	}
+	tree.Set(2, "x")
+	if actualValue, _ := tree.Query(1, 5); actualValue != "bxde" {
+		t.Errorf("Got %v expected %v", actualValue, "bxde")
+	}
+}
+
+func TestSegmentTreeUpdate(t *testing.T) {
+	tree := NewLazy(sum, 0, addToSum, sum, 1, 2, 3, 4, 5, 6, 7)
+
+	tree.Update(1, 5, 10)
+	if actualValue, _ := tree.Query(0, 7); actualValue != 68 {
+		t.Errorf("Got %v expected %v", actualValue, 68)
+	}
+	if actualValue, _ := tree.Query(4, 6); actualValue != 21 {
+		t.Errorf("Got %v expected %v", actualValue, 21)
+	}
+
+	tree.Update(3, 7, -1)
+	tree.Update(0, 0, 100)
+	tree.Update(5, 8, 100)
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[1 12 13 13 14 5 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Set(2, 0)
+	if actualValue, _ := tree.Query(1, 4); actualValue != 25 {
+		t.Errorf("Got %v expected %v", actualValue, 25)
+	}
+	if actualValue, _ := tree.Get(4); actualValue != 14 {
+		t.Errorf("Got %v expected %v", actualValue, 14)
+	}
+
+	assign := func(aggregate, update, length int) int {
+		return update
+	}
+	last := func(previous, next int) int {
+		return next
+	}
+	minima := NewLazyFromList(min, math.MaxInt, assign, last, arraylist.New(5, 3, 8, 1, 4, 7))
+	minima.Update(0, 3, 6)
+	minima.Update(1, 2, 2)
+	if actualValue, _ := minima.Query(0, 3); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, expectedValue := fmt.Sprint(minima.Values()), "[6 2 6 1 4 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSegmentTreeUpdateWithoutLazy(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for range update")
+		}
+	}()
+	tree := NewFromList(sum, 0, arraylist.New(1, 2, 3))
+	tree.Update(0, 1, 1)
+}
+
+func TestSegmentTreeRandom(t *testing.T) {
+	for _, size := range []int{1, 2, 3, 17, 64, 100} {
+		values := make([]int, size)
+		for i := range values {
+			values[i] = rand.Intn(100)
+		}
+		sums := NewLazy(sum, 0, addToSum, sum, values...)
+		minima := NewLazy(min, math.MaxInt, addToMin, sum, values...)
+
+		for i := 0; i < 1000; i++ {
+			from := rand.Intn(size + 1)
+			to := from + rand.Intn(size-from+1)
+			switch rand.Intn(3) {
+			case 0:
+				index, value := rand.Intn(size), rand.Intn(100)
+				values[index] = value
+				sums.Set(index, value)
+				minima.Set(index, value)
+			case 1:
+				update := rand.Intn(21) - 10
+				for j := from; j < to; j++ {
+					values[j] += update
+				}
+				sums.Update(from, to, update)
+				minima.Update(from, to, update)
+			}
+
+			expectedSum, expectedMin := 0, math.MaxInt
+			for _, value := range values[from:to] {
+				expectedSum += value
+				expectedMin = min(expectedMin, value)
+			}
+			if actualValue, _ := sums.Query(from, to); actualValue != expectedSum {
+				t.Fatalf("Got %v expected %v for sum of [%v, %v)", actualValue, expectedSum, from, to)
+			}
+			if actualValue, _ := minima.Query(from, to); actualValue != expectedMin {
+				t.Fatalf("Got %v expected %v for minimum of [%v, %v)", actualValue, expectedMin, from, to)
+			}
+		}
+		if actualValue, expectedValue := fmt.Sprint(sums.Values()), fmt.Sprint(values); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestSegmentTreeClear(t *testing.T) {
+	tree := New(sum, 0, 1, 2, 3)
+	tree.Clear()
+
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, ok := tree.Query(0, 0); actualValue != 0 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := len(tree.Values()); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue, expectedValue := New(sum, 0).String(), "SegmentTree\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSegmentTreeSerialization(t *testing.T) {
+	tree := NewLazy(sum, 0, addToSum, sum, 1, 2, 3, 4)
+	tree.Update(0, 2, 1)
+
+	serialized, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(serialized), "[2,3,3,4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	deserialized := NewLazy(sum, 0, addToSum, sum)
+	err = json.Unmarshal([]byte(`[5,6,7]`), deserialized)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	deserialized.Update(1, 3, 1)
+	if actualValue, _ := deserialized.Query(0, 3); actualValue != 20 {
+		t.Errorf("Got %v expected %v", actualValue, 20)
+	}
+	if actualValue, expectedValue := deserialized.String(), "SegmentTree\n5, 7, 8"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkQuery(b *testing.B, tree *Tree[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Query(n/2, n+1)
+		}
+	}
+}
+
+func benchmarkUpdate(b *testing.B, tree *Tree[int], size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Update(n/2, n+1, 1)
+		}
+	}
+}
+
+func BenchmarkSegmentTreeQuery1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := New(sum, 0, make([]int, size)...)
+	b.StartTimer()
+	benchmarkQuery(b, tree, size)
+}
+
+func BenchmarkSegmentTreeQuery100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := New(sum, 0, make([]int, size)...)
+	b.StartTimer()
+	benchmarkQuery(b, tree, size)
+}
+
+func BenchmarkSegmentTreeUpdate1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewLazy(sum, 0, addToSum, sum, make([]int, size)...)
+	b.StartTimer()
+	benchmarkUpdate(b, tree, size)
+}
+
+func BenchmarkSegmentTreeUpdate100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewLazy(sum, 0, addToSum, sum, make([]int, size)...)
+	b.StartTimer()
+	benchmarkUpdate(b, tree, size)
+}
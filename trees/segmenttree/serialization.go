@@ -0,0 +1,38 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package segmenttree
+
+import (
+	"encoding/json"
+)
+
+// Assert Serialization implementation
+//var _ containers.JSONSerializer = (*Tree)(nil)
+//var _ containers.JSONDeserializer = (*Tree)(nil)
+
+// ToJSON outputs the JSON representation of the tree's elements in order of their indices.
+func (tree *Tree[E]) ToJSON() ([]byte, error) {
+	return json.Marshal(tree.Values())
+}
+
+// FromJSON replaces the tree's elements by the input JSON representation, keeping the tree's functions.
+func (tree *Tree[E]) FromJSON(data []byte) error {
+	var values []E
+	err := json.Unmarshal(data, &values)
+	if err == nil {
+		tree.build(values)
+	}
+	return err
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (tree *Tree[E]) UnmarshalJSON(bytes []byte) error {
+	return tree.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (tree *Tree[E]) MarshalJSON() ([]byte, error) {
+	return tree.ToJSON()
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topk
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/trees/binaryheap"
+	"github.com/kcswag/kcgods/utils"
+	"math"
+)
+
+// Quantile tracks the q-quantile of a stream, the elements up to the quantile are kept in a max-heap (lower)
+// and the remaining ones in a min-heap (upper).
+type Quantile[E any] struct {
+	lower      *binaryheap.Heap[E]
+	upper      *binaryheap.Heap[E]
+	q          float64
+	Comparator utils.Comparator
+}
+
+// NewQuantile instantiates a new empty tracker of the q-quantile under the custom comparator,
+// e.g. 0.9 for the 90th percentile. Panics unless q is between 0 and 1.
+func NewQuantile[E any](q float64, comparator utils.Comparator) *Quantile[E] {
+	if !(q >= 0 && q <= 1) {
+		panic("Invalid quantile, should be between 0 and 1")
+	}
+	inverted := func(a, b interface{}) int {
+		return comparator(b, a)
+	}
+	return &Quantile[E]{
+		lower:      binaryheap.NewWith[E](inverted),
+		upper:      binaryheap.NewWith[E](comparator),
+		q:          q,
+		Comparator: comparator,
+	}
+}
+
+// NewMedian instantiates a new empty tracker of the median under the custom comparator.
+func NewMedian[E any](comparator utils.Comparator) *Quantile[E] {
+	return NewQuantile[E](0.5, comparator)
+}
+
+// Offer adds the value in O(log n).
+func (quantile *Quantile[E]) Offer(value E) {
+	if top, ok := quantile.lower.Peek(); !ok || quantile.Comparator(value, top) <= 0 {
+		quantile.lower.Push(value)
+	} else {
+		quantile.upper.Push(value)
+	}
+	// The rank grows by at most one with every value, so that at most one element has to move
+	rank := quantile.rank()
+	for quantile.lower.Size() > rank {
+		value, _ := quantile.lower.Pop()
+		quantile.upper.Push(value)
+	}
+	for quantile.lower.Size() < rank {
+		value, _ := quantile.upper.Pop()
+		quantile.lower.Push(value)
+	}
+}
+
+// OfferAll adds all values.
+func (quantile *Quantile[E]) OfferAll(values ...E) {
+	for _, value := range values {
+		quantile.Offer(value)
+	}
+}
+
+// Value returns the q-quantile of the values so far by the nearest-rank method in O(1), i.e. the smallest value
+// that is equal to or larger than q*n of the n values. The median of an even number of values is the lower one.
+// Second return parameter is true, unless no values have been offered.
+func (quantile *Quantile[E]) Value() (value E, ok bool) {
+	return quantile.lower.Peek()
+}
+
+// Q returns the quantile that is tracked, e.g. 0.5 for the median.
+func (quantile *Quantile[E]) Q() float64 {
+	return quantile.q
+}
+
+// Empty returns true if no values have been offered.
+func (quantile *Quantile[E]) Empty() bool {
+	return quantile.lower.Empty()
+}
+
+// Size returns number of values that have been offered.
+func (quantile *Quantile[E]) Size() int {
+	return quantile.lower.Size() + quantile.upper.Size()
+}
+
+// Clear removes all values.
+func (quantile *Quantile[E]) Clear() {
+	quantile.lower.Clear()
+	quantile.upper.Clear()
+}
+
+// String returns a string representation of container
+func (quantile *Quantile[E]) String() string {
+	str := fmt.Sprintf("Quantile %v\n", quantile.q)
+	if value, ok := quantile.Value(); ok {
+		str += fmt.Sprintf("%v", value)
+	}
+	return str
+}
+
+// rank returns the 1-based rank of the quantile among the values, or 0 if there are none.
+func (quantile *Quantile[E]) rank() int {
+	size := quantile.Size()
+	if size == 0 {
+		return 0
+	}
+	// Tolerate rounding errors, e.g. 0.7*10 is slightly more than 7
+	rank := int(math.Ceil(quantile.q*float64(size) - 1e-9))
+	if rank < 1 {
+		return 1
+	}
+	return rank
+}
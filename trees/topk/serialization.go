@@ -0,0 +1,59 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topk
+
+import (
+	"encoding/json"
+)
+
+// Assert Serialization implementation
+//var _ containers.JSONSerializer = (*TopK)(nil)
+//var _ containers.JSONDeserializer = (*TopK)(nil)
+
+// ToJSON outputs the JSON representation of the kept elements, largest first.
+func (topK *TopK[E]) ToJSON() ([]byte, error) {
+	return json.Marshal(topK.Values())
+}
+
+// FromJSON populates the accumulator from the input JSON representation, keeping the k largest elements.
+func (topK *TopK[E]) FromJSON(data []byte) error {
+	var elements []E
+	err := json.Unmarshal(data, &elements)
+	if err == nil {
+		topK.Clear()
+		topK.OfferAll(elements...)
+	}
+	return err
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (topK *TopK[E]) UnmarshalJSON(bytes []byte) error {
+	return topK.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (topK *TopK[E]) MarshalJSON() ([]byte, error) {
+	return topK.ToJSON()
+}
+
+// ToJSON outputs the JSON representation of the kept elements, smallest first.
+func (bottomK *BottomK[E]) ToJSON() ([]byte, error) {
+	return bottomK.topK.ToJSON()
+}
+
+// FromJSON populates the accumulator from the input JSON representation, keeping the k smallest elements.
+func (bottomK *BottomK[E]) FromJSON(data []byte) error {
+	return bottomK.topK.FromJSON(data)
+}
+
+// UnmarshalJSON @implements json.Unmarshaler
+func (bottomK *BottomK[E]) UnmarshalJSON(bytes []byte) error {
+	return bottomK.FromJSON(bytes)
+}
+
+// MarshalJSON @implements json.Marshaler
+func (bottomK *BottomK[E]) MarshalJSON() ([]byte, error) {
+	return bottomK.ToJSON()
+}
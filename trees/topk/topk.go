@@ -0,0 +1,209 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package topk implements accumulators of the k largest or smallest elements of a stream, and a tracker of
+// a quantile (e.g. the median) of a stream, all built on binary heaps.
+//
+// TopK keeps the k largest elements in a min-heap of at most k elements, so that the smallest of them is at the top
+// and can be replaced in O(log k) by a larger element, while elements that are not larger are rejected in O(1).
+// BottomK does the same for the k smallest elements. Two accumulators can be merged, e.g. to combine the results
+// of several workers.
+//
+// Quantile keeps the elements up to the quantile in a max-heap and the remaining ones in a min-heap,
+// so that the quantile is at the top of the first one.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/Partial_sorting
+package topk
+
+import (
+	"fmt"
+	"github.com/kcswag/kcgods/trees/binaryheap"
+	"github.com/kcswag/kcgods/utils"
+	"strings"
+)
+
+// TopK holds the k largest elements in a min-heap.
+type TopK[E any] struct {
+	heap       *binaryheap.Heap[E]
+	k          int
+	Comparator utils.Comparator
+}
+
+// New instantiates a new empty accumulator of the k largest elements under the custom comparator.
+func New[E any](k int, comparator utils.Comparator) *TopK[E] {
+	if k < 1 {
+		panic("Invalid k, should be at least 1")
+	}
+	return &TopK[E]{heap: binaryheap.NewWith[E](comparator), k: k, Comparator: comparator}
+}
+
+// Offer adds the value if it is among the k largest elements so far, dropping the smallest element if there
+// were k elements already. Returns true if the value has been kept.
+// A value equal to the smallest element is rejected once there are k elements, i.e. earlier elements win ties.
+func (topK *TopK[E]) Offer(value E) bool {
+	if topK.heap.Size() < topK.k {
+		topK.heap.Push(value)
+		return true
+	}
+	if min, _ := topK.heap.Peek(); topK.Comparator(value, min) <= 0 {
+		return false
+	}
+	topK.heap.Replace(value)
+	return true
+}
+
+// OfferAll offers all values and returns the number of values that have been kept.
+func (topK *TopK[E]) OfferAll(values ...E) int {
+	count := 0
+	for _, value := range values {
+		if topK.Offer(value) {
+			count++
+		}
+	}
+	return count
+}
+
+// Merge offers all elements of the other accumulator, which is not modified, e.g. to combine partial results.
+// The k largest elements of both accumulators are kept, whatever the k of the other accumulator.
+func (topK *TopK[E]) Merge(other *TopK[E]) {
+	if other == topK {
+		return
+	}
+	topK.OfferAll(other.heap.Values()...)
+}
+
+// Kth returns the k-th largest element, i.e. the bound a value has to exceed to be kept.
+// Second return parameter is true, unless fewer than k elements have been offered.
+func (topK *TopK[E]) Kth() (value E, ok bool) {
+	if topK.heap.Size() < topK.k {
+		return value, false
+	}
+	return topK.heap.Peek()
+}
+
+// K returns the maximum number of elements that are kept.
+func (topK *TopK[E]) K() int {
+	return topK.k
+}
+
+// Full returns true if k elements are kept, i.e. further values have to exceed the k-th largest element.
+func (topK *TopK[E]) Full() bool {
+	return topK.heap.Size() == topK.k
+}
+
+// Empty returns true if no elements are kept.
+func (topK *TopK[E]) Empty() bool {
+	return topK.heap.Empty()
+}
+
+// Size returns number of elements that are kept, which is at most k.
+func (topK *TopK[E]) Size() int {
+	return topK.heap.Size()
+}
+
+// Clear removes all elements.
+func (topK *TopK[E]) Clear() {
+	topK.heap.Clear()
+}
+
+// Values returns the kept elements, largest first.
+func (topK *TopK[E]) Values() []E {
+	values := topK.heap.Sorted()
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (topK *TopK[E]) String() string {
+	str := "TopK\n"
+	values := []string{}
+	for _, value := range topK.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// BottomK holds the k smallest elements in a max-heap.
+type BottomK[E any] struct {
+	topK       *TopK[E] // the k largest elements under the inverted comparator
+	Comparator utils.Comparator
+}
+
+// NewBottom instantiates a new empty accumulator of the k smallest elements under the custom comparator.
+func NewBottom[E any](k int, comparator utils.Comparator) *BottomK[E] {
+	inverted := func(a, b interface{}) int {
+		return comparator(b, a)
+	}
+	return &BottomK[E]{topK: New[E](k, inverted), Comparator: comparator}
+}
+
+// Offer adds the value if it is among the k smallest elements so far, dropping the largest element if there
+// were k elements already. Returns true if the value has been kept.
+// A value equal to the largest element is rejected once there are k elements, i.e. earlier elements win ties.
+func (bottomK *BottomK[E]) Offer(value E) bool {
+	return bottomK.topK.Offer(value)
+}
+
+// OfferAll offers all values and returns the number of values that have been kept.
+func (bottomK *BottomK[E]) OfferAll(values ...E) int {
+	return bottomK.topK.OfferAll(values...)
+}
+
+// Merge offers all elements of the other accumulator, which is not modified, e.g. to combine partial results.
+// The k smallest elements of both accumulators are kept, whatever the k of the other accumulator.
+func (bottomK *BottomK[E]) Merge(other *BottomK[E]) {
+	bottomK.topK.Merge(other.topK)
+}
+
+// Kth returns the k-th smallest element, i.e. the bound a value has to fall below to be kept.
+// Second return parameter is true, unless fewer than k elements have been offered.
+func (bottomK *BottomK[E]) Kth() (value E, ok bool) {
+	return bottomK.topK.Kth()
+}
+
+// K returns the maximum number of elements that are kept.
+func (bottomK *BottomK[E]) K() int {
+	return bottomK.topK.K()
+}
+
+// Full returns true if k elements are kept, i.e. further values have to fall below the k-th smallest element.
+func (bottomK *BottomK[E]) Full() bool {
+	return bottomK.topK.Full()
+}
+
+// Empty returns true if no elements are kept.
+func (bottomK *BottomK[E]) Empty() bool {
+	return bottomK.topK.Empty()
+}
+
+// Size returns number of elements that are kept, which is at most k.
+func (bottomK *BottomK[E]) Size() int {
+	return bottomK.topK.Size()
+}
+
+// Clear removes all elements.
+func (bottomK *BottomK[E]) Clear() {
+	bottomK.topK.Clear()
+}
+
+// Values returns the kept elements, smallest first.
+func (bottomK *BottomK[E]) Values() []E {
+	return bottomK.topK.Values()
+}
+
+// String returns a string representation of container
+func (bottomK *BottomK[E]) String() string {
+	str := "BottomK\n"
+	values := []string{}
+	for _, value := range bottomK.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topk
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kcswag/kcgods/utils"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTopKOffer(t *testing.T) {
+	topK := New[int](3, utils.IntComparator)
+	if actualValue := topK.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if _, ok := topK.Kth(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	tests := [][]interface{}{
+		{5, true, "[5]"},
+		{1, true, "[5 1]"},
+		{3, true, "[5 3 1]"},
+		{0, false, "[5 3 1]"},
+		{4, true, "[5 4 3]"},
+		{3, false, "[5 4 3]"},
+		{9, true, "[9 5 4]"},
+	}
+	for _, test := range tests {
+		if actualValue := topK.Offer(test[0].(int)); actualValue != test[1] {
+			t.Errorf("Got %v expected %v for %v", actualValue, test[1], test[0])
+		}
+		if actualValue := fmt.Sprint(topK.Values()); actualValue != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[2])
+		}
+	}
+	if actualValue, ok := topK.Kth(); actualValue != 4 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := topK.Full(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := topK.K(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue := topK.OfferAll(10, 2, 11, 4); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, expectedValue := topK.String(), "TopK\n11, 10, 9"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	topK.Clear()
+	if actualValue := topK.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestBottomKOffer(t *testing.T) {
+	bottomK := NewBottom[string](2, utils.StringComparator)
+	for _, value := range []string{"d", "b", "e", "a", "c"} {
+		bottomK.Offer(value)
+	}
+	if actualValue := fmt.Sprint(bottomK.Values()); actualValue != "[a b]" {
+		t.Errorf("Got %v expected %v", actualValue, "[a b]")
+	}
+	if actualValue, ok := bottomK.Kth(); actualValue != "b" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+	if actualValue := bottomK.Offer("b"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, expectedValue := bottomK.String(), "BottomK\na, b"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTopKMerge(t *testing.T) {
+	// Every worker keeps the top 5 of its share, the merged accumulator has the top 5 of all values
+	values := rand.Perm(1000)
+	workers := make([]*TopK[int], 4)
+	for i := range workers {
+		workers[i] = New[int](5, utils.IntComparator)
+	}
+	for i, value := range values {
+		workers[i%len(workers)].Offer(value)
+	}
+	merged := New[int](5, utils.IntComparator)
+	for _, worker := range workers {
+		merged.Merge(worker)
+	}
+	merged.Merge(merged)
+	if actualValue := fmt.Sprint(merged.Values()); actualValue != "[999 998 997 996 995]" {
+		t.Errorf("Got %v expected %v", actualValue, "[999 998 997 996 995]")
+	}
+	if actualValue := workers[0].Size(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+
+	bottomK := NewBottom[int](3, utils.IntComparator)
+	bottomK.OfferAll(5, 7, 9)
+	other := NewBottom[int](2, utils.IntComparator)
+	other.OfferAll(8, 1, 6)
+	bottomK.Merge(other)
+	if actualValue := fmt.Sprint(bottomK.Values()); actualValue != "[1 5 6]" {
+		t.Errorf("Got %v expected %v", actualValue, "[1 5 6]")
+	}
+}
+
+func TestTopKRandom(t *testing.T) {
+	for _, k := range []int{1, 7, 100} {
+		topK := New[int](k, utils.IntComparator)
+		bottomK := NewBottom[int](k, utils.IntComparator)
+		values := make([]int, 500)
+		for i := range values {
+			values[i] = rand.Intn(200)
+			topK.Offer(values[i])
+			bottomK.Offer(values[i])
+		}
+		utils.Sort(values, utils.IntComparator)
+		for i, value := range topK.Values() {
+			if expectedValue := values[len(values)-1-i]; value != expectedValue {
+				t.Fatalf("Got %v expected %v", value, expectedValue)
+			}
+		}
+		for i, value := range bottomK.Values() {
+			if expectedValue := values[i]; value != expectedValue {
+				t.Fatalf("Got %v expected %v", value, expectedValue)
+			}
+		}
+	}
+}
+
+func TestTopKSerialization(t *testing.T) {
+	topK := New[int](3, utils.IntComparator)
+	topK.OfferAll(4, 8, 1, 6)
+
+	bytes, err := json.Marshal(topK)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue := string(bytes); actualValue != "[8,6,4]" {
+		t.Errorf("Got %v expected %v", actualValue, "[8,6,4]")
+	}
+
+	newTopK := New[int](2, utils.IntComparator)
+	if err := json.Unmarshal(bytes, newTopK); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue := fmt.Sprint(newTopK.Values()); actualValue != "[8 6]" {
+		t.Errorf("Got %v expected %v", actualValue, "[8 6]")
+	}
+
+	bottomK := NewBottom[int](3, utils.IntComparator)
+	if err := bottomK.FromJSON([]byte(`[4,8,1,6]`)); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if bytes, _ := bottomK.ToJSON(); string(bytes) != "[1,4,6]" {
+		t.Errorf("Got %v expected %v", string(bytes), "[1,4,6]")
+	}
+}
+
+func TestTopKInvalidK(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for k %v", 0)
+		}
+	}()
+	New[int](0, utils.IntComparator)
+}
+
+func TestQuantileMedian(t *testing.T) {
+	median := NewMedian[int](utils.IntComparator)
+	if _, ok := median.Value(); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	tests := [][]interface{}{
+		{5, 5},
+		{1, 1},
+		{9, 5},
+		{7, 5},
+		{8, 7},
+		{2, 5},
+		{3, 5},
+		{0, 3},
+	}
+	for _, test := range tests {
+		median.Offer(test[0].(int))
+		if actualValue, ok := median.Value(); actualValue != test[1] || !ok {
+			t.Errorf("Got %v expected %v after %v", actualValue, test[1], test[0])
+		}
+	}
+	if actualValue := median.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue, expectedValue := median.String(), "Quantile 0.5\n3"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	median.Clear()
+	if actualValue := median.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestQuantileRandom(t *testing.T) {
+	for _, q := range []float64{0, 0.1, 0.25, 0.5, 0.7, 0.9, 0.99, 1} {
+		quantile := NewQuantile[int](q, utils.IntComparator)
+		var values []int
+		for i := 0; i < 300; i++ {
+			value := rand.Intn(100)
+			quantile.Offer(value)
+			values = append(values, value)
+
+			sorted := make([]int, len(values))
+			copy(sorted, values)
+			utils.Sort(sorted, utils.IntComparator)
+			rank := int(math.Ceil(q*float64(len(sorted)) - 1e-9))
+			if rank < 1 {
+				rank = 1
+			}
+			if actualValue, _ := quantile.Value(); actualValue != sorted[rank-1] {
+				t.Fatalf("Got %v expected %v for q %v and %v values", actualValue, sorted[rank-1], q, len(sorted))
+			}
+		}
+		if actualValue := quantile.Q(); actualValue != q {
+			t.Errorf("Got %v expected %v", actualValue, q)
+		}
+	}
+}
+
+func TestQuantileInvalid(t *testing.T) {
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Got no panic for quantile %v", q)
+				}
+			}()
+			NewQuantile[int](q, utils.IntComparator)
+		}()
+	}
+}
+
+func benchmarkOffer(b *testing.B, topK *TopK[int], values []int) {
+	for i := 0; i < b.N; i++ {
+		for _, value := range values {
+			topK.Offer(value)
+		}
+	}
+}
+
+func BenchmarkTopK10Offer10000(b *testing.B) {
+	b.StopTimer()
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = rand.Intn(10000 * 3)
+	}
+	topK := New[int](10, utils.IntComparator)
+	b.StartTimer()
+	benchmarkOffer(b, topK, values)
+}
+
+func BenchmarkTopK1000Offer10000(b *testing.B) {
+	b.StopTimer()
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = rand.Intn(10000 * 3)
+	}
+	topK := New[int](1000, utils.IntComparator)
+	b.StartTimer()
+	benchmarkOffer(b, topK, values)
+}
+
+func BenchmarkMedianOffer10000(b *testing.B) {
+	b.StopTimer()
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = rand.Intn(10000 * 3)
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		median := NewMedian[int](utils.IntComparator)
+		median.OfferAll(values...)
+	}
+}
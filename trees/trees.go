@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package trees provides an abstract Tree interface.
+// Package trees provides abstract Tree and Heap interfaces.
 //
 // In computer science, a tree is a widely used abstract data type (ADT) or data structure implementing this ADT that simulates a hierarchical tree structure, with a root value and subtrees of children with a parent node, represented as a set of linked nodes.
 //
@@ -15,3 +15,20 @@ import "github.com/kcswag/kcgods/containers"
 type Tree[E any] interface {
 	containers.Container[E]
 }
+
+// Heap interface that all heaps implement
+type Heap[E any] interface {
+	// Push adds the values onto the heap.
+	Push(values ...E)
+	// Pop removes the top element of the heap and returns it.
+	// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+	Pop() (value E, ok bool)
+	// Peek returns the top element of the heap without removing it.
+	// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+	Peek() (value E, ok bool)
+	// Meld moves all elements of the other heap, which must order its elements the same way, into the heap
+	// and leaves the other heap empty.
+	Meld(other Heap[E])
+
+	Tree[E]
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trees_test
+
+import (
+	"github.com/kcswag/kcgods/trees"
+	"github.com/kcswag/kcgods/trees/binaryheap"
+	"github.com/kcswag/kcgods/trees/daryheap"
+	"github.com/kcswag/kcgods/trees/fibonacciheap"
+	"github.com/kcswag/kcgods/trees/minmaxheap"
+	"github.com/kcswag/kcgods/trees/pairingheap"
+	"math/rand"
+	"testing"
+)
+
+var heaps = []struct {
+	name string
+	new  func() trees.Heap[int]
+}{
+	{"BinaryHeap", func() trees.Heap[int] { return binaryheap.NewWithIntComparator() }},
+	{"DaryHeap4", func() trees.Heap[int] { return daryheap.NewWithIntComparator(4) }},
+	{"PairingHeap", func() trees.Heap[int] { return pairingheap.NewWithIntComparator() }},
+	{"FibonacciHeap", func() trees.Heap[int] { return fibonacciheap.NewWithIntComparator() }},
+	{"MinMaxHeap", func() trees.Heap[int] { return minmaxheap.NewWithIntComparator() }},
+}
+
+func TestHeaps(t *testing.T) {
+	for _, test := range heaps {
+		heap, other := test.new(), test.new()
+		values := rand.Perm(1000)
+		for _, value := range values[:500] {
+			heap.Push(value)
+		}
+		other.Push(values[500:]...)
+		heap.Meld(other)
+		if actualValue := other.Empty(); actualValue != true {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, true)
+		}
+		if actualValue := heap.Size(); actualValue != 1000 {
+			t.Errorf("%v: Got %v expected %v", test.name, actualValue, 1000)
+		}
+		for expectedValue := 0; expectedValue < 1000; expectedValue++ {
+			if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+				t.Fatalf("%v: Got %v expected %v", test.name, actualValue, expectedValue)
+			}
+		}
+	}
+}
+
+func benchmarkPushPop(b *testing.B, newHeap func() trees.Heap[int], size int) {
+	b.StopTimer()
+	values := rand.Perm(size)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		heap := newHeap()
+		for _, value := range values {
+			heap.Push(value)
+		}
+		for !heap.Empty() {
+			heap.Pop()
+		}
+	}
+}
+
+func benchmarkMeld(b *testing.B, newHeap func() trees.Heap[int], size int) {
+	b.StopTimer()
+	values := rand.Perm(size)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		heap, other := newHeap(), newHeap()
+		for _, value := range values {
+			heap.Push(value)
+			other.Push(value)
+		}
+		heap.Meld(other)
+		heap.Pop()
+	}
+}
+
+func BenchmarkHeapsPushPop100(b *testing.B) {
+	for _, test := range heaps {
+		b.Run(test.name, func(b *testing.B) {
+			benchmarkPushPop(b, test.new, 100)
+		})
+	}
+}
+
+func BenchmarkHeapsPushPop10000(b *testing.B) {
+	for _, test := range heaps {
+		b.Run(test.name, func(b *testing.B) {
+			benchmarkPushPop(b, test.new, 10000)
+		})
+	}
+}
+
+func BenchmarkHeapsMeld100(b *testing.B) {
+	for _, test := range heaps {
+		b.Run(test.name, func(b *testing.B) {
+			benchmarkMeld(b, test.new, 100)
+		})
+	}
+}
+
+func BenchmarkHeapsMeld10000(b *testing.B) {
+	for _, test := range heaps {
+		b.Run(test.name, func(b *testing.B) {
+			benchmarkMeld(b, test.new, 10000)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import "reflect"
+
+// Equals tells whether two values are equal, e.g. to look up values in a list.
+// It can express domain equality, e.g. two entities with the same ID.
+type Equals[T any] func(a, b T) bool
+
+// ComparableEquals compares the values with ==.
+func ComparableEquals[T comparable](a, b T) bool {
+	return a == b
+}
+
+// DeepEquals compares the values with reflect.DeepEqual, i.e. pointers, slices and maps are compared by the values
+// they refer to.
+func DeepEquals[T any](a, b T) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// DefaultEquals returns the equality used by containers that are not given one.
+// Values that consist only of booleans, numbers and strings (including arrays and structs of them) are compared
+// with ==, which is much faster than reflect.DeepEqual and gives the same result. All other values, e.g. pointers
+// or interfaces, are compared with DeepEquals.
+func DefaultEquals[T any]() Equals[T] {
+	// Common types are compared directly, others through interfaces
+	switch any(*new(T)).(type) {
+	case int:
+		return any(ComparableEquals[int]).(func(a, b T) bool)
+	case int64:
+		return any(ComparableEquals[int64]).(func(a, b T) bool)
+	case uint64:
+		return any(ComparableEquals[uint64]).(func(a, b T) bool)
+	case float64:
+		return any(ComparableEquals[float64]).(func(a, b T) bool)
+	case string:
+		return any(ComparableEquals[string]).(func(a, b T) bool)
+	}
+	if flat(reflect.TypeOf((*T)(nil)).Elem()) {
+		return func(a, b T) bool {
+			return any(a) == any(b)
+		}
+	}
+	return DeepEquals[T]
+}
+
+// flat returns true if == and reflect.DeepEqual agree on the values of the type.
+func flat(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return flat(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !flat(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
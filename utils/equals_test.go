@@ -0,0 +1,111 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+type point struct {
+	x, y int
+	name string
+}
+
+type node struct {
+	value int
+	next  *node
+}
+
+func TestDefaultEqualsFlat(t *testing.T) {
+	if actualValue := DefaultEquals[int]()(1, 1); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := DefaultEquals[int]()(1, 2); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := DefaultEquals[string]()("a", "a"); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := DefaultEquals[float64]()(math.NaN(), math.NaN()); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := DefaultEquals[point]()(point{1, 2, "a"}, point{1, 2, "a"}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := DefaultEquals[point]()(point{1, 2, "a"}, point{1, 2, "b"}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := DefaultEquals[[2]point]()([2]point{{x: 1}}, [2]point{{x: 1}}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestDefaultEqualsDeep(t *testing.T) {
+	// Pointers are compared by the values they refer to, same as reflect.DeepEqual
+	if actualValue := DefaultEquals[*node]()(&node{value: 1}, &node{value: 1}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := DefaultEquals[node]()(node{1, &node{value: 2}}, node{1, &node{value: 3}}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := DefaultEquals[[]int]()([]int{1, 2}, []int{1, 2}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := DefaultEquals[any]()([]int{1}, []int{1}); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := DefaultEquals[any]()(1, "1"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestComparableEquals(t *testing.T) {
+	a, b := &node{value: 1}, &node{value: 1}
+	if actualValue := ComparableEquals(a, b); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := ComparableEquals(a, a); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := DeepEquals(a, b); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func benchmarkEquals[T any](b *testing.B, equals Equals[T], values []T, value T) {
+	for i := 0; i < b.N; i++ {
+		for _, v := range values {
+			if equals(v, value) {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkDeepEqualsInt(b *testing.B) {
+	values := make([]int, 1000)
+	benchmarkEquals(b, DeepEquals[int], values, 1)
+}
+
+func BenchmarkDefaultEqualsInt(b *testing.B) {
+	values := make([]int, 1000)
+	benchmarkEquals(b, DefaultEquals[int](), values, 1)
+}
+
+func BenchmarkComparableEqualsInt(b *testing.B) {
+	values := make([]int, 1000)
+	benchmarkEquals(b, ComparableEquals[int], values, 1)
+}
+
+func BenchmarkDeepEqualsStruct(b *testing.B) {
+	values := make([]point, 1000)
+	benchmarkEquals(b, DeepEquals[point], values, point{name: "a"})
+}
+
+func BenchmarkDefaultEqualsStruct(b *testing.B) {
+	values := make([]point, 1000)
+	benchmarkEquals(b, DefaultEquals[point](), values, point{name: "a"})
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+)
+
+// MarshalKey converts a key to the name of a JSON object member.
+// Keys implementing encoding.TextMarshaler are converted by MarshalText, string keys are used as is
+// and any other key is converted to its JSON encoding, e.g. 1 to "1" and a struct to "{\"A\":1}".
+func MarshalKey(key interface{}) (string, error) {
+	if marshaler, ok := key.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	if value := reflect.ValueOf(key); value.Kind() == reflect.String {
+		return value.String(), nil
+	}
+	data, err := json.Marshal(key)
+	return string(data), err
+}
+
+// UnmarshalKey converts the name of a JSON object member written by MarshalKey back to a key.
+func UnmarshalKey[K any](name string) (K, error) {
+	var key K
+	if unmarshaler, ok := interface{}(&key).(encoding.TextUnmarshaler); ok {
+		err := unmarshaler.UnmarshalText([]byte(name))
+		return key, err
+	}
+	if value := reflect.ValueOf(&key).Elem(); value.Kind() == reflect.String {
+		value.SetString(name)
+		return key, nil
+	}
+	err := json.Unmarshal([]byte(name), &key)
+	return key, err
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2022, Kinson Chow. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMarshalKey(t *testing.T) {
+	type name string
+	type point struct {
+		X, Y int
+	}
+	date := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := [][]interface{}{
+		{"a", "a"},
+		{"", ""},
+		{name("b"), "b"},
+		{1, "1"},
+		{-1.5, "-1.5"},
+		{true, "true"},
+		{point{1, 2}, `{"X":1,"Y":2}`},
+		{[]int{1, 2}, "[1,2]"},
+		{date, "2022-01-02T03:04:05Z"},
+	}
+	for _, test := range tests {
+		actualValue, err := MarshalKey(test[0])
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if expectedValue := test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	if _, err := MarshalKey(func() {}); err == nil {
+		t.Errorf("Got no error for unsupported key")
+	}
+}
+
+func TestUnmarshalKey(t *testing.T) {
+	type name string
+	type point struct {
+		X, Y int
+	}
+
+	assert := func(actualValue interface{}, err error, expectedValue interface{}) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if fmt.Sprint(actualValue) != fmt.Sprint(expectedValue) {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	s, err := UnmarshalKey[string]("1")
+	assert(s, err, "1")
+	s, err = UnmarshalKey[string](`"a"`)
+	assert(s, err, `"a"`)
+	n, err := UnmarshalKey[name]("b")
+	assert(n, err, name("b"))
+	i, err := UnmarshalKey[int]("-1")
+	assert(i, err, -1)
+	f, err := UnmarshalKey[float64]("1.5")
+	assert(f, err, 1.5)
+	p, err := UnmarshalKey[point](`{"X":1,"Y":2}`)
+	assert(p, err, point{1, 2})
+	l, err := UnmarshalKey[[]int]("[1,2]")
+	assert(l, err, []int{1, 2})
+	d, err := UnmarshalKey[time.Time]("2022-01-02T03:04:05Z")
+	assert(d, err, time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	if _, err := UnmarshalKey[int]("a"); err == nil {
+		t.Errorf("Got no error for invalid key")
+	}
+}
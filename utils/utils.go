@@ -7,6 +7,7 @@
 // Provided functionalities:
 // - sorting
 // - comparators
+// - JSON object keys
 package utils
 
 import (